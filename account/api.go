@@ -82,6 +82,10 @@ func (api *PrivateAPI) GetRawNameCard(idStr string) (*NameCard, error) {
 	return api.b.GetRawNameCard([]byte(idStr))
 }
 
+func (api *PrivateAPI) GetRawUserProfile(idStr string) (*UserProfile, error) {
+	return api.b.GetRawUserProfile([]byte(idStr))
+}
+
 func (api *PrivateAPI) GetRawProfile(idStr string) (*Profile, error) {
 	return api.b.GetRawProfile([]byte(idStr))
 }
@@ -253,3 +257,15 @@ func (api *PublicAPI) GetNameCardByIDs(idStrs []string) (map[string]*BackendName
 	}
 	return api.b.GetNameCardByIDs(idByteList)
 }
+
+func (api *PublicAPI) GetUserProfile(idStr string) (*BackendUserProfile, error) {
+	return api.b.GetUserProfile([]byte(idStr))
+}
+
+func (api *PublicAPI) GetUserProfileByIDs(idStrs []string) (map[string]*BackendUserProfile, error) {
+	idByteList := make([][]byte, len(idStrs))
+	for i, idStr := range idStrs {
+		idByteList[i] = []byte(idStr)
+	}
+	return api.b.GetUserProfileByIDs(idByteList)
+}
@@ -304,3 +304,54 @@ func (b *Backend) GetNameCardByIDs(idByteList [][]byte) (map[string]*BackendName
 
 	return backendNameCards, nil
 }
+
+/**********
+ * User Profile
+ **********/
+
+func (b *Backend) GetRawUserProfile(idBytes []byte) (*UserProfile, error) {
+
+	id, err := types.UnmarshalTextPttID(idBytes, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.GetRawUserProfileByID(id)
+
+}
+
+func (b *Backend) GetRawUserProfileByID(id *types.PttID) (*UserProfile, error) {
+
+	spm := b.SPM().(*ServiceProtocolManager)
+	return spm.GetUserProfileByID(id)
+}
+
+func (b *Backend) GetUserProfile(idBytes []byte) (*BackendUserProfile, error) {
+
+	u, err := b.GetRawUserProfile(idBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return userProfileToBackendUserProfile(u), nil
+}
+
+func (b *Backend) GetUserProfileByIDs(idByteList [][]byte) (map[string]*BackendUserProfile, error) {
+
+	backendUserProfiles := make(map[string]*BackendUserProfile)
+
+	var u *BackendUserProfile
+	var err error
+
+	for _, idBytes := range idByteList {
+
+		u, err = b.GetUserProfile(idBytes)
+		if err != nil {
+			continue
+		}
+
+		backendUserProfiles[string(idBytes)] = u
+	}
+
+	return backendUserProfiles, nil
+}
@@ -59,3 +59,32 @@ func userNameToBackendNameCard(u *NameCard) *BackendNameCard {
 		Card: u.Card,
 	}
 }
+
+type BackendProfileField struct {
+	Key        string            `json:"K"`
+	Value      string            `json:"V"`
+	Visibility ProfileVisibility `json:"v"`
+	BoardIDs   []*types.PttID    `json:"B,omitempty"`
+}
+
+type BackendUserProfile struct {
+	ID     *types.PttID
+	Fields []*BackendProfileField `json:"F"`
+}
+
+func userProfileToBackendUserProfile(u *UserProfile) *BackendUserProfile {
+	fields := make([]*BackendProfileField, len(u.Fields))
+	for i, f := range u.Fields {
+		fields[i] = &BackendProfileField{
+			Key:        f.Key,
+			Value:      f.Value,
+			Visibility: f.Visibility,
+			BoardIDs:   f.BoardIDs,
+		}
+	}
+
+	return &BackendUserProfile{
+		ID:     u.ID,
+		Fields: fields,
+	}
+}
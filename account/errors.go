@@ -21,4 +21,6 @@ import "errors"
 var (
 	ErrInvalidImg  = errors.New("invalid img")
 	ErrInvalidName = errors.New("invalid name")
+
+	ErrInvalidProfileField = errors.New("invalid profile field")
 )
@@ -88,6 +88,16 @@ const (
 
 	ForceSyncNameCardMsg
 	ForceSyncNameCardAckMsg
+
+	// user-profile
+	SyncCreateUserProfileMsg
+	SyncCreateUserProfileAckMsg
+
+	SyncUpdateUserProfileMsg
+	SyncUpdateUserProfileAckMsg
+
+	ForceSyncUserProfileMsg
+	ForceSyncUserProfileAckMsg
 )
 
 // user-profile
@@ -102,6 +112,21 @@ const (
 	ProfileImageMaskRatio = 0.8
 )
 
+// profile-field
+const (
+	ProfileFieldKeyBio      = "bio"
+	ProfileFieldKeyLink     = "link"
+	ProfileFieldKeyLocation = "location"
+	ProfileFieldKeyPronouns = "pronouns"
+
+	MaxProfileFields           = 32
+	MaxProfileFieldKeyLength   = 32
+	MaxProfileFieldValueLength = 1024
+	MaxProfileFieldBoards      = 16
+
+	SizeProfileFieldSalt = 16
+)
+
 // db
 var (
 	dbAccount     *pttdb.LDBBatch    = nil
@@ -118,6 +143,9 @@ var (
 	DBNameCardPrefix    = []byte(".ncdb")
 	DBNameCardIdxPrefix = []byte(".ncix")
 
+	DBUserProfilePrefix    = []byte(".updb")
+	DBUserProfileIdxPrefix = []byte(".upix")
+
 	DBUserNodePrefix     = []byte(".undb")
 	DBUserNodeIdxPrefix  = []byte(".unix")
 	DBUserNodeInfoPrefix = []byte(".uidb")
@@ -25,17 +25,19 @@ func NewEmptyApproveJoinProfile() *ApproveJoinEntity {
 		ApproveJoinEntity: &pkgservice.ApproveJoinEntity{
 			Entity: NewEmptyProfile(),
 		},
-		UserName: NewEmptyUserName(),
-		UserImg:  NewEmptyUserImg(),
-		NameCard: NewEmptyNameCard(),
+		UserName:    NewEmptyUserName(),
+		UserImg:     NewEmptyUserImg(),
+		NameCard:    NewEmptyNameCard(),
+		UserProfile: NewEmptyUserProfile(),
 	}
 }
 
 type ApproveJoinEntity struct {
 	*pkgservice.ApproveJoinEntity `json:"b"`
-	UserName                      *UserName `json:"n"`
-	UserImg                       *UserImg  `json:"i"`
-	NameCard                      *NameCard `json:"c"`
+	UserName                      *UserName    `json:"n"`
+	UserImg                       *UserImg     `json:"i"`
+	NameCard                      *NameCard    `json:"c"`
+	UserProfile                   *UserProfile `json:"p,omitempty"`
 }
 
 func (pm *ProtocolManager) ApproveJoin(
@@ -67,11 +69,21 @@ func (pm *ProtocolManager) ApproveJoin(
 		return nil, nil, err
 	}
 
+	// user-profile (nil if the profile is created before having the user-profile.)
+	userProfile, err := spm.GetUserProfileByID(userID)
+	if err != nil {
+		userProfile = nil
+	} else {
+		userProfile.Fields = filterProfileFields(userProfile.Fields, pm.profileFieldViewer(peer))
+		userProfile.SyncInfo = nil
+	}
+
 	data := &ApproveJoinEntity{
 		ApproveJoinEntity: approveJoinEntity.(*pkgservice.ApproveJoinEntity),
 		UserName:          userName,
 		UserImg:           userImg,
 		NameCard:          nameCard,
+		UserProfile:       userProfile,
 	}
 
 	return keyInfo, data, nil
@@ -50,5 +50,14 @@ func (pm *ProtocolManager) CleanObject() error {
 		nameCard.Delete(false)
 	}
 
+	// user-profile
+	userProfile := NewEmptyUserProfile()
+	pm.SetUserProfileDB(userProfile)
+	userProfile.SetID(profile.MyID)
+	err = userProfile.Get(false)
+	if err == nil {
+		userProfile.Delete(false)
+	}
+
 	return nil
 }
@@ -71,6 +71,14 @@ func (spm *ServiceProtocolManager) CreateJoinEntity(
 		return nil, err
 	}
 
+	// user profile
+	if approveJoin.UserProfile != nil && approveJoin.UserProfile.ID != nil {
+		err = pm.createJoinEntityUserProfile(approveJoin.UserProfile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return entity, nil
 }
 
@@ -148,3 +156,28 @@ func (pm *ProtocolManager) createJoinEntityNameCard(nameCard *NameCard) error {
 
 	return nil
 }
+
+func (pm *ProtocolManager) createJoinEntityUserProfile(userProfile *UserProfile) error {
+	pm.SetUserProfileDB(userProfile)
+
+	err := userProfile.Lock()
+	if err != nil {
+		return err
+	}
+	defer userProfile.Unlock()
+
+	origUserProfile := NewEmptyUserProfile()
+	pm.SetUserProfileDB(origUserProfile)
+	origUserProfile.SetID(userProfile.GetID())
+
+	err = origUserProfile.GetByID(true)
+	if err == nil {
+		if userProfile.UpdateTS.IsLess(origUserProfile.UpdateTS) {
+			return nil
+		}
+	}
+
+	userProfile.Save(true)
+
+	return nil
+}
@@ -59,6 +59,11 @@ func (spm *ServiceProtocolManager) CreateProfile() (*Profile, error) {
 		return nil, err
 	}
 
+	err = pm.CreateUserProfile()
+	if err != nil {
+		return nil, err
+	}
+
 	return profile, nil
 }
 
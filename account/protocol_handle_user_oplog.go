@@ -30,6 +30,9 @@ type ProcessUserInfo struct {
 	UserNodeInfo       map[types.PttID]*pkgservice.BaseOplog
 	CreateNameCardInfo map[types.PttID]*pkgservice.BaseOplog
 	NameCardInfo       map[types.PttID]*pkgservice.BaseOplog
+
+	CreateUserProfileInfo map[types.PttID]*pkgservice.BaseOplog
+	UserProfileInfo       map[types.PttID]*pkgservice.BaseOplog
 }
 
 func NewProcessUserInfo() *ProcessUserInfo {
@@ -42,6 +45,9 @@ func NewProcessUserInfo() *ProcessUserInfo {
 		UserNodeInfo:       make(map[types.PttID]*pkgservice.BaseOplog),
 		CreateNameCardInfo: make(map[types.PttID]*pkgservice.BaseOplog),
 		NameCardInfo:       make(map[types.PttID]*pkgservice.BaseOplog),
+
+		CreateUserProfileInfo: make(map[types.PttID]*pkgservice.BaseOplog),
+		UserProfileInfo:       make(map[types.PttID]*pkgservice.BaseOplog),
 	}
 }
 
@@ -75,6 +81,11 @@ func (pm *ProtocolManager) processUserLog(oplog *pkgservice.BaseOplog, processIn
 		origLogs, err = pm.handleCreateNameCardLogs(oplog, info)
 	case UserOpTypeUpdateNameCard:
 		origLogs, err = pm.handleUpdateNameCardLogs(oplog, info)
+
+	case UserOpTypeCreateUserProfile:
+		origLogs, err = pm.handleCreateUserProfileLogs(oplog, info)
+	case UserOpTypeUpdateUserProfile:
+		origLogs, err = pm.handleUpdateUserProfileLogs(oplog, info)
 	}
 	return
 }
@@ -109,6 +120,11 @@ func (pm *ProtocolManager) processPendingUserLog(oplog *pkgservice.BaseOplog, pr
 		isToSign, origLogs, err = pm.handlePendingCreateNameCardLogs(oplog, info)
 	case UserOpTypeUpdateNameCard:
 		isToSign, origLogs, err = pm.handlePendingUpdateNameCardLogs(oplog, info)
+
+	case UserOpTypeCreateUserProfile:
+		isToSign, origLogs, err = pm.handlePendingCreateUserProfileLogs(oplog, info)
+	case UserOpTypeUpdateUserProfile:
+		isToSign, origLogs, err = pm.handlePendingUpdateUserProfileLogs(oplog, info)
 	}
 	return
 }
@@ -152,6 +168,14 @@ func (pm *ProtocolManager) postprocessUserOplogs(processInfo pkgservice.ProcessI
 	pm.SyncNameCard(SyncCreateNameCardMsg, createNameCardIDs, peer)
 	pm.SyncNameCard(SyncUpdateNameCardMsg, updateNameCardIDs, peer)
 
+	// user profile
+	createUserProfileIDs := pkgservice.ProcessInfoToSyncIDList(info.CreateUserProfileInfo, UserOpTypeCreateUserProfile)
+
+	updateUserProfileIDs := pkgservice.ProcessInfoToSyncIDList(info.UserProfileInfo, UserOpTypeUpdateUserProfile)
+
+	pm.SyncUserProfile(SyncCreateUserProfileMsg, createUserProfileIDs, peer)
+	pm.SyncUserProfile(SyncUpdateUserProfileMsg, updateUserProfileIDs, peer)
+
 	// broadcast
 	pm.broadcastUserOplogsCore(toBroadcastLogs)
 
@@ -185,6 +209,11 @@ func (pm *ProtocolManager) SetNewestUserOplog(oplog *pkgservice.BaseOplog) (err
 		isNewer, err = pm.setNewestCreateNameCardLog(oplog)
 	case UserOpTypeUpdateNameCard:
 		isNewer, err = pm.setNewestUpdateNameCardLog(oplog)
+
+	case UserOpTypeCreateUserProfile:
+		isNewer, err = pm.setNewestCreateUserProfileLog(oplog)
+	case UserOpTypeUpdateUserProfile:
+		isNewer, err = pm.setNewestUpdateUserProfileLog(oplog)
 	}
 
 	oplog.IsNewer = isNewer
@@ -217,6 +246,11 @@ func (pm *ProtocolManager) HandleFailedUserOplog(oplog *pkgservice.BaseOplog) (e
 		err = pm.handleFailedCreateNameCardLog(oplog)
 	case UserOpTypeUpdateNameCard:
 		err = pm.handleFailedUpdateNameCardLog(oplog)
+
+	case UserOpTypeCreateUserProfile:
+		err = pm.handleFailedCreateUserProfileLog(oplog)
+	case UserOpTypeUpdateUserProfile:
+		err = pm.handleFailedUpdateUserProfileLog(oplog)
 	}
 
 	return
@@ -253,6 +287,11 @@ func (pm *ProtocolManager) HandleFailedValidUserOplog(oplog *pkgservice.BaseOplo
 		err = pm.handleFailedValidCreateNameCardLog(oplog, info)
 	case UserOpTypeUpdateNameCard:
 		err = pm.handleFailedValidUpdateNameCardLog(oplog, info)
+
+	case UserOpTypeCreateUserProfile:
+		err = pm.handleFailedValidCreateUserProfileLog(oplog, info)
+	case UserOpTypeUpdateUserProfile:
+		err = pm.handleFailedValidUpdateUserProfileLog(oplog, info)
 	}
 
 	return
@@ -285,6 +324,11 @@ func (pm *ProtocolManager) postprocessFailedValidUserOplogs(processInfo pkgservi
 
 	pm.ForceSyncNameCard(nameCardIDs, peer)
 
+	// user-profile
+	userProfileIDs := pkgservice.ProcessInfoToForceSyncIDList(info.UserProfileInfo)
+
+	pm.ForceSyncUserProfile(userProfileIDs, peer)
+
 	return nil
 }
 
@@ -52,6 +52,10 @@ type ProtocolManager struct {
 	// name-card
 	dbNameCardPrefix    []byte
 	dbNameCardIdxPrefix []byte
+
+	// user-profile
+	dbUserProfilePrefix    []byte
+	dbUserProfileIdxPrefix []byte
 }
 
 func newBaseProtocolManager(pm *ProtocolManager, ptt pkgservice.Ptt, entity pkgservice.Entity) *pkgservice.BaseProtocolManager {
@@ -141,6 +145,10 @@ func NewProtocolManager(profile *Profile, ptt pkgservice.Ptt) (*ProtocolManager,
 	pm.dbNameCardPrefix = DBNameCardPrefix
 	pm.dbNameCardIdxPrefix = DBNameCardIdxPrefix
 
+	// user-profile
+	pm.dbUserProfilePrefix = DBUserProfilePrefix
+	pm.dbUserProfileIdxPrefix = DBUserProfileIdxPrefix
+
 	return pm, nil
 }
 
@@ -87,6 +87,20 @@ func (pm *ProtocolManager) HandleMessage(op pkgservice.OpType, dataBytes []byte,
 	case SyncUpdateNameCardAckMsg:
 		err = pm.HandleSyncUpdateNameCardAck(dataBytes, peer)
 
+	// user-profile
+	case SyncCreateUserProfileMsg:
+		err = pm.HandleSyncCreateUserProfile(dataBytes, peer, SyncCreateUserProfileAckMsg)
+	case SyncCreateUserProfileAckMsg:
+		err = pm.HandleSyncCreateUserProfileAck(dataBytes, peer)
+	case SyncUpdateUserProfileMsg:
+		err = pm.HandleSyncUpdateUserProfile(dataBytes, peer, SyncUpdateUserProfileAckMsg)
+	case SyncUpdateUserProfileAckMsg:
+		err = pm.HandleSyncUpdateUserProfileAck(dataBytes, peer)
+	case ForceSyncUserProfileMsg:
+		err = pm.HandleForceSyncUserProfile(dataBytes, peer)
+	case ForceSyncUserProfileAckMsg:
+		err = pm.HandleForceSyncUserProfileAck(dataBytes, peer)
+
 	}
 	return nil
 }
@@ -0,0 +1,112 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"encoding/json"
+	"reflect"
+
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+type SyncUpdateUserProfileAck struct {
+	Objs []*UserProfile `json:"o"`
+}
+
+func (pm *ProtocolManager) HandleSyncUpdateUserProfileAck(dataBytes []byte, peer *pkgservice.PttPeer) error {
+	data := &SyncUpdateUserProfileAck{}
+	err := json.Unmarshal(dataBytes, data)
+	if err != nil {
+		return err
+	}
+
+	origObj := NewEmptyUserProfile()
+	pm.SetUserProfileDB(origObj)
+	for _, obj := range data.Objs {
+		pm.SetUserProfileDB(obj)
+
+		pm.HandleSyncUpdateObjectAck(
+			obj,
+			peer,
+
+			origObj,
+
+			pm.userOplogMerkle,
+
+			pm.SetUserDB,
+			pm.updateSyncUserProfile,
+			nil,
+			pm.broadcastUserOplogCore,
+		)
+	}
+
+	return nil
+}
+
+func (pm *ProtocolManager) updateSyncUserProfile(theToSyncInfo pkgservice.SyncInfo, theFromObj pkgservice.Object, oplog *pkgservice.BaseOplog) error {
+	toSyncInfo, ok := theToSyncInfo.(*SyncUserProfileInfo)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	fromObj, ok := theFromObj.(*UserProfile)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	// op-data
+	opData := &UserOpUpdateUserProfile{}
+	err := oplog.GetData(opData)
+	if err != nil {
+		return err
+	}
+
+	// logID
+	toLogID := toSyncInfo.GetLogID()
+	updateLogID := fromObj.GetUpdateLogID()
+
+	if !reflect.DeepEqual(toLogID, updateLogID) {
+		return pkgservice.ErrInvalidObject
+	}
+
+	// fields (only the fields visible to me are sent.)
+	fields := fromObj.Fields
+
+	hashes, err := profileFieldsToHashes(fields)
+	if err != nil {
+		return err
+	}
+
+	for _, hash := range hashes {
+		if !isInHashes(hash, opData.Hashes) {
+			return pkgservice.ErrInvalidObject
+		}
+	}
+
+	toSyncInfo.Fields = fields
+
+	return nil
+}
+
+func isInHashes(hash []byte, hashes [][]byte) bool {
+	for _, eachHash := range hashes {
+		if reflect.DeepEqual(hash, eachHash) {
+			return true
+		}
+	}
+	return false
+}
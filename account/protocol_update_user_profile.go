@@ -0,0 +1,114 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+type UpdateUserProfile struct {
+	Fields []*ProfileField `json:"F"`
+}
+
+func (pm *ProtocolManager) UpdateUserProfile(fields []*ProfileField) (*UserProfile, error) {
+	myID := pm.Ptt().GetMyEntity().GetID()
+
+	if !pm.IsMaster(myID, false) {
+		return nil, types.ErrInvalidID
+	}
+
+	fields, err := normalizeProfileFields(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	// the profiles created before having the user-profile.
+	spm := pm.Entity().Service().SPM().(*ServiceProtocolManager)
+	_, err = spm.GetUserProfileByID(myID)
+	if err == leveldb.ErrNotFound {
+		err = pm.CreateUserProfile()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data := &UpdateUserProfile{Fields: fields}
+
+	origObj := NewEmptyUserProfile()
+	pm.SetUserProfileDB(origObj)
+
+	opData := &UserOpUpdateUserProfile{}
+
+	err = pm.UpdateObject(
+		myID,
+
+		data,
+		UserOpTypeUpdateUserProfile,
+
+		origObj,
+
+		opData,
+
+		pm.userOplogMerkle,
+
+		pm.SetUserDB,
+
+		pm.NewUserOplog,
+
+		pm.inupdateUserProfile,
+
+		nil,
+
+		pm.broadcastUserOplogCore,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return origObj, nil
+}
+
+func (pm *ProtocolManager) inupdateUserProfile(obj pkgservice.Object, theData pkgservice.UpdateData, oplog *pkgservice.BaseOplog, theOpData pkgservice.OpData) (pkgservice.SyncInfo, error) {
+
+	data, ok := theData.(*UpdateUserProfile)
+	if !ok {
+		return nil, pkgservice.ErrInvalidData
+	}
+
+	opData, ok := theOpData.(*UserOpUpdateUserProfile)
+	if !ok {
+		return nil, pkgservice.ErrInvalidData
+	}
+
+	// op-data
+	hashes, err := profileFieldsToHashes(data.Fields)
+	if err != nil {
+		return nil, err
+	}
+	opData.Hashes = hashes
+
+	// sync-info
+	syncInfo := NewEmptySyncUserProfileInfo()
+	syncInfo.InitWithOplog(oplog.ToStatus(), oplog)
+
+	syncInfo.Fields = data.Fields
+
+	return syncInfo, nil
+}
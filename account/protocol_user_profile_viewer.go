@@ -0,0 +1,74 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+profileFieldViewer returns whether the peer is able to see the profile-field.
+
+My devices see all the fields.
+The owner of the profile reveals the friends-only fields to the members of the profile (friends),
+and the boards-only fields to the members of the specified boards.
+Others relay only the fields visible to everyone.
+*/
+func (pm *ProtocolManager) profileFieldViewer(peer *pkgservice.PttPeer) func(f *ProfileField) bool {
+	if peer.PeerType == pkgservice.PeerTypeMe {
+		return nil
+	}
+
+	myID := pm.Ptt().GetMyEntity().GetID()
+	profile := pm.Entity().(*Profile)
+	if !reflect.DeepEqual(myID, profile.MyID) {
+		return isPublicProfileField
+	}
+
+	userID := peer.UserID
+
+	return func(f *ProfileField) bool {
+		switch f.Visibility {
+		case ProfileVisibilityEveryone:
+			return true
+		case ProfileVisibilityFriends:
+			return userID != nil && pm.IsMember(userID, false)
+		case ProfileVisibilityBoards:
+			return userID != nil && pm.isBoardsMember(f.BoardIDs, userID)
+		}
+		return false
+	}
+}
+
+func (pm *ProtocolManager) isBoardsMember(boardIDs []*types.PttID, userID *types.PttID) bool {
+	ptt := pm.Ptt()
+	for _, boardID := range boardIDs {
+		board := ptt.GetEntity(boardID)
+		if board == nil || board.GetStatus() != types.StatusAlive {
+			continue
+		}
+
+		if board.PM().IsMember(userID, false) {
+			return true
+		}
+	}
+
+	return false
+}
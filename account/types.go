@@ -24,3 +24,13 @@ const (
 	ImgTypeGIF
 	ImgTypePNG
 )
+
+// profile-visibility
+type ProfileVisibility uint8
+
+const (
+	ProfileVisibilityInvalid ProfileVisibility = iota
+	ProfileVisibilityEveryone
+	ProfileVisibilityFriends
+	ProfileVisibilityBoards
+)
@@ -40,6 +40,9 @@ const (
 	UserOpTypeCreateNameCard
 	UserOpTypeUpdateNameCard
 
+	UserOpTypeCreateUserProfile
+	UserOpTypeUpdateUserProfile
+
 	NUserOpType
 )
 
@@ -81,3 +84,14 @@ type UserOpCreateNameCard struct {
 type UserOpUpdateNameCard struct {
 	Hash []byte `json:"H"`
 }
+
+type UserOpCreateUserProfile struct {
+}
+
+/*
+UserOpUpdateUserProfile keeps the hash of each field,
+so that the peer can verify the fields visible to the peer without knowing the others.
+*/
+type UserOpUpdateUserProfile struct {
+	Hashes [][]byte `json:"H"`
+}
@@ -0,0 +1,389 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/pttdb"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+type SyncUserProfileInfo struct {
+	*pkgservice.BaseSyncInfo `json:"b"`
+
+	Fields []*ProfileField `json:"F,omitempty"`
+}
+
+func NewEmptySyncUserProfileInfo() *SyncUserProfileInfo {
+	return &SyncUserProfileInfo{BaseSyncInfo: &pkgservice.BaseSyncInfo{}}
+}
+
+func (s *SyncUserProfileInfo) ToObject(theObj pkgservice.Object) error {
+	obj, ok := theObj.(*UserProfile)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	s.BaseSyncInfo.ToObject(obj)
+
+	obj.Fields = s.Fields
+
+	return nil
+}
+
+/*
+ProfileField is a structured field (bio, link, location, pronouns or any custom key) of the user-profile.
+
+Salt is randomly generated for each update, so that the hashes in the oplog reveal nothing about the fields invisible to the peer.
+*/
+type ProfileField struct {
+	Key        string            `json:"K"`
+	Value      string            `json:"V"`
+	Visibility ProfileVisibility `json:"v"`
+	BoardIDs   []*types.PttID    `json:"B,omitempty"`
+	Salt       []byte            `json:"S,omitempty"`
+}
+
+func (f *ProfileField) Hash() ([]byte, error) {
+	marshaled, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.Hash(marshaled), nil
+}
+
+/*
+UserProfile keeps the structured profile-fields of the user.
+
+The fields are filtered by the visibility before sending to the peers,
+so the user-profile of other users contains only the fields visible to me.
+*/
+type UserProfile struct {
+	*pkgservice.BaseObject `json:"b"`
+	UpdateTS               types.Timestamp      `json:"UT"`
+	SyncInfo               *SyncUserProfileInfo `json:"s,omitempty"`
+
+	Fields []*ProfileField `json:"F,omitempty"`
+
+	// viewer is set only in the prototype-obj for sending to the peer.
+	viewer func(f *ProfileField) bool
+}
+
+func NewUserProfile(
+	createTS types.Timestamp,
+	creatorID *types.PttID,
+	entityID *types.PttID,
+
+	logID *types.PttID,
+
+	status types.Status,
+
+	fields []*ProfileField,
+
+) (*UserProfile, error) {
+
+	id := creatorID
+
+	o := pkgservice.NewObject(id, createTS, creatorID, entityID, logID, status)
+
+	return &UserProfile{
+		BaseObject: o,
+		UpdateTS:   createTS,
+
+		Fields: fields,
+	}, nil
+}
+
+func NewEmptyUserProfile() *UserProfile {
+	return &UserProfile{BaseObject: &pkgservice.BaseObject{}}
+}
+
+func UserProfilesToObjs(typedObjs []*UserProfile) []pkgservice.Object {
+	objs := make([]pkgservice.Object, len(typedObjs))
+	for i, obj := range typedObjs {
+		objs[i] = obj
+	}
+	return objs
+}
+
+func ObjsToUserProfiles(objs []pkgservice.Object) []*UserProfile {
+	typedObjs := make([]*UserProfile, len(objs))
+	for i, obj := range objs {
+		typedObjs[i] = obj.(*UserProfile)
+	}
+	return typedObjs
+}
+
+func AliveUserProfiles(typedObjs []*UserProfile) []*UserProfile {
+	objs := make([]*UserProfile, 0, len(typedObjs))
+	for _, obj := range typedObjs {
+		if obj.Status == types.StatusAlive {
+			objs = append(objs, obj)
+		}
+	}
+	return objs
+}
+
+func (pm *ProtocolManager) SetUserProfileDB(u *UserProfile) {
+	spm := pm.Entity().Service().SPM()
+
+	u.SetDB(dbAccount, spm.DBObjLock(), pm.Entity().GetID(), pm.dbUserProfilePrefix, pm.dbUserProfileIdxPrefix, nil, nil)
+}
+
+func (spm *ServiceProtocolManager) SetUserProfileDB(u *UserProfile) {
+	u.SetDB(dbAccount, spm.DBObjLock(), nil, DBUserProfilePrefix, DBUserProfileIdxPrefix, nil, nil)
+}
+
+func (u *UserProfile) Save(isLocked bool) error {
+	var err error
+
+	if !isLocked {
+		err = u.Lock()
+		if err != nil {
+			return err
+		}
+		defer u.Unlock()
+	}
+
+	key, err := u.MarshalKey()
+	if err != nil {
+		return err
+	}
+	marshaled, err := u.Marshal()
+	if err != nil {
+		return err
+	}
+
+	idxKey, err := u.IdxKey()
+	if err != nil {
+		return err
+	}
+
+	idx := &pttdb.Index{Keys: [][]byte{key}, UpdateTS: u.UpdateTS}
+
+	kvs := []*pttdb.KeyVal{
+		&pttdb.KeyVal{K: key, V: marshaled},
+	}
+
+	_, err = u.DB().ForcePutAll(idxKey, idx, kvs)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *UserProfile) NewEmptyObj() pkgservice.Object {
+	newU := NewEmptyUserProfile()
+	newU.CloneDB(u.BaseObject)
+	newU.viewer = u.viewer
+	return newU
+}
+
+/*
+GetNewObjByID gets the user-profile with only the fields visible to the viewer of the prototype-obj.
+*/
+func (u *UserProfile) GetNewObjByID(id *types.PttID, isLocked bool) (pkgservice.Object, error) {
+	newU := u.NewEmptyObj().(*UserProfile)
+	newU.SetID(id)
+	err := newU.GetByID(isLocked)
+	if err != nil {
+		return nil, err
+	}
+
+	newU.Fields = filterProfileFields(newU.Fields, u.viewer)
+	if newU.SyncInfo != nil {
+		newU.SyncInfo.Fields = filterProfileFields(newU.SyncInfo.Fields, u.viewer)
+	}
+
+	return newU, nil
+}
+
+func (u *UserProfile) SetUpdateTS(ts types.Timestamp) {
+	u.UpdateTS = ts
+}
+
+func (u *UserProfile) GetUpdateTS() types.Timestamp {
+	return u.UpdateTS
+}
+
+func (u *UserProfile) Get(isLocked bool) error {
+	var err error
+
+	if !isLocked {
+		err = u.RLock()
+		if err != nil {
+			return err
+		}
+		defer u.RUnlock()
+	}
+
+	key, err := u.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	val, err := u.DB().DBGet(key)
+	if err != nil {
+		return err
+	}
+
+	return u.Unmarshal(val)
+}
+
+func (u *UserProfile) GetByID(isLocked bool) error {
+	var err error
+
+	val, err := u.GetValueByID(isLocked)
+	if err != nil {
+		return err
+	}
+
+	return u.Unmarshal(val)
+}
+
+func (u *UserProfile) MarshalKey() ([]byte, error) {
+	return common.Concat([][]byte{DBUserProfilePrefix, u.ID[:]})
+}
+
+func (u *UserProfile) Marshal() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+func (u *UserProfile) Unmarshal(theBytes []byte) error {
+	return json.Unmarshal(theBytes, u)
+}
+
+func (u *UserProfile) GetSyncInfo() pkgservice.SyncInfo {
+	if u.SyncInfo == nil {
+		return nil
+	}
+	return u.SyncInfo
+}
+
+func (u *UserProfile) SetSyncInfo(theSyncInfo pkgservice.SyncInfo) error {
+	if theSyncInfo == nil {
+		u.SyncInfo = nil
+		return nil
+	}
+
+	syncInfo, ok := theSyncInfo.(*SyncUserProfileInfo)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+	u.SyncInfo = syncInfo
+
+	return nil
+}
+
+/**********
+ * Profile Field
+ **********/
+
+/*
+normalizeProfileFields validates the fields and renews the salts.
+*/
+func normalizeProfileFields(fields []*ProfileField) ([]*ProfileField, error) {
+	if len(fields) > MaxProfileFields {
+		return nil, ErrInvalidProfileField
+	}
+
+	newFields := make([]*ProfileField, 0, len(fields))
+	for _, f := range fields {
+		if f == nil {
+			continue
+		}
+
+		if len(f.Key) == 0 || len(f.Key) > MaxProfileFieldKeyLength || len(f.Value) > MaxProfileFieldValueLength {
+			return nil, ErrInvalidProfileField
+		}
+
+		switch f.Visibility {
+		case ProfileVisibilityEveryone, ProfileVisibilityFriends:
+			if len(f.BoardIDs) != 0 {
+				return nil, ErrInvalidProfileField
+			}
+		case ProfileVisibilityBoards:
+			if len(f.BoardIDs) == 0 || len(f.BoardIDs) > MaxProfileFieldBoards {
+				return nil, ErrInvalidProfileField
+			}
+			for _, boardID := range f.BoardIDs {
+				if boardID == nil {
+					return nil, ErrInvalidProfileField
+				}
+			}
+		default:
+			return nil, ErrInvalidProfileField
+		}
+
+		salt := make([]byte, SizeProfileFieldSalt)
+		_, err := types.RandRead(salt)
+		if err != nil {
+			return nil, err
+		}
+
+		newFields = append(newFields, &ProfileField{
+			Key:        f.Key,
+			Value:      f.Value,
+			Visibility: f.Visibility,
+			BoardIDs:   f.BoardIDs,
+			Salt:       salt,
+		})
+	}
+
+	return newFields, nil
+}
+
+func profileFieldsToHashes(fields []*ProfileField) ([][]byte, error) {
+	hashes := make([][]byte, len(fields))
+
+	var err error
+	for i, f := range fields {
+		hashes[i], err = f.Hash()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return hashes, nil
+}
+
+/*
+filterProfileFields returns the fields visible to the viewer. nil viewer sees all the fields.
+*/
+func filterProfileFields(fields []*ProfileField, viewer func(f *ProfileField) bool) []*ProfileField {
+	if viewer == nil {
+		return fields
+	}
+
+	newFields := make([]*ProfileField, 0, len(fields))
+	for _, f := range fields {
+		if viewer(f) {
+			newFields = append(newFields, f)
+		}
+	}
+
+	return newFields
+}
+
+func isPublicProfileField(f *ProfileField) bool {
+	return f.Visibility == ProfileVisibilityEveryone
+}
@@ -0,0 +1,121 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+func TestNormalizeProfileFields(t *testing.T) {
+	boardID, _ := types.NewPttID()
+
+	tests := []struct {
+		name    string
+		fields  []*ProfileField
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			fields: []*ProfileField{
+				&ProfileField{Key: ProfileFieldKeyBio, Value: "bio", Visibility: ProfileVisibilityEveryone},
+				nil,
+				&ProfileField{Key: ProfileFieldKeyPronouns, Value: "they/them", Visibility: ProfileVisibilityFriends},
+				&ProfileField{Key: "custom", Value: "value", Visibility: ProfileVisibilityBoards, BoardIDs: []*types.PttID{boardID}},
+			},
+			wantLen: 3,
+		},
+		{
+			name:    "invalid visibility",
+			fields:  []*ProfileField{&ProfileField{Key: ProfileFieldKeyBio, Value: "bio"}},
+			wantErr: true,
+		},
+		{
+			name:    "boards without board-ids",
+			fields:  []*ProfileField{&ProfileField{Key: ProfileFieldKeyLocation, Value: "Taipei", Visibility: ProfileVisibilityBoards}},
+			wantErr: true,
+		},
+		{
+			name:    "empty key",
+			fields:  []*ProfileField{&ProfileField{Value: "value", Visibility: ProfileVisibilityEveryone}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeProfileFields(tt.fields)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("normalizeProfileFields() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("normalizeProfileFields() len = %v, want %v", len(got), tt.wantLen)
+			}
+			for _, f := range got {
+				if len(f.Salt) != SizeProfileFieldSalt {
+					t.Errorf("normalizeProfileFields() salt = %v", f.Salt)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterProfileFields(t *testing.T) {
+	fields, err := normalizeProfileFields([]*ProfileField{
+		&ProfileField{Key: ProfileFieldKeyBio, Value: "bio", Visibility: ProfileVisibilityEveryone},
+		&ProfileField{Key: ProfileFieldKeyPronouns, Value: "they/them", Visibility: ProfileVisibilityFriends},
+	})
+	if err != nil {
+		t.Errorf("normalizeProfileFields() error = %v", err)
+		return
+	}
+
+	hashes, err := profileFieldsToHashes(fields)
+	if err != nil {
+		t.Errorf("profileFieldsToHashes() error = %v", err)
+		return
+	}
+
+	// all
+	got := filterProfileFields(fields, nil)
+	if !reflect.DeepEqual(got, fields) {
+		t.Errorf("filterProfileFields() = %v, want %v", got, fields)
+	}
+
+	// public
+	got = filterProfileFields(fields, isPublicProfileField)
+	if len(got) != 1 || got[0].Key != ProfileFieldKeyBio {
+		t.Errorf("filterProfileFields() = %v", got)
+		return
+	}
+
+	// the filtered fields are verifiable with the hashes in the oplog.
+	gotHashes, _ := profileFieldsToHashes(got)
+	if !isInHashes(gotHashes[0], hashes) {
+		t.Errorf("isInHashes() = false")
+	}
+
+	got[0].Value = "modified"
+	gotHashes, _ = profileFieldsToHashes(got)
+	if isInHashes(gotHashes[0], hashes) {
+		t.Errorf("isInHashes() = true with modified value")
+	}
+}
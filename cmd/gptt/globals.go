@@ -59,6 +59,7 @@ var (
 		utils.MyDataDirFlag,
 		utils.MyKeyFileFlag,
 		utils.MyKeyHexFlag,
+		utils.PassphraseFileFlag,
 		utils.ServerFlag,
 	}
 
@@ -66,6 +67,7 @@ var (
 	contentFlags = []cli.Flag{
 		utils.ContentDataDirFlag,
 		utils.ContentKeystoreDirFlag,
+		utils.ContentMaxArticleRevisionsFlag,
 	}
 
 	// flags that configure content
@@ -184,6 +186,31 @@ The output of this command is supposed to be machine-readable.
 		Category:  "MISCELLANEOUS COMMANDS",
 	}
 
+	accountCommand = cli.Command{
+		Name:     "account",
+		Usage:    "Manage the keystore of my key and node key",
+		Category: "ACCOUNT COMMANDS",
+		Subcommands: []cli.Command{
+			{
+				Action:    utils.MigrateFlags(accountPasswd),
+				Name:      "passwd",
+				Usage:     "Change the passphrase of the keystore",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					configFileFlag,
+					utils.DataDirFlag,
+					utils.PassphraseFileFlag,
+					utils.NewPassphraseFileFlag,
+				},
+				Description: `
+The passwd command encrypts my key and node key with the new passphrase.
+The plain key-files are encrypted if there is no passphrase yet,
+and the key-files are stored in plain if the new passphrase is empty.
+`,
+			},
+		},
+	}
+
 	dumpConfigCommand = cli.Command{
 		Action:      utils.MigrateFlags(dumpConfig),
 		Name:        "dumpconfig",
@@ -218,4 +245,6 @@ var (
 
 const (
 	TimeSleepBrowser = 8
+
+	TimeoutShutdownUnlock = 5
 )
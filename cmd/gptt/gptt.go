@@ -53,6 +53,19 @@ func gptt(ctx *cli.Context) error {
 	// we need NodeConfig be the 1st. The DataDir in other configs are referring to the DataDir in NodeConfig.
 	utils.SetNodeConfig(ctx, cfg.Node)
 
+	// keystore
+	err = utils.SetKeystoreConfig(ctx, cfg.Me, cfg.Node)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Me.IsLocked() || cfg.Node.IsLocked() {
+		err = waitUnlock(cfg)
+		if err != nil {
+			return err
+		}
+	}
+
 	utils.SetMeConfig(ctx, cfg.Me, cfg.Node)
 
 	utils.SetAccountConfig(ctx, cfg.Account, cfg.Node)
@@ -122,6 +135,17 @@ func registerServices(ctx *pkgservice.ServiceContext, cfg *Config) (pkgservice.P
 	myNodeKey := cfg.Node.NodeKey()
 	myNodeID := discover.PubkeyID(&myNodeKey.PublicKey)
 
+	// encrypt the keys stored in the db
+	dbKey, err := cfg.Me.DBKey()
+	if err != nil {
+		return nil, err
+	}
+
+	err = pkgservice.SetKeyInfoDBKey(dbKey)
+	if err != nil {
+		return nil, err
+	}
+
 	ptt, err := pkgservice.NewPtt(ctx, cfg.Ptt, &myNodeID, myNodeKey)
 	if err != nil {
 		return nil, err
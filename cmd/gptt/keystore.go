@@ -0,0 +1,215 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ailabstw/go-pttai/cmd/utils"
+	"github.com/ailabstw/go-pttai/crypto/keystore"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/rpc"
+	"golang.org/x/crypto/ssh/terminal"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+var (
+	ErrPassphraseMismatch = errors.New("passphrases do not match")
+)
+
+/**********
+ * unlock
+ **********/
+
+/*
+UnlockAPI is the only api served while the keystore is locked.
+*/
+type UnlockAPI struct {
+	cfg *Config
+
+	lock       sync.Mutex
+	isUnlocked bool
+	unlocked   chan struct{}
+}
+
+/*
+Unlock unlocks the keystore (my key and node key) with the passphrase, and the node starts.
+*/
+func (api *UnlockAPI) Unlock(passphrase string) (bool, error) {
+	api.lock.Lock()
+	defer api.lock.Unlock()
+
+	if api.isUnlocked {
+		return true, nil
+	}
+
+	err := api.cfg.Me.Unlock(passphrase)
+	if err != nil {
+		return false, err
+	}
+
+	err = api.cfg.Node.Unlock(passphrase)
+	if err != nil {
+		return false, err
+	}
+
+	api.isUnlocked = true
+	close(api.unlocked)
+
+	return true, nil
+}
+
+/*
+waitUnlock serves me_unlock on the rpc-endpoint until the keystore is unlocked.
+*/
+func waitUnlock(cfg *Config) error {
+	api := &UnlockAPI{
+		cfg:      cfg,
+		unlocked: make(chan struct{}),
+	}
+
+	apis := []rpc.API{
+		{
+			Namespace: "me",
+			Version:   "1.0",
+			Service:   api,
+			Public:    true,
+		},
+	}
+
+	endpoint := cfg.Node.HTTPEndpoint()
+	listener, handler, httpServer, err := rpc.StartHTTPEndpoint(endpoint, apis, []string{"me"}, cfg.Node.HTTPCors, cfg.Node.HTTPVirtualHosts)
+	if err != nil {
+		return err
+	}
+
+	log.Warn("Keystore is locked. Waiting for me_unlock.", "url", fmt.Sprintf("http://%s", endpoint))
+
+	<-api.unlocked
+
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutShutdownUnlock*time.Second)
+	defer cancel()
+
+	httpServer.Shutdown(ctx)
+	listener.Close()
+	handler.Stop()
+
+	log.Info("Keystore is unlocked.")
+
+	return nil
+}
+
+/**********
+ * account
+ **********/
+
+func accountPasswd(ctx *cli.Context) error {
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	utils.SetNodeConfig(ctx, cfg.Node)
+
+	err = utils.SetKeystoreConfig(ctx, cfg.Me, cfg.Node)
+	if err != nil {
+		return err
+	}
+
+	// passphrase
+	passphrase := cfg.Me.Passphrase
+	if !ctx.GlobalIsSet(utils.PassphraseFileFlag.Name) && (cfg.Me.IsLocked() || cfg.Node.IsLocked()) {
+		passphrase, err = readPassphrase("Passphrase: ")
+		if err != nil {
+			return err
+		}
+	}
+
+	// new passphrase
+	var newPassphrase string
+	switch {
+	case ctx.GlobalIsSet(utils.NewPassphraseFileFlag.Name):
+		newPassphrase, err = utils.ReadPassphraseFile(ctx.GlobalString(utils.NewPassphraseFileFlag.Name))
+	default:
+		newPassphrase, err = readNewPassphrase()
+	}
+	if err != nil {
+		return err
+	}
+
+	// change (all or none)
+	meKeyFiles, err := cfg.Me.KeyFiles()
+	if err != nil {
+		return err
+	}
+
+	nodeKeyFiles, err := cfg.Node.KeyFiles()
+	if err != nil {
+		return err
+	}
+
+	err = keystore.ChangePassphraseFiles(append(meKeyFiles, nodeKeyFiles...), passphrase, newPassphrase)
+	if err != nil {
+		return err
+	}
+
+	cfg.Me.Passphrase = newPassphrase
+	cfg.Node.Passphrase = newPassphrase
+
+	if newPassphrase == "" {
+		fmt.Println("Keystore is decrypted.")
+	} else {
+		fmt.Println("Passphrase is changed.")
+	}
+
+	return nil
+}
+
+func readPassphrase(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	defer fmt.Fprintln(os.Stderr)
+
+	passphrase, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+	if err != nil {
+		return "", err
+	}
+
+	return string(passphrase), nil
+}
+
+func readNewPassphrase() (string, error) {
+	newPassphrase, err := readPassphrase("New passphrase (empty to store the keys in plain): ")
+	if err != nil {
+		return "", err
+	}
+
+	confirmPassphrase, err := readPassphrase("Repeat new passphrase: ")
+	if err != nil {
+		return "", err
+	}
+
+	if newPassphrase != confirmPassphrase {
+		return "", ErrPassphraseMismatch
+	}
+
+	return newPassphrase, nil
+}
@@ -74,6 +74,7 @@ func InitApp() *cli.App {
 		versionCommand,
 		licenseCommand,
 		dumpConfigCommand,
+		accountCommand,
 	}
 	sort.Sort(cli.CommandsByName(app.Commands))
 
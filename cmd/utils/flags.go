@@ -118,6 +118,16 @@ var (
 		Usage: "my postfix (20 bytes)",
 	}
 
+	// Keystore settings
+	PassphraseFileFlag = cli.StringFlag{
+		Name:  "passphrasefile",
+		Usage: "File containing the passphrase of the encrypted keystore (my key and node key)",
+	}
+	NewPassphraseFileFlag = cli.StringFlag{
+		Name:  "newpassphrasefile",
+		Usage: "File containing the new passphrase of the encrypted keystore (for changing passphrase)",
+	}
+
 	ServerFlag = cli.BoolFlag{
 		Name:  "server",
 		Usage: "set as server mode",
@@ -142,6 +152,12 @@ var (
 		Value: DirectoryString{content.DefaultConfig.KeystoreDir},
 	}
 
+	ContentMaxArticleRevisionsFlag = cli.IntFlag{
+		Name:  "contentmaxrevisions",
+		Usage: "max number of kept article revisions",
+		Value: content.DefaultConfig.MaxArticleRevisions,
+	}
+
 	// Friend settings
 	FriendMaxSyncRandomSecondsFlag = cli.IntFlag{
 		Name:  "friendmaxsync",
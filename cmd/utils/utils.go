@@ -132,6 +132,38 @@ func setMyKey(ctx *cli.Context, cfg *me.Config) error {
 	return nil
 }
 
+// SetKeystoreConfig sets the passphrase of the keystore from the command line flags.
+// Needs to be done before SetMeConfig because my key is loaded in SetMeConfig.
+func SetKeystoreConfig(ctx *cli.Context, cfg *me.Config, cfgNode *node.Config) error {
+	cfg.DataDir = filepath.Join(cfgNode.DataDir, "me")
+
+	passphrase, err := ReadPassphraseFile(ctx.GlobalString(PassphraseFileFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	cfg.Passphrase = passphrase
+	cfgNode.Passphrase = passphrase
+
+	return nil
+}
+
+// ReadPassphraseFile reads the passphrase from the 1st line of the file.
+func ReadPassphraseFile(filename string) (string, error) {
+	if filename == "" {
+		return "", nil
+	}
+
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(string(content), "\n")
+
+	return strings.TrimRight(lines[0], "\r"), nil
+}
+
 // SetContentConfig applies node-related command line flags to the config.
 func SetAccountConfig(ctx *cli.Context, cfg *account.Config, cfgNode *node.Config) {
 	// datadir
@@ -151,6 +183,12 @@ func SetContentConfig(ctx *cli.Context, cfg *content.Config, cfgNode *node.Confi
 	default:
 		cfg.KeystoreDir = filepath.Join(cfgNode.DataDir, ".keystore")
 	}
+
+	if ctx.GlobalIsSet(ContentMaxArticleRevisionsFlag.Name) {
+		cfg.MaxArticleRevisions = ctx.GlobalInt(ContentMaxArticleRevisionsFlag.Name)
+	}
+
+	content.MaxArticleRevisions = cfg.MaxArticleRevisions
 }
 
 // SetContentConfig applies node-related command line flags to the config.
@@ -34,15 +34,110 @@ func (api *PrivateAPI) CreateBoard(title []byte, isPrivate bool) (*BackendCreate
 	return api.b.CreateBoard(title, isPrivate)
 }
 
-func (api *PrivateAPI) CreateArticle(entityID string, title []byte, article [][]byte, mediaIDs []string) (*BackendCreateArticle, error) {
+/*
+CreateArticle creates the article. format is optional (plain-text as default).
+*/
+func (api *PrivateAPI) CreateArticle(entityID string, title []byte, article [][]byte, mediaIDs []string, format *ArticleFormat) (*BackendCreateArticle, error) {
+	theFormat := ArticleFormatPlain
+	if format != nil {
+		theFormat = *format
+	}
+
 	return api.b.CreateArticle(
 		[]byte(entityID),
 		title,
 		article,
 		mediaIDs,
+		theFormat,
 	)
 }
 
+/*
+ForwardArticle forwards the article in fromEntityID to toEntityID as a new article,
+with the provenance of the original article.
+*/
+func (api *PrivateAPI) ForwardArticle(fromEntityID string, articleID string, toEntityID string) (*BackendCreateArticle, error) {
+	return api.b.ForwardArticle(
+		[]byte(fromEntityID),
+		[]byte(articleID),
+		[]byte(toEntityID),
+	)
+}
+
+/*
+VerifyArticleProvenance verifies the provenance of the forwarded article with the original article.
+The original board is required to be joined.
+*/
+func (api *PrivateAPI) VerifyArticleProvenance(entityID string, articleID string) (bool, error) {
+	return api.b.VerifyArticleProvenance([]byte(entityID), []byte(articleID))
+}
+
+/*
+CreateDraft creates the local-only draft. format and publishTS are optional.
+The draft is published automatically once publishTS is reached.
+*/
+func (api *PrivateAPI) CreateDraft(entityID string, title []byte, article [][]byte, mediaIDs []string, format *ArticleFormat, publishTS *types.Timestamp) (*Draft, error) {
+	theFormat := ArticleFormatPlain
+	if format != nil {
+		theFormat = *format
+	}
+
+	thePublishTS := types.ZeroTimestamp
+	if publishTS != nil {
+		thePublishTS = *publishTS
+	}
+
+	return api.b.CreateDraft(
+		[]byte(entityID),
+		title,
+		article,
+		mediaIDs,
+		theFormat,
+		thePublishTS,
+	)
+}
+
+/*
+UpdateDraft updates the draft. format and publishTS are optional (no schedule if publishTS is not set).
+*/
+func (api *PrivateAPI) UpdateDraft(entityID string, draftID string, title []byte, article [][]byte, mediaIDs []string, format *ArticleFormat, publishTS *types.Timestamp) (*Draft, error) {
+	theFormat := ArticleFormatPlain
+	if format != nil {
+		theFormat = *format
+	}
+
+	thePublishTS := types.ZeroTimestamp
+	if publishTS != nil {
+		thePublishTS = *publishTS
+	}
+
+	return api.b.UpdateDraft(
+		[]byte(entityID),
+		[]byte(draftID),
+		title,
+		article,
+		mediaIDs,
+		theFormat,
+		thePublishTS,
+	)
+}
+
+func (api *PrivateAPI) DeleteDraft(entityID string, draftID string) (bool, error) {
+	return api.b.DeleteDraft([]byte(entityID), []byte(draftID))
+}
+
+func (api *PrivateAPI) PublishDraft(entityID string, draftID string) (*BackendCreateArticle, error) {
+	return api.b.PublishDraft([]byte(entityID), []byte(draftID))
+}
+
+func (api *PrivateAPI) GetDraft(entityID string, draftID string) (*Draft, error) {
+	return api.b.GetDraft([]byte(entityID), []byte(draftID))
+}
+
+func (api *PrivateAPI) GetDraftList(entityID string) ([]*Draft, error) {
+	return api.b.GetDraftList([]byte(entityID))
+}
+
 func (api *PrivateAPI) CreateComment(entityID string, articleID string, commentType CommentType, comment []byte, mediaID string) (*BackendCreateComment, error) {
 	return api.b.CreateComment(
 		[]byte(entityID),
@@ -67,6 +162,10 @@ func (api *PrivateAPI) SetTitle(entityID string, title []byte) (*BackendGetBoard
 	return api.b.SetTitle([]byte(entityID), title)
 }
 
+func (api *PrivateAPI) SetBoardNotify(entityID string, setting pkgservice.NotifySetting) (bool, error) {
+	return api.b.SetBoardNotify([]byte(entityID), setting)
+}
+
 func (api *PrivateAPI) UpdateArticle(entityID string, articleID string, article [][]byte, mediaIDs []string) (*BackendUpdateArticle, error) {
 	return api.b.UpdateArticle(
 		[]byte(entityID),
@@ -120,6 +219,26 @@ func (api *PrivateAPI) DeleteMember(entityID string, userID string) (bool, error
 	return api.b.DeleteMember([]byte(entityID), []byte(userID))
 }
 
+func (api *PrivateAPI) SetMemberRole(entityID string, userID string, role pkgservice.MemberRole) (bool, error) {
+	return api.b.SetMemberRole([]byte(entityID), []byte(userID), role)
+}
+
+func (api *PrivateAPI) GetMemberRoles(entityID string) ([]*pkgservice.MemberRoleInfo, error) {
+	return api.b.GetMemberRoles([]byte(entityID))
+}
+
+func (api *PrivateAPI) BanMember(entityID string, userID string, isBanned bool) (bool, error) {
+	return api.b.BanMember([]byte(entityID), []byte(userID), isBanned)
+}
+
+func (api *PrivateAPI) MuteMember(entityID string, userID string, seconds int64) (bool, error) {
+	return api.b.MuteMember([]byte(entityID), []byte(userID), seconds)
+}
+
+func (api *PrivateAPI) GetBanList(entityID string) ([]*pkgservice.MemberBanInfo, error) {
+	return api.b.GetBanList([]byte(entityID))
+}
+
 func (api *PrivateAPI) InviteMaster(entityID string, userID string, nodeURL string) (*BackendInviteMaster, error) {
 	return api.b.InviteMaster(
 		[]byte(entityID),
@@ -128,10 +247,61 @@ func (api *PrivateAPI) InviteMaster(entityID string, userID string, nodeURL stri
 	)
 }
 
+func (api *PrivateAPI) TransferMaster(entityID string, userID string) (*BackendRevokeMaster, error) {
+	return api.b.TransferMaster([]byte(entityID), []byte(userID))
+}
+
+func (api *PrivateAPI) AddMaster(entityID string, userID string) (bool, error) {
+	return api.b.AddMaster([]byte(entityID), []byte(userID))
+}
+
+func (api *PrivateAPI) SetMasterQuorum(entityID string, quorum uint32) (bool, error) {
+	return api.b.SetMasterQuorum([]byte(entityID), quorum)
+}
+
+func (api *PrivateAPI) GetMasterQuorum(entityID string) (*BackendMasterQuorum, error) {
+	return api.b.GetMasterQuorum([]byte(entityID))
+}
+
+func (api *PrivateAPI) GetPendingQuorumOplogs(entityID string) ([]*BackendPendingQuorumOplog, error) {
+	return api.b.GetPendingQuorumOplogs([]byte(entityID))
+}
+
+func (api *PrivateAPI) CoSignMasterOplog(entityID string, logID string) (bool, error) {
+	return api.b.CoSignMasterOplog([]byte(entityID), []byte(logID))
+}
+
+func (api *PrivateAPI) CoSignBoardOplog(entityID string, logID string) (bool, error) {
+	return api.b.CoSignBoardOplog([]byte(entityID), []byte(logID))
+}
+
 func (api *PrivateAPI) GetJoinKeyInfos(entityID string) ([]*pkgservice.KeyInfo, error) {
 	return api.b.GetJoinKeys([]byte(entityID))
 }
 
+/*
+CreateInvite creates the invite-url of the board.
+
+maxUse: 0 as unlimited.
+expireSeconds: 0 as no expiration.
+inviteeID: optional, only the invitee is able to join with the invite-url.
+*/
+func (api *PrivateAPI) CreateInvite(entityID string, maxUse uint32, expireSeconds int64, inviteeID *string) (*pkgservice.BackendJoinURL, error) {
+	theInviteeID := ""
+	if inviteeID != nil {
+		theInviteeID = *inviteeID
+	}
+	return api.b.CreateInvite([]byte(entityID), maxUse, expireSeconds, []byte(theInviteeID))
+}
+
+func (api *PrivateAPI) RevokeInvite(entityID string, inviteID string) (bool, error) {
+	return api.b.RevokeInvite([]byte(entityID), []byte(inviteID))
+}
+
+func (api *PrivateAPI) GetInvites(entityID string) ([]*pkgservice.BackendInvite, error) {
+	return api.b.GetInvites([]byte(entityID))
+}
+
 func (api *PrivateAPI) GetRawBoard(entityID string) (*Board, error) {
 	return api.b.GetRawBoard([]byte(entityID))
 }
@@ -164,6 +334,10 @@ func (api *PublicAPI) GetBoardList(startingBoardID string, limit int, listOrder
 	)
 }
 
+func (api *PublicAPI) GetPublicBoardList() ([]*BackendPublicBoard, error) {
+	return api.b.GetPublicBoardList()
+}
+
 func (api *PublicAPI) GetArticle(entityID string, articleID string) (*BackendGetArticle, error) {
 	return api.b.GetArticle(
 		[]byte(entityID),
@@ -171,6 +345,13 @@ func (api *PublicAPI) GetArticle(entityID string, articleID string) (*BackendGet
 	)
 }
 
+func (api *PublicAPI) GetArticleRendered(entityID string, articleID string) (*BackendGetArticleRendered, error) {
+	return api.b.GetArticleRendered(
+		[]byte(entityID),
+		[]byte(articleID),
+	)
+}
+
 func (api *PrivateAPI) GetRawArticle(entityID string, articleID string) (*Article, error) {
 	return api.b.GetRawArticle(
 		[]byte(entityID),
@@ -211,6 +392,28 @@ func (api *PublicAPI) GetArticleBlockList(entityID string, articleID string, sub
 	)
 }
 
+/*
+GetArticleRevisions gets the kept revisions of the article, the newest last.
+*/
+func (api *PublicAPI) GetArticleRevisions(entityID string, articleID string) ([]*BackendArticleRevision, error) {
+	return api.b.GetArticleRevisions(
+		[]byte(entityID),
+		[]byte(articleID),
+	)
+}
+
+/*
+GetArticleDiff gets the line-based diff of the article between the revisions fromRev and toRev.
+*/
+func (api *PublicAPI) GetArticleDiff(entityID string, articleID string, fromRev uint32, toRev uint32) (*BackendArticleDiff, error) {
+	return api.b.GetArticleDiff(
+		[]byte(entityID),
+		[]byte(articleID),
+		fromRev,
+		toRev,
+	)
+}
+
 func (api *PublicAPI) GetArticleList(entityID string, startingArticleID string, limit int, listOrder pttdb.ListOrder) ([]*BackendGetArticle, error) {
 	return api.b.GetArticleList(
 		[]byte(entityID),
@@ -228,6 +431,10 @@ func (api *PublicAPI) ShowBoardURL(entityID string) (*pkgservice.BackendJoinURL,
 	return api.b.ShowBoardURL([]byte(entityID))
 }
 
+func (api *PublicAPI) ShowBoardURLQRCode(entityID string, format string, size int) (*pkgservice.BackendJoinURLQRCode, error) {
+	return api.b.ShowBoardURLQRCode([]byte(entityID), []byte(format), size)
+}
+
 /**********
  * BoardOplog
  **********/
@@ -49,6 +49,20 @@ func (s *SyncArticleInfo) ToObject(theObj pkgservice.Object) error {
 	return nil
 }
 
+/*
+ArticleProvenance refers to the original article of the forwarded article.
+
+SigHash is the hash of the signatures of the original content-blocks,
+which can be verified with the blocks in the original board.
+*/
+type ArticleProvenance struct {
+	BoardID        *types.PttID `json:"BID"`
+	ArticleID      *types.PttID `json:"AID"`
+	CreatorID      *types.PttID `json:"CID"`
+	ContentBlockID *types.PttID `json:"cID"`
+	SigHash        []byte       `json:"SH"`
+}
+
 type Article struct {
 	*pkgservice.BaseObject `json:"b"`
 
@@ -58,6 +72,10 @@ type Article struct {
 
 	Title []byte `json:"T,omitempty"`
 
+	Format ArticleFormat `json:"F,omitempty"`
+
+	Provenance *ArticleProvenance `json:"p,omitempty"`
+
 	NPush *pkgservice.Count `json:"-"` // from other db-records
 	NBoo  *pkgservice.Count `json:"-"` // from other db-records
 
@@ -76,6 +94,7 @@ func NewArticle(
 	status types.Status,
 
 	title []byte,
+	format ArticleFormat,
 
 ) (*Article, error) {
 
@@ -91,7 +110,8 @@ func NewArticle(
 
 		UpdateTS: createTS,
 
-		Title: title,
+		Title:  title,
+		Format: format,
 	}, nil
 }
 
@@ -439,6 +459,8 @@ func (a *Article) DeleteAll(comment *Comment, isLocked bool) error {
 	setBlockInfoDB := a.SetBlockInfoDB()
 	setBlockInfoDB(blockInfo, a.ID)
 
+	a.DeleteRevisions()
+
 	blockInfo.Remove(false)
 
 	// postdelete
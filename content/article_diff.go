@@ -0,0 +1,110 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import "bytes"
+
+type DiffOp uint8
+
+const (
+	DiffOpEqual DiffOp = iota
+	DiffOpInsert
+	DiffOpDelete
+)
+
+type DiffLine struct {
+	Op   DiffOp `json:"O"`
+	Line []byte `json:"L"`
+}
+
+/*
+DiffLines computes the line-based diff from fromLines to toLines
+based on the longest common subsequence.
+
+If the changed part is larger than MaxDiffCells, the changed part is shown as deleted and then inserted.
+*/
+func DiffLines(fromLines [][]byte, toLines [][]byte) []*DiffLine {
+	// common prefix
+	nPrefix := 0
+	for nPrefix < len(fromLines) && nPrefix < len(toLines) && bytes.Equal(fromLines[nPrefix], toLines[nPrefix]) {
+		nPrefix++
+	}
+
+	// common suffix
+	nSuffix := 0
+	for nSuffix < len(fromLines)-nPrefix && nSuffix < len(toLines)-nPrefix && bytes.Equal(fromLines[len(fromLines)-1-nSuffix], toLines[len(toLines)-1-nSuffix]) {
+		nSuffix++
+	}
+
+	from := fromLines[nPrefix : len(fromLines)-nSuffix]
+	to := toLines[nPrefix : len(toLines)-nSuffix]
+
+	diffs := make([]*DiffLine, 0, len(fromLines)+len(toLines))
+	for _, line := range fromLines[:nPrefix] {
+		diffs = append(diffs, &DiffLine{Op: DiffOpEqual, Line: line})
+	}
+
+	// lcs[i][j]: length of lcs of from[i:] and to[j:]
+	nFrom, nTo := len(from), len(to)
+	if nFrom*nTo > MaxDiffCells {
+		nFrom, nTo = 0, 0
+	}
+	lcs := make([][]int, nFrom+1)
+	for i := range lcs {
+		lcs[i] = make([]int, nTo+1)
+	}
+	for i := nFrom - 1; i >= 0; i-- {
+		for j := nTo - 1; j >= 0; j-- {
+			switch {
+			case bytes.Equal(from[i], to[j]):
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < nFrom && j < nTo {
+		switch {
+		case bytes.Equal(from[i], to[j]):
+			diffs = append(diffs, &DiffLine{Op: DiffOpEqual, Line: from[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diffs = append(diffs, &DiffLine{Op: DiffOpDelete, Line: from[i]})
+			i++
+		default:
+			diffs = append(diffs, &DiffLine{Op: DiffOpInsert, Line: to[j]})
+			j++
+		}
+	}
+	for ; i < len(from); i++ {
+		diffs = append(diffs, &DiffLine{Op: DiffOpDelete, Line: from[i]})
+	}
+	for ; j < len(to); j++ {
+		diffs = append(diffs, &DiffLine{Op: DiffOpInsert, Line: to[j]})
+	}
+
+	for _, line := range fromLines[len(fromLines)-nSuffix:] {
+		diffs = append(diffs, &DiffLine{Op: DiffOpEqual, Line: line})
+	}
+
+	return diffs
+}
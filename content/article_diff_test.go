@@ -0,0 +1,108 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	// prepare test-cases
+	tests := []struct {
+		name string
+		from []string
+		to   []string
+		want []string
+	}{
+		{
+			name: "same",
+			from: []string{"a", "b"},
+			to:   []string{"a", "b"},
+			want: []string{" a", " b"},
+		},
+		{
+			name: "insert and delete",
+			from: []string{"a", "b", "c", "d"},
+			to:   []string{"a", "c", "e", "d"},
+			want: []string{" a", "-b", " c", "+e", " d"},
+		},
+		{
+			name: "replace all",
+			from: []string{"a"},
+			to:   []string{"b", "c"},
+			want: []string{"-a", "+b", "+c"},
+		},
+		{
+			name: "empty",
+			from: nil,
+			to:   []string{"a"},
+			want: []string{"+a"},
+		},
+	}
+
+	toBytes := func(lines []string) [][]byte {
+		theBytes := make([][]byte, len(lines))
+		for i, line := range lines {
+			theBytes[i] = []byte(line)
+		}
+		return theBytes
+	}
+
+	opPrefix := map[DiffOp]string{DiffOpEqual: " ", DiffOpInsert: "+", DiffOpDelete: "-"}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs := DiffLines(toBytes(tt.from), toBytes(tt.to))
+
+			got := make([]string, len(diffs))
+			for i, diff := range diffs {
+				got[i] = opPrefix[diff.Op] + string(diff.Line)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffLines() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// teardown test
+}
+
+func TestDiffLines_MaxDiffCells(t *testing.T) {
+	origMaxDiffCells := MaxDiffCells
+	MaxDiffCells = 4
+	defer func() { MaxDiffCells = origMaxDiffCells }()
+
+	from := [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("z")}
+	to := [][]byte{[]byte("a"), []byte("d"), []byte("c"), []byte("e"), []byte("z")}
+
+	diffs := DiffLines(from, to)
+
+	got := make([]DiffOp, len(diffs))
+	for i, diff := range diffs {
+		got[i] = diff.Op
+	}
+	want := []DiffOp{DiffOpEqual, DiffOpDelete, DiffOpDelete, DiffOpInsert, DiffOpInsert, DiffOpInsert, DiffOpEqual}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffLines() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/pttdb"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/*
+ArticleRevision records one version of the content of the article.
+
+The content blocks of the revision are kept in the block-db until the revision is pruned.
+*/
+type ArticleRevision struct {
+	V         types.Version
+	EntityID  *types.PttID          `json:"EID"`
+	ArticleID *types.PttID          `json:"AID"`
+	Rev       uint32                `json:"R"`
+	LogID     *types.PttID          `json:"l"`
+	UpdateTS  types.Timestamp       `json:"UT"`
+	UpdaterID *types.PttID          `json:"UID"`
+	BlockInfo *pkgservice.BlockInfo `json:"b"`
+}
+
+func (r *ArticleRevision) MarshalKey() ([]byte, error) {
+	revBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(revBytes, r.Rev)
+
+	return common.Concat([][]byte{DBArticleRevisionPrefix, r.EntityID[:], r.ArticleID[:], revBytes})
+}
+
+func (r *ArticleRevision) Marshal() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *ArticleRevision) Unmarshal(theBytes []byte) error {
+	return json.Unmarshal(theBytes, r)
+}
+
+func (a *Article) MarshalRevisionPrefix() ([]byte, error) {
+	return common.Concat([][]byte{DBArticleRevisionPrefix, a.EntityID[:], a.ID[:]})
+}
+
+/*
+SaveRevision saves the current content of the article as a new revision,
+and prunes the revisions exceeding MaxArticleRevisions.
+
+The revision is not saved if the content is the same as the latest revision.
+*/
+func (a *Article) SaveRevision(oplog *pkgservice.BaseOplog) error {
+	blockInfo := a.GetBlockInfo()
+	if blockInfo == nil {
+		return pkgservice.ErrInvalidBlock
+	}
+
+	revs, err := a.GetRevisions()
+	if err != nil {
+		return err
+	}
+
+	rev := uint32(0)
+	if len(revs) != 0 {
+		lastRev := revs[len(revs)-1]
+		if reflect.DeepEqual(lastRev.BlockInfo.ID, blockInfo.ID) {
+			return nil
+		}
+		rev = lastRev.Rev + 1
+	}
+
+	updaterID := blockInfo.UpdaterID
+	if updaterID == nil {
+		updaterID = oplog.CreatorID
+	}
+
+	r := &ArticleRevision{
+		V:         types.CurrentVersion,
+		EntityID:  a.EntityID,
+		ArticleID: a.ID,
+		Rev:       rev,
+		LogID:     oplog.ID,
+		UpdateTS:  oplog.UpdateTS,
+		UpdaterID: updaterID,
+		BlockInfo: blockInfo,
+	}
+
+	key, err := r.MarshalKey()
+	if err != nil {
+		return err
+	}
+	marshaled, err := r.Marshal()
+	if err != nil {
+		return err
+	}
+
+	err = dbBoardCore.Put(key, marshaled)
+	if err != nil {
+		return err
+	}
+
+	revs = append(revs, r)
+
+	return a.pruneRevisions(revs)
+}
+
+/*
+GetRevisions gets the revisions of the article, from the oldest to the newest.
+*/
+func (a *Article) GetRevisions() ([]*ArticleRevision, error) {
+	prefix, err := a.MarshalRevisionPrefix()
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := dbBoardCore.NewIteratorWithPrefix(nil, prefix, pttdb.ListOrderNext)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	setBlockInfoDB := a.SetBlockInfoDB()
+
+	revs := make([]*ArticleRevision, 0)
+	for iter.Next() {
+		r := &ArticleRevision{}
+		err = r.Unmarshal(iter.Value())
+		if err != nil {
+			log.Warn("GetRevisions: unable to unmarshal", "e", err)
+			continue
+		}
+		if r.BlockInfo == nil {
+			continue
+		}
+		setBlockInfoDB(r.BlockInfo, a.ID)
+
+		revs = append(revs, r)
+	}
+
+	return revs, nil
+}
+
+/*
+GetRevision gets the revision of the article.
+*/
+func (a *Article) GetRevision(rev uint32) (*ArticleRevision, error) {
+	r := &ArticleRevision{EntityID: a.EntityID, ArticleID: a.ID, Rev: rev}
+
+	key, err := r.MarshalKey()
+	if err != nil {
+		return nil, err
+	}
+
+	theBytes, err := dbBoardCore.Get(key)
+	if err == leveldb.ErrNotFound {
+		return nil, ErrInvalidRevision
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Unmarshal(theBytes)
+	if err != nil {
+		return nil, err
+	}
+	if r.BlockInfo == nil {
+		return nil, pkgservice.ErrInvalidBlock
+	}
+
+	setBlockInfoDB := a.SetBlockInfoDB()
+	setBlockInfoDB(r.BlockInfo, a.ID)
+
+	return r, nil
+}
+
+/*
+pruneRevisions removes the oldest revisions exceeding MaxArticleRevisions.
+The blocks of the current content are never removed.
+*/
+func (a *Article) pruneRevisions(revs []*ArticleRevision) error {
+	maxRevisions := MaxArticleRevisions
+	if maxRevisions < 1 {
+		maxRevisions = 1
+	}
+
+	if len(revs) <= maxRevisions {
+		return nil
+	}
+
+	var blockInfoID *types.PttID
+	blockInfo := a.GetBlockInfo()
+	if blockInfo != nil {
+		blockInfoID = blockInfo.ID
+	}
+
+	for _, r := range revs[:len(revs)-maxRevisions] {
+		a.removeRevision(r, blockInfoID)
+	}
+
+	return nil
+}
+
+/*
+DeleteRevisions removes all the revisions of the article,
+except the blocks of the current content (removed along with the article).
+*/
+func (a *Article) DeleteRevisions() error {
+	revs, err := a.GetRevisions()
+	if err != nil {
+		return err
+	}
+
+	var blockInfoID *types.PttID
+	blockInfo := a.GetBlockInfo()
+	if blockInfo != nil {
+		blockInfoID = blockInfo.ID
+	}
+
+	for _, r := range revs {
+		a.removeRevision(r, blockInfoID)
+	}
+
+	return nil
+}
+
+func (a *Article) removeRevision(r *ArticleRevision, blockInfoID *types.PttID) error {
+	if !reflect.DeepEqual(r.BlockInfo.ID, blockInfoID) {
+		// keep the medias, which may still be referred by the article.
+		r.BlockInfo.MediaIDs = nil
+		err := r.BlockInfo.Remove(false)
+		if err != nil {
+			log.Warn("removeRevision: unable to remove block", "e", err)
+		}
+	}
+
+	key, err := r.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	return dbBoardCore.Delete(key)
+}
@@ -28,8 +28,8 @@ import (
 func (b *Backend) CreateBoard(title []byte, isPrivate bool) (*BackendCreateBoard, error) {
 	entityType := pkgservice.EntityTypePrivate
 
-	if isPrivate {
-		entityType = pkgservice.EntityTypePrivate
+	if !isPrivate {
+		entityType = pkgservice.EntityTypePublic
 	}
 
 	board, err := b.SPM().(*ServiceProtocolManager).CreateBoard(title, entityType)
@@ -42,7 +42,7 @@ func (b *Backend) CreateBoard(title []byte, isPrivate bool) (*BackendCreateBoard
 	return backendBoard, nil
 }
 
-func (b *Backend) CreateArticle(entityIDBytes []byte, title []byte, article [][]byte, mediaIDStrs []string) (*BackendCreateArticle, error) {
+func (b *Backend) CreateArticle(entityIDBytes []byte, title []byte, article [][]byte, mediaIDStrs []string, format ArticleFormat) (*BackendCreateArticle, error) {
 
 	thePM, err := b.EntityIDToPM(entityIDBytes)
 	if err != nil {
@@ -64,7 +64,7 @@ func (b *Backend) CreateArticle(entityIDBytes []byte, title []byte, article [][]
 		}
 	}
 
-	theArticle, err := pm.CreateArticle(title, article, mediaIDs)
+	theArticle, err := pm.CreateArticle(title, article, mediaIDs, format)
 	if err != nil {
 		return nil, err
 	}
@@ -224,7 +224,152 @@ func (b *Backend) InviteMaster(boardID []byte, userID []byte, nodeURL []byte) (*
 
 func (b *Backend) TransferMaster(boardID []byte, userID []byte) (*BackendRevokeMaster, error) {
 
-	return nil, types.ErrNotImplemented
+	id, err := types.UnmarshalTextPttID(userID, false)
+	if err != nil {
+		return nil, err
+	}
+	thePM, err := b.EntityIDToPM(boardID)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	err = pm.TransferMaster(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BackendRevokeMaster{}, nil
+}
+
+func (b *Backend) AddMaster(entityIDBytes []byte, userIDBytes []byte) (bool, error) {
+
+	userID, err := types.UnmarshalTextPttID(userIDBytes, false)
+	if err != nil {
+		return false, err
+	}
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	if !pm.IsMember(userID, false) {
+		return false, types.ErrInvalidID
+	}
+
+	_, _, err = pm.AddMaster(userID, false)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+/**********
+ * Master Quorum
+ **********/
+
+func (b *Backend) SetMasterQuorum(entityIDBytes []byte, quorum uint32) (bool, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	err = pm.SetMasterQuorum(quorum)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) GetMasterQuorum(entityIDBytes []byte) (*BackendMasterQuorum, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	return &BackendMasterQuorum{
+		Quorum:  pm.MasterQuorum(),
+		NMaster: len(pm.GetMasters()),
+	}, nil
+}
+
+func (b *Backend) GetPendingQuorumOplogs(entityIDBytes []byte) ([]*BackendPendingQuorumOplog, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	myID := b.Ptt().GetMyEntity().GetID()
+	quorum := pm.MasterQuorum()
+
+	masterLogs, err := pm.GetPendingQuorumOplogs(pm.SetMasterDB)
+	if err != nil {
+		return nil, err
+	}
+
+	boardLogs, err := pm.GetPendingQuorumBoardOplogs()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*BackendPendingQuorumOplog, 0, len(masterLogs)+len(boardLogs))
+	for _, eachLog := range masterLogs {
+		results = append(results, oplogToBackendPendingQuorumOplog(eachLog, "master", quorum, myID))
+	}
+	for _, eachLog := range boardLogs {
+		results = append(results, oplogToBackendPendingQuorumOplog(eachLog, "board", quorum, myID))
+	}
+
+	return results, nil
+}
+
+func (b *Backend) CoSignMasterOplog(entityIDBytes []byte, logIDBytes []byte) (bool, error) {
+
+	logID, err := types.UnmarshalTextPttID(logIDBytes, false)
+	if err != nil {
+		return false, err
+	}
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	err = pm.CoSignMasterOplog(logID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) CoSignBoardOplog(entityIDBytes []byte, logIDBytes []byte) (bool, error) {
+
+	logID, err := types.UnmarshalTextPttID(logIDBytes, false)
+	if err != nil {
+		return false, err
+	}
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	err = pm.CoSignBoardOplog(logID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
 func (b *Backend) GetBoard(entityIDBytes []byte) (*BackendGetBoard, error) {
@@ -287,6 +432,17 @@ func (b *Backend) GetBoardList(startingIDBytes []byte, limit int, listOrder pttd
 	return backendBoardList, nil
 }
 
+func (b *Backend) GetPublicBoardList() ([]*BackendPublicBoard, error) {
+	publicEntities := b.Ptt().GetPublicEntities(b.Name())
+
+	backendBoardList := make([]*BackendPublicBoard, len(publicEntities))
+	for i, entity := range publicEntities {
+		backendBoardList[i] = publicEntityToBackendPublicBoard(entity)
+	}
+
+	return backendBoardList, nil
+}
+
 func (b *Backend) GetArticle(entityIDBytes []byte, articleIDBytes []byte) (*BackendGetArticle, error) {
 
 	article, err := b.GetRawArticle(entityIDBytes, articleIDBytes)
@@ -297,6 +453,93 @@ func (b *Backend) GetArticle(entityIDBytes []byte, articleIDBytes []byte) (*Back
 	return articleToBackendGetArticle(article), nil
 }
 
+func (b *Backend) GetArticleRendered(entityIDBytes []byte, articleIDBytes []byte) (*BackendGetArticleRendered, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	articleID, err := types.UnmarshalTextPttID(articleIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	if articleID == nil {
+		return nil, types.ErrInvalidID
+	}
+
+	article, rendered, err := pm.GetArticleRendered(articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BackendGetArticleRendered{
+		ID:      article.ID,
+		BoardID: article.EntityID,
+		Format:  article.Format,
+		HTML:    rendered,
+	}, nil
+}
+
+func (b *Backend) GetArticleRevisions(entityIDBytes []byte, articleIDBytes []byte) ([]*BackendArticleRevision, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	articleID, err := types.UnmarshalTextPttID(articleIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	if articleID == nil {
+		return nil, types.ErrInvalidID
+	}
+
+	revs, err := pm.GetArticleRevisions(articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	backendRevs := make([]*BackendArticleRevision, len(revs))
+	for i, r := range revs {
+		backendRevs[i] = articleRevisionToBackendArticleRevision(r)
+	}
+
+	return backendRevs, nil
+}
+
+func (b *Backend) GetArticleDiff(entityIDBytes []byte, articleIDBytes []byte, fromRev uint32, toRev uint32) (*BackendArticleDiff, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	articleID, err := types.UnmarshalTextPttID(articleIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	if articleID == nil {
+		return nil, types.ErrInvalidID
+	}
+
+	lines, err := pm.GetArticleDiff(articleID, fromRev, toRev)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BackendArticleDiff{
+		ID:      articleID,
+		FromRev: fromRev,
+		ToRev:   toRev,
+		Lines:   lines,
+	}, nil
+}
+
 func (b *Backend) GetRawArticle(entityIDBytes []byte, articleIDBytes []byte) (*Article, error) {
 
 	thePM, err := b.EntityIDToPM(entityIDBytes)
@@ -360,7 +603,17 @@ func (b *Backend) GetArticleBlockList(entityIDBytes []byte, articleIDBytes []byt
 		return nil, err
 	}
 
-	return articleBlockList, nil
+	// hide the comments from the blocked users
+	ptt := b.Ptt()
+	theList := make([]*ArticleBlock, 0, len(articleBlockList))
+	for _, articleBlock := range articleBlockList {
+		if articleBlock.ContentType == ContentTypeComment && ptt.IsBlockedUser(articleBlock.CreatorID) {
+			continue
+		}
+		theList = append(theList, articleBlock)
+	}
+
+	return theList, nil
 }
 
 func (b *Backend) GetArticleList(entityIDBytes []byte, startingArticleIDBytes []byte, limit int, listOrder pttdb.ListOrder) ([]*BackendGetArticle, error) {
@@ -376,13 +629,39 @@ func (b *Backend) GetArticleList(entityIDBytes []byte, startingArticleIDBytes []
 		return nil, err
 	}
 
-	articleList, err := pm.GetArticleList(startID, limit, listOrder, false)
-	if err != nil {
-		return nil, err
-	}
-	theList := make([]*BackendGetArticle, len(articleList))
-	for i, article := range articleList {
-		theList[i] = articleToBackendGetArticle(article)
+	// hide the articles from the blocked users,
+	// and keep fetching until limit articles are visible.
+	ptt := b.Ptt()
+	theList := make([]*BackendGetArticle, 0)
+	nFetch := limit
+	for {
+		articleList, err := pm.GetArticleList(startID, nFetch, listOrder, false)
+		if err != nil {
+			return nil, err
+		}
+		nArticles := len(articleList)
+
+		// the start-article is already in the previous fetch.
+		if nFetch != limit && nArticles > 0 {
+			articleList = articleList[1:]
+		}
+
+		for _, article := range articleList {
+			if ptt.IsBlockedUser(article.CreatorID) {
+				continue
+			}
+			theList = append(theList, articleToBackendGetArticle(article))
+			if len(theList) == limit {
+				return theList, nil
+			}
+		}
+
+		if limit <= 0 || nArticles < nFetch {
+			break
+		}
+
+		startID = articleList[len(articleList)-1].ID
+		nFetch = limit + 1
 	}
 
 	return theList, nil
@@ -420,12 +699,108 @@ func (b *Backend) ShowBoardURL(entityIDBytes []byte) (*pkgservice.BackendJoinURL
 	if err != nil {
 		return nil, err
 	}
-	title := board.Title
-	if theTitle != nil {
-		title = theTitle.Title
+	title := board.Title
+	if theTitle != nil {
+		title = theTitle.Title
+	}
+
+	return pkgservice.MarshalBackendJoinURL(board.CreatorID, nodeID, keyInfo, title, pkgservice.PathJoinBoard)
+}
+
+func (b *Backend) ShowBoardURLQRCode(entityIDBytes []byte, format []byte, size int) (*pkgservice.BackendJoinURLQRCode, error) {
+	joinURL, err := b.ShowBoardURL(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return pkgservice.JoinURLToQRCode(joinURL, string(format), size)
+}
+
+/*
+CreateInvite creates the invite-url of the board with the max-use count, the expire-seconds and the optional bound invitee.
+*/
+func (b *Backend) CreateInvite(entityIDBytes []byte, maxUse uint32, expireSeconds int64, inviteeIDBytes []byte) (*pkgservice.BackendJoinURL, error) {
+
+	inviteeID, err := types.UnmarshalTextPttID(inviteeIDBytes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	theEntity, err := b.EntityIDToEntity(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	board := theEntity.(*Board)
+	pm := board.PM().(*ProtocolManager)
+
+	nodeID := b.Ptt().MyNodeID()
+
+	expireTS := types.ZeroTimestamp
+	if expireSeconds > 0 {
+		expireTS, err = types.GetTimestamp()
+		if err != nil {
+			return nil, err
+		}
+		expireTS.Ts += expireSeconds
+	}
+
+	invite, err := pm.CreateInvite(expireTS, maxUse, inviteeID)
+	if err != nil {
+		return nil, err
+	}
+
+	theTitle, err := pm.GetTitle()
+	if err != nil {
+		return nil, err
+	}
+	title := board.Title
+	if theTitle != nil {
+		title = theTitle.Title
+	}
+
+	return pkgservice.MarshalBackendInviteURL(board.CreatorID, nodeID, invite, title, pkgservice.PathJoinBoard)
+}
+
+func (b *Backend) RevokeInvite(entityIDBytes []byte, inviteIDBytes []byte) (bool, error) {
+
+	inviteID, err := types.UnmarshalTextPttID(inviteIDBytes, false)
+	if err != nil {
+		return false, err
+	}
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	err = pm.RevokeInvite(inviteID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) GetInvites(entityIDBytes []byte) ([]*pkgservice.BackendInvite, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	invites, err := pm.GetInvites()
+	if err != nil {
+		return nil, err
+	}
+
+	backendInvites := make([]*pkgservice.BackendInvite, len(invites))
+	for i, invite := range invites {
+		backendInvites[i] = pkgservice.InviteToBackendInvite(invite)
 	}
 
-	return pkgservice.MarshalBackendJoinURL(board.CreatorID, nodeID, keyInfo, title, pkgservice.PathJoinBoard)
+	return backendInvites, nil
 }
 
 /**********
@@ -668,7 +1043,17 @@ func (b *Backend) MarkBoardSeen(entityIDBytes []byte) (types.Timestamp, error) {
 	}
 	pm := thePM.(*ProtocolManager)
 
-	return pm.SaveLastSeen(types.ZeroTimestamp)
+	ts, err := pm.SaveLastSeen(types.ZeroTimestamp)
+	if err != nil {
+		return types.ZeroTimestamp, err
+	}
+
+	err = b.Ptt().GetMyEntity().SyncSeen(pkgservice.SeenTypeBoard, pm.Entity().GetID(), nil, ts)
+	if err != nil {
+		log.Warn("MarkBoardSeen: unable to sync seen", "e", err)
+	}
+
+	return ts, nil
 }
 
 func (b *Backend) MarkArticleSeen(entityIDBytes []byte, articleIDBytes []byte) (types.Timestamp, error) {
@@ -684,7 +1069,17 @@ func (b *Backend) MarkArticleSeen(entityIDBytes []byte, articleIDBytes []byte) (
 		return types.ZeroTimestamp, err
 	}
 
-	return pm.SaveArticleLastSeen(articleID, types.ZeroTimestamp)
+	ts, err := pm.SaveArticleLastSeen(articleID, types.ZeroTimestamp)
+	if err != nil {
+		return types.ZeroTimestamp, err
+	}
+
+	err = b.Ptt().GetMyEntity().SyncSeen(pkgservice.SeenTypeArticle, pm.Entity().GetID(), articleID, ts)
+	if err != nil {
+		log.Warn("MarkArticleSeen: unable to sync seen", "e", err)
+	}
+
+	return ts, nil
 }
 
 func (b *Backend) SetTitle(entityIDBytes []byte, title []byte) (*BackendGetBoard, error) {
@@ -724,6 +1119,23 @@ func (b *Backend) SetTitle(entityIDBytes []byte, title []byte) (*BackendGetBoard
 	return boardToBackendGetBoard(board, string(myName), theTitle, myID), nil
 }
 
+/*
+SetBoardNotify sets the local-only notification preference of the board.
+*/
+func (b *Backend) SetBoardNotify(entityIDBytes []byte, setting pkgservice.NotifySetting) (bool, error) {
+	entity, err := b.EntityIDToEntity(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+
+	err = pkgservice.SetNotifySetting(entity.GetID(), setting)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (b *Backend) GetRawTitle(entityIDBytes []byte) (*Title, error) {
 
 	entityID, err := types.UnmarshalTextPttID(entityIDBytes, false)
@@ -770,3 +1182,288 @@ func (b *Backend) DeleteMember(entityIDBytes []byte, userIDBytes []byte) (bool,
 
 	return pm.DeleteMember(userID)
 }
+
+func (b *Backend) SetMemberRole(entityIDBytes []byte, userIDBytes []byte, role pkgservice.MemberRole) (bool, error) {
+
+	userID, err := types.UnmarshalTextPttID(userIDBytes, false)
+	if err != nil {
+		return false, err
+	}
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	err = pm.SetMemberRole(userID, role)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) GetMemberRoles(entityIDBytes []byte) ([]*pkgservice.MemberRoleInfo, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	return pm.GetMemberRoles()
+}
+
+func (b *Backend) BanMember(entityIDBytes []byte, userIDBytes []byte, isBanned bool) (bool, error) {
+
+	userID, err := types.UnmarshalTextPttID(userIDBytes, false)
+	if err != nil {
+		return false, err
+	}
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	err = pm.BanMember(userID, isBanned)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) MuteMember(entityIDBytes []byte, userIDBytes []byte, seconds int64) (bool, error) {
+
+	userID, err := types.UnmarshalTextPttID(userIDBytes, false)
+	if err != nil {
+		return false, err
+	}
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	// unmute if seconds is 0.
+	expireTS := types.ZeroTimestamp
+	if seconds > 0 {
+		expireTS, err = types.GetTimestamp()
+		if err != nil {
+			return false, err
+		}
+		expireTS.Ts += seconds
+	}
+
+	err = pm.MuteMember(userID, expireTS)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) GetBanList(entityIDBytes []byte) ([]*pkgservice.MemberBanInfo, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	return pm.GetBanList()
+}
+
+func (b *Backend) CreateDraft(entityIDBytes []byte, title []byte, article [][]byte, mediaIDStrs []string, format ArticleFormat, publishTS types.Timestamp) (*Draft, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	mediaIDs, err := mediaIDStrsToIDs(mediaIDStrs)
+	if err != nil {
+		return nil, err
+	}
+
+	return pm.CreateDraft(title, article, mediaIDs, format, publishTS)
+}
+
+func (b *Backend) UpdateDraft(entityIDBytes []byte, draftIDBytes []byte, title []byte, article [][]byte, mediaIDStrs []string, format ArticleFormat, publishTS types.Timestamp) (*Draft, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	draftID, err := types.UnmarshalTextPttID(draftIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	if draftID == nil {
+		return nil, types.ErrInvalidID
+	}
+
+	mediaIDs, err := mediaIDStrsToIDs(mediaIDStrs)
+	if err != nil {
+		return nil, err
+	}
+
+	return pm.UpdateDraft(draftID, title, article, mediaIDs, format, publishTS)
+}
+
+func (b *Backend) GetDraft(entityIDBytes []byte, draftIDBytes []byte) (*Draft, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	draftID, err := types.UnmarshalTextPttID(draftIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	if draftID == nil {
+		return nil, types.ErrInvalidID
+	}
+
+	return pm.GetDraft(draftID)
+}
+
+func (b *Backend) GetDraftList(entityIDBytes []byte) ([]*Draft, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	return pm.GetDraftList()
+}
+
+func (b *Backend) DeleteDraft(entityIDBytes []byte, draftIDBytes []byte) (bool, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	draftID, err := types.UnmarshalTextPttID(draftIDBytes, false)
+	if err != nil {
+		return false, err
+	}
+	if draftID == nil {
+		return false, types.ErrInvalidID
+	}
+
+	err = pm.DeleteDraft(draftID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) PublishDraft(entityIDBytes []byte, draftIDBytes []byte) (*BackendCreateArticle, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	draftID, err := types.UnmarshalTextPttID(draftIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	if draftID == nil {
+		return nil, types.ErrInvalidID
+	}
+
+	theArticle, err := pm.PublishDraft(draftID)
+	if err != nil {
+		return nil, err
+	}
+
+	return articleToBackendCreateArticle(theArticle), nil
+}
+
+func mediaIDStrsToIDs(mediaIDStrs []string) ([]*types.PttID, error) {
+	if len(mediaIDStrs) == 0 {
+		return nil, nil
+	}
+
+	mediaIDs := make([]*types.PttID, len(mediaIDStrs))
+	for i, mediaIDStr := range mediaIDStrs {
+		mediaID, err := types.UnmarshalTextPttID([]byte(mediaIDStr), false)
+		if err != nil {
+			return nil, err
+		}
+		mediaIDs[i] = mediaID
+	}
+
+	return mediaIDs, nil
+}
+
+func (b *Backend) ForwardArticle(fromEntityIDBytes []byte, articleIDBytes []byte, toEntityIDBytes []byte) (*BackendCreateArticle, error) {
+
+	theFromPM, err := b.EntityIDToPM(fromEntityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	fromPM := theFromPM.(*ProtocolManager)
+
+	thePM, err := b.EntityIDToPM(toEntityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	articleID, err := types.UnmarshalTextPttID(articleIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	if articleID == nil {
+		return nil, types.ErrInvalidID
+	}
+
+	theArticle, err := pm.ForwardArticle(fromPM, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return articleToBackendCreateArticle(theArticle), nil
+}
+
+func (b *Backend) VerifyArticleProvenance(entityIDBytes []byte, articleIDBytes []byte) (bool, error) {
+	article, err := b.GetRawArticle(entityIDBytes, articleIDBytes)
+	if err != nil {
+		return false, err
+	}
+	if article.Provenance == nil {
+		return false, ErrInvalidProvenance
+	}
+
+	boardIDBytes, err := article.Provenance.BoardID.MarshalText()
+	if err != nil {
+		return false, err
+	}
+
+	thePM, err := b.EntityIDToPM(boardIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	err = pm.VerifyArticleProvenance(article.Provenance)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -18,6 +18,7 @@ package content
 
 import (
 	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/p2p/discover"
 	pkgservice "github.com/ailabstw/go-pttai/service"
 )
 
@@ -168,6 +169,11 @@ type BackendGetBoard struct {
 	LastSeen        types.Timestamp
 	CreatorID       *types.PttID          `json:"C"`
 	BoardType       pkgservice.EntityType `json:"BT"`
+
+	Notify pkgservice.NotifySetting `json:"NS"`
+
+	NUnreadArticle uint32 `json:"nA"`
+	NUnreadComment uint32 `json:"nC"`
 }
 
 func boardToBackendGetBoard(b *Board, myName string, theTitle *Title, myID *types.PttID) *BackendGetBoard {
@@ -194,6 +200,17 @@ func boardToBackendGetBoard(b *Board, myName string, theTitle *Title, myID *type
 		}
 	*/
 
+	// the muted boards are always seen in the board-list.
+	notify := pkgservice.LoadNotifySetting(b.ID)
+	if notify != pkgservice.NotifySettingAll && lastSeen.IsLess(articleCreateTS) {
+		lastSeen = articleCreateTS
+	}
+
+	unread, err := b.LoadUnread()
+	if err != nil || notify != pkgservice.NotifySettingAll {
+		unread = &BoardUnread{}
+	}
+
 	return &BackendGetBoard{
 		ID:              b.ID,
 		Title:           title,
@@ -205,6 +222,25 @@ func boardToBackendGetBoard(b *Board, myName string, theTitle *Title, myID *type
 		LastSeen:        lastSeen,
 		CreatorID:       b.CreatorID,
 		BoardType:       b.EntityType,
+		Notify:          notify,
+		NUnreadArticle:  unread.NArticle,
+		NUnreadComment:  unread.NComment,
+	}
+}
+
+type BackendPublicBoard struct {
+	ID       *types.PttID
+	Title    []byte           `json:"T"`
+	NodeID   *discover.NodeID `json:"NID"`
+	UpdateTS types.Timestamp  `json:"UT"`
+}
+
+func publicEntityToBackendPublicBoard(e *pkgservice.PublicEntity) *BackendPublicBoard {
+	return &BackendPublicBoard{
+		ID:       e.ID,
+		Title:    e.Title,
+		NodeID:   e.NodeID,
+		UpdateTS: e.UpdateTS,
 	}
 }
 
@@ -222,6 +258,9 @@ type BackendGetArticle struct {
 	CommentCreateTS types.Timestamp `json:"c"`
 	LastSeen        types.Timestamp `json:"L"`
 	Status          types.Status    `json:"S"`
+	Format          ArticleFormat   `json:"F"`
+
+	Provenance *ArticleProvenance `json:"P,omitempty"`
 }
 
 func articleToBackendGetArticle(a *Article) *BackendGetArticle {
@@ -262,9 +301,43 @@ func articleToBackendGetArticle(a *Article) *BackendGetArticle {
 		CommentCreateTS: commentCreateTS,
 		LastSeen:        lastSeen,
 		Status:          a.Status,
+		Format:          a.Format,
+		Provenance:      a.Provenance,
+	}
+}
+
+type BackendGetArticleRendered struct {
+	ID      *types.PttID
+	BoardID *types.PttID  `json:"BID"`
+	Format  ArticleFormat `json:"F"`
+	HTML    string        `json:"H"`
+}
+
+type BackendArticleRevision struct {
+	Rev            uint32          `json:"R"`
+	UpdateTS       types.Timestamp `json:"UT"`
+	UpdaterID      *types.PttID    `json:"UID"`
+	ContentBlockID *types.PttID    `json:"cID"`
+	NBlock         int             `json:"N"`
+}
+
+func articleRevisionToBackendArticleRevision(r *ArticleRevision) *BackendArticleRevision {
+	return &BackendArticleRevision{
+		Rev:            r.Rev,
+		UpdateTS:       r.UpdateTS,
+		UpdaterID:      r.UpdaterID,
+		ContentBlockID: r.BlockInfo.ID,
+		NBlock:         r.BlockInfo.NBlock,
 	}
 }
 
+type BackendArticleDiff struct {
+	ID      *types.PttID
+	FromRev uint32      `json:"F"`
+	ToRev   uint32      `json:"T"`
+	Lines   []*DiffLine `json:"L"`
+}
+
 type BackendGetArticleBlock struct {
 }
 
@@ -344,3 +417,41 @@ type BackendArticleSummaryParams struct {
 	ArticleID      string `json:"A"`
 	ContentBlockID string `json:"B"`
 }
+
+type BackendMasterQuorum struct {
+	Quorum  uint32 `json:"Q"`
+	NMaster int    `json:"N"`
+}
+
+/*
+BackendPendingQuorumOplog is the pending critical oplog waiting for the co-sign of the masters.
+LogType is either "master" or "board", indicating content_coSignMasterOplog or content_coSignBoardOplog.
+*/
+type BackendPendingQuorumOplog struct {
+	LogID     *types.PttID      `json:"ID"`
+	LogType   string            `json:"T"`
+	Op        pkgservice.OpType `json:"O"`
+	CreatorID *types.PttID      `json:"CID"`
+	CreateTS  types.Timestamp   `json:"CT"`
+	SignIDs   []*types.PttID    `json:"S"`
+	Quorum    uint32            `json:"Q"`
+	IsSigned  bool              `json:"s"`
+}
+
+func oplogToBackendPendingQuorumOplog(oplog *pkgservice.BaseOplog, logType string, quorum uint32, myID *types.PttID) *BackendPendingQuorumOplog {
+	signIDs := make([]*types.PttID, len(oplog.MasterSigns))
+	for i, eachSign := range oplog.MasterSigns {
+		signIDs[i] = eachSign.ID
+	}
+
+	return &BackendPendingQuorumOplog{
+		LogID:     oplog.ID,
+		LogType:   logType,
+		Op:        oplog.Op,
+		CreatorID: oplog.CreatorID,
+		CreateTS:  oplog.CreateTS,
+		SignIDs:   signIDs,
+		Quorum:    quorum,
+		IsSigned:  pkgservice.IDInOplogSigns(myID, oplog.MasterSigns),
+	}
+}
@@ -99,6 +99,23 @@ func (b *Board) InitPM(ptt pkgservice.Ptt, service pkgservice.Service) error {
 	return nil
 }
 
+/*
+PublicTitle returns the title announced in the public-board directory.
+*/
+func (b *Board) PublicTitle() []byte {
+	pm, ok := b.PM().(*ProtocolManager)
+	if !ok {
+		return b.Title
+	}
+
+	title, err := pm.GetTitle()
+	if err != nil || title == nil {
+		return b.Title
+	}
+
+	return title.Title
+}
+
 func (b *Board) IdxKey() ([]byte, error) {
 	return common.Concat([][]byte{DBBoardIdxPrefix, b.ID[:]})
 }
@@ -75,6 +75,10 @@ type BoardOpCreateArticle struct {
 	MediaIDs []*types.PttID `json:"ms,omitempty"`
 
 	TitleHash []byte `json:"th"`
+
+	Format ArticleFormat `json:"f,omitempty"`
+
+	Provenance *ArticleProvenance `json:"p,omitempty"`
 }
 
 type BoardOpUpdateArticle struct {
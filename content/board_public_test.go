@@ -0,0 +1,61 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func TestPublicBoard_ReaderNotWritable(t *testing.T) {
+	pm, teardown := tNewBoardPM(t)
+	defer teardown()
+
+	pm.Entity().SetEntityType(pkgservice.EntityTypePublic)
+
+	readerID, _ := types.NewPttID()
+	err := pm.AddReader(readerID)
+	if err != nil {
+		t.Fatalf("AddReader() error = %v", err)
+	}
+
+	if !pm.IsReader(readerID) {
+		t.Errorf("IsReader() = false, want true")
+	}
+
+	if pm.IsWritable(readerID) {
+		t.Errorf("IsWritable() = true, want false")
+	}
+
+	ts, _ := types.GetTimestamp()
+	oplog := &pkgservice.BaseOplog{
+		CreatorID: readerID,
+		CreateTS:  ts,
+		Op:        BoardOpTypeCreateArticle,
+	}
+	if pm.isValidBoardOplogCreator(oplog) {
+		t.Errorf("isValidBoardOplogCreator() = true, want false")
+	}
+
+	// not a reader once the board is not public.
+	pm.Entity().SetEntityType(pkgservice.EntityTypePrivate)
+	if pm.IsReader(readerID) {
+		t.Errorf("IsReader() private = true, want false")
+	}
+}
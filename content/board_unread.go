@@ -0,0 +1,82 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/*
+BoardUnread is the local counters of the unseen articles / comments since the last-seen of the board.
+*/
+type BoardUnread struct {
+	NArticle uint32 `json:"A"`
+	NComment uint32 `json:"C"`
+}
+
+func (b *Board) SaveUnread(unread *BoardUnread) error {
+	key, err := b.MarshalUnreadKey()
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := json.Marshal(unread)
+	if err != nil {
+		return err
+	}
+
+	return dbBoardCore.Put(key, marshaled)
+}
+
+func (b *Board) LoadUnread() (*BoardUnread, error) {
+	unread := &BoardUnread{}
+
+	key, err := b.MarshalUnreadKey()
+	if err != nil {
+		return nil, err
+	}
+	data, err := dbBoardCore.Get(key)
+	if err == leveldb.ErrNotFound {
+		return unread, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, unread)
+	if err != nil {
+		return nil, err
+	}
+
+	return unread, nil
+}
+
+func (b *Board) DeleteUnread() error {
+	key, err := b.MarshalUnreadKey()
+	if err != nil {
+		return err
+	}
+
+	return dbBoardCore.Delete(key)
+}
+
+func (b *Board) MarshalUnreadKey() ([]byte, error) {
+	return common.Concat([][]byte{DBBoardUnreadPrefix, b.ID[:]})
+}
@@ -0,0 +1,133 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+func TestBoardUnread(t *testing.T) {
+	pm, teardown := tNewBoardPM(t)
+	defer teardown()
+
+	board := pm.Entity().(*Board)
+
+	lastSeen := types.Timestamp{Ts: 1000}
+	_, err := pm.SaveLastSeen(lastSeen)
+	if err != nil {
+		t.Fatalf("SaveLastSeen() error = %v", err)
+	}
+
+	oldTS := types.Timestamp{Ts: 900}
+	ts1 := types.Timestamp{Ts: 1100}
+	ts2 := types.Timestamp{Ts: 1200}
+	seenTS := types.Timestamp{Ts: 1300}
+	ts3 := types.Timestamp{Ts: 1400}
+
+	// prepare test-cases
+	tests := []struct {
+		name string
+		run  func() error
+		want BoardUnread
+	}{
+		{
+			name: "create article",
+			run:  func() error { return pm.increaseUnread(ts1, true) },
+			want: BoardUnread{NArticle: 1},
+		},
+		{
+			name: "create article and comments",
+			run: func() error {
+				pm.increaseUnread(ts2, true)
+				pm.increaseUnread(ts1, false)
+				return pm.increaseUnread(ts2, false)
+			},
+			want: BoardUnread{NArticle: 2, NComment: 2},
+		},
+		{
+			name: "create before last-seen",
+			run:  func() error { return pm.increaseUnread(oldTS, true) },
+			want: BoardUnread{NArticle: 2, NComment: 2},
+		},
+		{
+			name: "delete comment",
+			run:  func() error { return pm.decreaseUnread(nil, []types.Timestamp{ts1}) },
+			want: BoardUnread{NArticle: 2, NComment: 1},
+		},
+		{
+			name: "delete article with comment",
+			run:  func() error { return pm.decreaseUnread([]types.Timestamp{ts2}, []types.Timestamp{ts2}) },
+			want: BoardUnread{NArticle: 1},
+		},
+		{
+			name: "delete before last-seen",
+			run:  func() error { return pm.decreaseUnread([]types.Timestamp{oldTS}, nil) },
+			want: BoardUnread{NArticle: 1},
+		},
+		{
+			name: "seen",
+			run: func() error {
+				_, err := pm.SaveLastSeen(seenTS)
+				return err
+			},
+			want: BoardUnread{},
+		},
+		{
+			name: "delete after seen",
+			run:  func() error { return pm.decreaseUnread([]types.Timestamp{ts1}, nil) },
+			want: BoardUnread{},
+		},
+		{
+			name: "create after seen",
+			run:  func() error { return pm.increaseUnread(ts3, false) },
+			want: BoardUnread{NComment: 1},
+		},
+		{
+			name: "delete more than counted",
+			run:  func() error { return pm.decreaseUnread(nil, []types.Timestamp{ts3, ts3}) },
+			want: BoardUnread{},
+		},
+		{
+			name: "delete board",
+			run: func() error {
+				pm.increaseUnread(ts3, true)
+				return board.DeleteUnread()
+			},
+			want: BoardUnread{},
+		},
+	}
+
+	// run test
+	for _, tt := range tests {
+		err := tt.run()
+		if err != nil {
+			t.Errorf("%v: error = %v", tt.name, err)
+			continue
+		}
+
+		got, err := board.LoadUnread()
+		if err != nil {
+			t.Errorf("%v: LoadUnread() error = %v", tt.name, err)
+			continue
+		}
+		if *got != tt.want {
+			t.Errorf("%v: LoadUnread() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
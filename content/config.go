@@ -19,6 +19,8 @@ package content
 type Config struct {
 	DataDir     string
 	KeystoreDir string
+
+	MaxArticleRevisions int
 }
 
 func NewConfig() (*Config, error) {
@@ -0,0 +1,169 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/*
+Draft is the unpublished article. Drafts are local-only and never synced.
+
+The draft is published through the normal CreateArticle-path
+once PublishTS is reached (no scheduled publishing if PublishTS is zero).
+*/
+type Draft struct {
+	V        types.Version
+	ID       *types.PttID
+	EntityID *types.PttID    `json:"BID"`
+	CreateTS types.Timestamp `json:"CT"`
+	UpdateTS types.Timestamp `json:"UT"`
+
+	Title    []byte         `json:"T,omitempty"`
+	Article  [][]byte       `json:"A,omitempty"`
+	MediaIDs []*types.PttID `json:"m,omitempty"`
+	Format   ArticleFormat  `json:"F,omitempty"`
+
+	PublishTS  types.Timestamp `json:"PT"`
+	PublishErr string          `json:"E,omitempty"`
+}
+
+func NewDraft(entityID *types.PttID, title []byte, article [][]byte, mediaIDs []*types.PttID, format ArticleFormat, publishTS types.Timestamp) (*Draft, error) {
+	id, err := types.NewPttID()
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Draft{
+		V:        types.CurrentVersion,
+		ID:       id,
+		EntityID: entityID,
+		CreateTS: ts,
+		UpdateTS: ts,
+
+		Title:    title,
+		Article:  article,
+		MediaIDs: mediaIDs,
+		Format:   format,
+
+		PublishTS: publishTS,
+	}, nil
+}
+
+func (d *Draft) MarshalKey() ([]byte, error) {
+	return common.Concat([][]byte{DBDraftPrefix, d.EntityID[:], d.ID[:]})
+}
+
+func (d *Draft) Marshal() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+func (d *Draft) Unmarshal(theBytes []byte) error {
+	return json.Unmarshal(theBytes, d)
+}
+
+func (d *Draft) Save() error {
+	key, err := d.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := d.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return dbBoardCore.Put(key, marshaled)
+}
+
+func (d *Draft) Get() error {
+	key, err := d.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	theBytes, err := dbBoardCore.Get(key)
+	if err == leveldb.ErrNotFound {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return d.Unmarshal(theBytes)
+}
+
+func (d *Draft) Delete() error {
+	key, err := d.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	return dbBoardCore.Delete(key)
+}
+
+/*
+IsToPublish checks whether the draft is scheduled and the publish-ts is reached.
+*/
+func (d *Draft) IsToPublish(ts types.Timestamp) bool {
+	if d.PublishTS.IsEqual(types.ZeroTimestamp) {
+		return false
+	}
+
+	return !ts.IsLess(d.PublishTS)
+}
+
+/*
+GetDraftList gets the drafts of the board.
+*/
+func GetDraftList(entityID *types.PttID) ([]*Draft, error) {
+	prefix, err := common.Concat([][]byte{DBDraftPrefix, entityID[:]})
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := dbBoardCore.NewIteratorWithPrefix(nil, prefix, pttdb.ListOrderNext)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	drafts := make([]*Draft, 0)
+	for iter.Next() {
+		draft := &Draft{}
+		err = draft.Unmarshal(iter.Value())
+		if err != nil {
+			log.Warn("GetDraftList: unable to unmarshal", "e", err)
+			continue
+		}
+
+		drafts = append(drafts, draft)
+	}
+
+	return drafts, nil
+}
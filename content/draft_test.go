@@ -0,0 +1,202 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+func TestDraft_CreateUpdateDelete(t *testing.T) {
+	pm, teardown := tNewBoardPM(t)
+	defer teardown()
+
+	// create
+	draft, err := pm.CreateDraft([]byte("title"), [][]byte{[]byte("content")}, nil, ArticleFormatPlain, types.ZeroTimestamp)
+	if err != nil {
+		t.Errorf("CreateDraft() error = %v", err)
+		return
+	}
+
+	got, err := pm.GetDraft(draft.ID)
+	if err != nil {
+		t.Errorf("GetDraft() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(got.Title, []byte("title")) {
+		t.Errorf("GetDraft() Title = %s, want %s", got.Title, "title")
+	}
+
+	// update
+	publishTS := types.Timestamp{Ts: draft.CreateTS.Ts + 3600}
+	_, err = pm.UpdateDraft(draft.ID, []byte("title2"), [][]byte{[]byte("content2")}, nil, ArticleFormatPlain, publishTS)
+	if err != nil {
+		t.Errorf("UpdateDraft() error = %v", err)
+		return
+	}
+
+	drafts, _ := pm.GetDraftList()
+	if len(drafts) != 1 {
+		t.Errorf("GetDraftList() = %v, want %v", len(drafts), 1)
+		return
+	}
+	if !reflect.DeepEqual(drafts[0].Title, []byte("title2")) || !drafts[0].PublishTS.IsEqual(publishTS) {
+		t.Errorf("GetDraftList() = %v, want title2 at %v", drafts[0], publishTS)
+	}
+
+	// delete
+	err = pm.DeleteDraft(draft.ID)
+	if err != nil {
+		t.Errorf("DeleteDraft() error = %v", err)
+	}
+
+	_, err = pm.GetDraft(draft.ID)
+	if err != ErrNotFound {
+		t.Errorf("GetDraft() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestPublishDraftLoopCore(t *testing.T) {
+	pm, teardown := tNewBoardPM(t)
+	defer teardown()
+
+	var published [][]byte
+	origPublishDraftArticle := publishDraftArticle
+	publishDraftArticle = func(pm *ProtocolManager, draft *Draft) (*Article, error) {
+		published = append(published, draft.Title)
+		return &Article{}, nil
+	}
+	defer func() { publishDraftArticle = origPublishDraftArticle }()
+
+	ts, _ := types.GetTimestamp()
+	dueTS := types.Timestamp{Ts: ts.Ts - 1}
+	laterTS := types.Timestamp{Ts: ts.Ts + 60}
+
+	due, _ := pm.CreateDraft([]byte("due"), nil, nil, ArticleFormatPlain, dueTS)
+	later, _ := pm.CreateDraft([]byte("later"), nil, nil, ArticleFormatPlain, laterTS)
+	pm.CreateDraft([]byte("unscheduled"), nil, nil, ArticleFormatPlain, types.ZeroTimestamp)
+
+	duration := pm.publishDraftLoopCore()
+
+	if !reflect.DeepEqual(published, [][]byte{[]byte("due")}) {
+		t.Errorf("publishDraftLoopCore() published = %s, want [due]", published)
+	}
+	if duration <= 0 || duration > 60*time.Second {
+		t.Errorf("publishDraftLoopCore() = %v, want (0, 60s]", duration)
+	}
+
+	_, err := pm.GetDraft(due.ID)
+	if err != ErrNotFound {
+		t.Errorf("GetDraft(due) error = %v, want %v", err, ErrNotFound)
+	}
+	_, err = pm.GetDraft(later.ID)
+	if err != nil {
+		t.Errorf("GetDraft(later) error = %v", err)
+	}
+}
+
+func TestPublishScheduledDraft_Edit(t *testing.T) {
+	pm, teardown := tNewBoardPM(t)
+	defer teardown()
+
+	var lockPublished sync.Mutex
+	nPublished := 0
+	origPublishDraftArticle := publishDraftArticle
+	publishDraftArticle = func(pm *ProtocolManager, draft *Draft) (*Article, error) {
+		lockPublished.Lock()
+		defer lockPublished.Unlock()
+		nPublished++
+		return &Article{}, nil
+	}
+	defer func() { publishDraftArticle = origPublishDraftArticle }()
+
+	ts, _ := types.GetTimestamp()
+	dueTS := types.Timestamp{Ts: ts.Ts - 1}
+	laterTS := types.Timestamp{Ts: ts.Ts + 3600}
+
+	// rescheduled before publishing: not published.
+	draft, _ := pm.CreateDraft([]byte("title"), nil, nil, ArticleFormatPlain, dueTS)
+	pm.UpdateDraft(draft.ID, []byte("title"), nil, nil, ArticleFormatPlain, laterTS)
+
+	pm.publishScheduledDraft(draft.ID, ts)
+	if nPublished != 0 {
+		t.Errorf("publishScheduledDraft() published = %v, want %v", nPublished, 0)
+	}
+
+	// edited after publishing: the draft is gone.
+	pm.UpdateDraft(draft.ID, []byte("title"), nil, nil, ArticleFormatPlain, dueTS)
+	pm.publishScheduledDraft(draft.ID, ts)
+	if nPublished != 1 {
+		t.Errorf("publishScheduledDraft() published = %v, want %v", nPublished, 1)
+	}
+
+	_, err := pm.UpdateDraft(draft.ID, []byte("title"), nil, nil, ArticleFormatPlain, dueTS)
+	if err != ErrNotFound {
+		t.Errorf("UpdateDraft() error = %v, want %v", err, ErrNotFound)
+	}
+
+	// concurrent publish and edit: published at most once.
+	nPublished = 0
+	draft, _ = pm.CreateDraft([]byte("title"), nil, nil, ArticleFormatPlain, dueTS)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			pm.publishScheduledDraft(draft.ID, ts)
+		}()
+		go func() {
+			defer wg.Done()
+			pm.UpdateDraft(draft.ID, []byte("edited"), nil, nil, ArticleFormatPlain, dueTS)
+		}()
+	}
+	wg.Wait()
+
+	if nPublished != 1 {
+		t.Errorf("publishScheduledDraft() published = %v, want %v", nPublished, 1)
+	}
+}
+
+func TestNextDraftPublishDuration(t *testing.T) {
+	// prepare test-cases
+	ts := types.Timestamp{Ts: 1000}
+
+	tests := []struct {
+		name   string
+		drafts []*Draft
+		want   time.Duration
+	}{
+		{"no drafts", nil, PublishDraftIdleTime},
+		{"unscheduled", []*Draft{{PublishTS: types.ZeroTimestamp}}, PublishDraftIdleTime},
+		{"earliest", []*Draft{{PublishTS: types.Timestamp{Ts: 1060}}, {PublishTS: types.Timestamp{Ts: 1010}}}, 10 * time.Second},
+		{"past", []*Draft{{PublishTS: types.Timestamp{Ts: 990}}}, 0},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextDraftPublishDuration(tt.drafts, ts); got != tt.want {
+				t.Errorf("nextDraftPublishDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -30,4 +30,10 @@ var (
 	ErrInvalidOP = errors.New("invalid op")
 
 	ErrInvalidTitleLength = errors.New("invalid title length")
+
+	ErrInvalidArticleFormat = errors.New("invalid article format")
+
+	ErrInvalidRevision = errors.New("invalid revision")
+
+	ErrInvalidProvenance = errors.New("invalid provenance")
 )
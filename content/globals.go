@@ -18,6 +18,7 @@ package content
 
 import (
 	"path/filepath"
+	"time"
 
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/log"
@@ -31,6 +32,8 @@ var (
 	DefaultConfig = Config{
 		DataDir:     filepath.Join(node.DefaultDataDir(), "content"),
 		KeystoreDir: filepath.Join(node.DefaultDataDir(), ".keyContent"),
+
+		MaxArticleRevisions: 10,
 	}
 )
 
@@ -119,10 +122,13 @@ var (
 	DBBoardLastSeenPrefix          = []byte(".bdls")
 	DBBoardArticleCreateTSPrefix   = []byte(".bdac")
 	DBBoardCommentCreateTSPrefix   = []byte(".bdcc")
+	DBBoardUnreadPrefix            = []byte(".bdur")
 	DBArticlePrefix                = []byte(".aldb")
 	DBArticleIdxPrefix             = []byte(".alix")
 	DBArticleLastSeenPrefix        = []byte(".alls")
 	DBArticleCommentCreateTSPrefix = []byte(".alcc")
+	DBArticleRevisionPrefix        = []byte(".alrv")
+	DBDraftPrefix                  = []byte(".dfdb")
 	DBPushPrefix                   = []byte(".alps")
 	DBBooPrefix                    = []byte(".albo")
 	DBCommentPrefix                = []byte(".ctdb")
@@ -139,7 +145,7 @@ var (
 
 // max-masters
 const (
-	MaxMasters = 1
+	MaxMasters = 5
 )
 
 // sync
@@ -159,6 +165,20 @@ const (
 	NFirstLineInBlock = 1
 )
 
+var (
+	MaxArticleRevisions = 10
+
+	// the lcs-table in DiffLines is limited to MaxDiffCells (8MB),
+	// the larger diffs are shown as replacing the changed lines.
+	MaxDiffCells = 1 << 20
+)
+
+// draft
+var (
+	PublishDraftIdleTime  = 1 * time.Hour
+	PublishDraftRetryTime = 10 * time.Second
+)
+
 // image
 const (
 	MaxUploadImageSize   = 10485760 // 10MB
@@ -171,6 +191,14 @@ const (
 	PCommentCount = 12
 )
 
+// render
+const (
+	MarkdownMediaScheme = "media:"
+
+	URLImagePath = "/api/img"
+	URLFilePath  = "/api/file"
+)
+
 func InitContent(dataDir string, keystoreDir string) error {
 	var err error
 
@@ -16,7 +16,14 @@
 
 package content
 
-import "testing"
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
 
 const ()
 
@@ -27,3 +34,39 @@ func setupTest(t *testing.T) {
 
 func teardownTest(t *testing.T) {
 }
+
+/*
+tNewBoardPM creates the protocol-manager of an alive board with the content-db in a temp-dir.
+*/
+func tNewBoardPM(t *testing.T) (*ProtocolManager, func()) {
+	dir, err := ioutil.TempDir("", "content-test")
+	if err != nil {
+		t.Fatalf("TempDir() error = %v", err)
+	}
+
+	err = InitContent(dir, dir)
+	if err != nil {
+		t.Fatalf("InitContent() error = %v", err)
+	}
+
+	teardown := func() {
+		TeardownContent()
+		os.RemoveAll(dir)
+	}
+
+	myID, _ := types.NewPttID()
+	boardID, _ := types.NewPttID()
+	ts, _ := types.GetTimestamp()
+	dbLock, _ := types.NewLockMap(0)
+
+	b := &Board{BaseEntity: pkgservice.NewBaseEntity(boardID, ts, myID, types.StatusAlive, dbBoard, dbLock)}
+
+	pm, err := NewProtocolManager(b, nil)
+	if err != nil {
+		teardown()
+		t.Fatalf("NewProtocolManager() error = %v", err)
+	}
+	b.BaseEntity.Init(pm, nil, nil)
+
+	return pm, teardown
+}
@@ -0,0 +1,329 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"bytes"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+/*
+MediaResolver resolves the media-id (as in ![](media:ID)) to the url of the media.
+*/
+type MediaResolver func(mediaID string) (src string, isImage bool, ok bool)
+
+var (
+	mdHeadingRegexp     = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	mdUnorderedRegexp   = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	mdOrderedRegexp     = regexp.MustCompile(`^\s*\d+\.\s+(.*)$`)
+	mdHorizontalRegexp  = regexp.MustCompile(`^\s*([-*_])(\s*([-*_])){2,}\s*$`)
+	mdBlockquoteRegexp  = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	mdFencedCodeRegexp  = regexp.MustCompile("^\\s*```")
+	mdAllowedURLSchemes = map[string]bool{
+		"http":   true,
+		"https":  true,
+		"mailto": true,
+	}
+)
+
+/*
+RenderPlain renders the plain-text article as sanitised html.
+*/
+func RenderPlain(lines [][]byte) string {
+	buf := &bytes.Buffer{}
+	buf.WriteString("<pre>")
+	for i, line := range lines {
+		if i != 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString(html.EscapeString(string(line)))
+	}
+	buf.WriteString("</pre>")
+
+	return buf.String()
+}
+
+/*
+RenderMarkdown renders the markdown article as sanitised html.
+
+Only a subset of markdown is supported (headings, paragraphs, lists, blockquotes, code, emphasis, links and media-images).
+All the text is escaped, only the tags generated by the renderer are in the result.
+Links are restricted to http / https / mailto, and images are restricted to the media in the board (![alt](media:ID)).
+*/
+func RenderMarkdown(lines [][]byte, resolver MediaResolver) string {
+	r := &mdRenderer{resolver: resolver}
+
+	for _, eachLine := range lines {
+		r.renderLine(string(eachLine))
+	}
+	r.closeBlock()
+
+	return r.buf.String()
+}
+
+type mdBlockType uint8
+
+const (
+	mdBlockNone mdBlockType = iota
+	mdBlockParagraph
+	mdBlockUnordered
+	mdBlockOrdered
+	mdBlockQuote
+	mdBlockCode
+)
+
+type mdRenderer struct {
+	buf       bytes.Buffer
+	resolver  MediaResolver
+	blockType mdBlockType
+	nLine     int
+}
+
+func (r *mdRenderer) openBlock(blockType mdBlockType) {
+	if r.blockType == blockType {
+		return
+	}
+	r.closeBlock()
+
+	r.blockType = blockType
+	r.nLine = 0
+
+	switch blockType {
+	case mdBlockParagraph:
+		r.buf.WriteString("<p>")
+	case mdBlockUnordered:
+		r.buf.WriteString("<ul>")
+	case mdBlockOrdered:
+		r.buf.WriteString("<ol>")
+	case mdBlockQuote:
+		r.buf.WriteString("<blockquote>")
+	case mdBlockCode:
+		r.buf.WriteString("<pre><code>")
+	}
+}
+
+func (r *mdRenderer) closeBlock() {
+	switch r.blockType {
+	case mdBlockParagraph:
+		r.buf.WriteString("</p>")
+	case mdBlockUnordered:
+		r.buf.WriteString("</ul>")
+	case mdBlockOrdered:
+		r.buf.WriteString("</ol>")
+	case mdBlockQuote:
+		r.buf.WriteString("</blockquote>")
+	case mdBlockCode:
+		r.buf.WriteString("</code></pre>")
+	}
+
+	r.blockType = mdBlockNone
+	r.nLine = 0
+}
+
+func (r *mdRenderer) renderLine(line string) {
+	// code
+	if r.blockType == mdBlockCode {
+		if mdFencedCodeRegexp.MatchString(line) {
+			r.closeBlock()
+			return
+		}
+		if r.nLine != 0 {
+			r.buf.WriteString("\n")
+		}
+		r.buf.WriteString(html.EscapeString(line))
+		r.nLine++
+		return
+	}
+
+	if mdFencedCodeRegexp.MatchString(line) {
+		r.openBlock(mdBlockCode)
+		return
+	}
+
+	// empty line
+	if len(strings.TrimSpace(line)) == 0 {
+		r.closeBlock()
+		return
+	}
+
+	// horizontal
+	if mdHorizontalRegexp.MatchString(line) {
+		r.closeBlock()
+		r.buf.WriteString("<hr>")
+		return
+	}
+
+	// heading
+	if matches := mdHeadingRegexp.FindStringSubmatch(line); matches != nil {
+		r.closeBlock()
+		level := string('0' + byte(len(matches[1])))
+		r.buf.WriteString("<h" + level + ">")
+		r.buf.WriteString(r.renderInline(matches[2]))
+		r.buf.WriteString("</h" + level + ">")
+		return
+	}
+
+	// list
+	if matches := mdUnorderedRegexp.FindStringSubmatch(line); matches != nil {
+		r.openBlock(mdBlockUnordered)
+		r.buf.WriteString("<li>" + r.renderInline(matches[1]) + "</li>")
+		return
+	}
+
+	if matches := mdOrderedRegexp.FindStringSubmatch(line); matches != nil {
+		r.openBlock(mdBlockOrdered)
+		r.buf.WriteString("<li>" + r.renderInline(matches[1]) + "</li>")
+		return
+	}
+
+	// blockquote
+	if matches := mdBlockquoteRegexp.FindStringSubmatch(line); matches != nil {
+		r.openBlock(mdBlockQuote)
+		if r.nLine != 0 {
+			r.buf.WriteString("<br>")
+		}
+		r.buf.WriteString(r.renderInline(matches[1]))
+		r.nLine++
+		return
+	}
+
+	// paragraph
+	r.openBlock(mdBlockParagraph)
+	if r.nLine != 0 {
+		r.buf.WriteString("<br>")
+	}
+	r.buf.WriteString(r.renderInline(line))
+	r.nLine++
+}
+
+func (r *mdRenderer) renderInline(s string) string {
+	buf := &bytes.Buffer{}
+
+	start := 0
+	flush := func(end int) {
+		buf.WriteString(html.EscapeString(s[start:end]))
+	}
+
+	for i := 0; i < len(s); {
+		n := 0
+		rendered := ""
+
+		switch {
+		case s[i] == '`':
+			if j := strings.IndexByte(s[i+1:], '`'); j >= 0 {
+				rendered = "<code>" + html.EscapeString(s[i+1:i+1+j]) + "</code>"
+				n = j + 2
+			}
+		case s[i] == '!' && strings.HasPrefix(s[i:], "!["):
+			if text, target, lenLink, ok := parseMarkdownLink(s[i+1:]); ok {
+				rendered = r.renderImage(text, target)
+				n = lenLink + 1
+			}
+		case s[i] == '[':
+			if text, target, lenLink, ok := parseMarkdownLink(s[i:]); ok {
+				rendered = r.renderLink(text, target)
+				n = lenLink
+			}
+		case strings.HasPrefix(s[i:], "**"):
+			if j := strings.Index(s[i+2:], "**"); j > 0 {
+				rendered = "<strong>" + r.renderInline(s[i+2:i+2+j]) + "</strong>"
+				n = j + 4
+			}
+		case s[i] == '*':
+			if j := strings.IndexByte(s[i+1:], '*'); j > 0 {
+				rendered = "<em>" + r.renderInline(s[i+1:i+1+j]) + "</em>"
+				n = j + 2
+			}
+		}
+
+		if n == 0 {
+			i++
+			continue
+		}
+
+		flush(i)
+		buf.WriteString(rendered)
+		i += n
+		start = i
+	}
+	flush(len(s))
+
+	return buf.String()
+}
+
+func (r *mdRenderer) renderImage(alt string, target string) string {
+	if r.resolver == nil || !strings.HasPrefix(target, MarkdownMediaScheme) {
+		return html.EscapeString(alt)
+	}
+
+	src, isImage, ok := r.resolver(strings.TrimPrefix(target, MarkdownMediaScheme))
+	if !ok {
+		return html.EscapeString(alt)
+	}
+	if !isImage {
+		return `<a href="` + html.EscapeString(src) + `">` + html.EscapeString(alt) + `</a>`
+	}
+
+	return `<img src="` + html.EscapeString(src) + `" alt="` + html.EscapeString(alt) + `">`
+}
+
+func (r *mdRenderer) renderLink(text string, target string) string {
+	if strings.HasPrefix(target, MarkdownMediaScheme) {
+		if r.resolver == nil {
+			return r.renderInline(text)
+		}
+		src, _, ok := r.resolver(strings.TrimPrefix(target, MarkdownMediaScheme))
+		if !ok {
+			return r.renderInline(text)
+		}
+		return `<a href="` + html.EscapeString(src) + `">` + r.renderInline(text) + `</a>`
+	}
+
+	theURL, err := url.Parse(target)
+	if err != nil || !mdAllowedURLSchemes[strings.ToLower(theURL.Scheme)] {
+		return r.renderInline(text)
+	}
+
+	return `<a href="` + html.EscapeString(theURL.String()) + `" rel="nofollow noopener noreferrer">` + r.renderInline(text) + `</a>`
+}
+
+/*
+parseMarkdownLink parses [text](target) at the beginning of s, and returns the text, the target, and the length of the link.
+*/
+func parseMarkdownLink(s string) (string, string, int, bool) {
+	if len(s) == 0 || s[0] != '[' {
+		return "", "", 0, false
+	}
+
+	endText := strings.IndexByte(s, ']')
+	if endText < 0 || endText+1 >= len(s) || s[endText+1] != '(' {
+		return "", "", 0, false
+	}
+
+	endTarget := strings.IndexByte(s[endText+2:], ')')
+	if endTarget < 0 {
+		return "", "", 0, false
+	}
+
+	text := s[1:endText]
+	target := strings.TrimSpace(s[endText+2 : endText+2+endTarget])
+
+	return text, target, endText + 3 + endTarget, true
+}
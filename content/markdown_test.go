@@ -0,0 +1,90 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import "testing"
+
+func TestRenderMarkdown(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	resolver := func(mediaID string) (string, bool, bool) {
+		switch mediaID {
+		case "img":
+			return "/api/img/board/img", true, true
+		case "file":
+			return "/api/file/board/file", false, true
+		}
+		return "", false, false
+	}
+
+	// prepare test-cases
+	tests := []struct {
+		name  string
+		lines []string
+		want  string
+	}{
+		{
+			name:  "heading and paragraph",
+			lines: []string{"# title", "line1", "line2", "", "line3"},
+			want:  "<h1>title</h1><p>line1<br>line2</p><p>line3</p>",
+		},
+		{
+			name:  "escape",
+			lines: []string{"<script>alert(1)</script> & **<b>**"},
+			want:  "<p>&lt;script&gt;alert(1)&lt;/script&gt; &amp; <strong>&lt;b&gt;</strong></p>",
+		},
+		{
+			name:  "list",
+			lines: []string{"- a", "- *b*", "1. c"},
+			want:  "<ul><li>a</li><li><em>b</em></li></ul><ol><li>c</li></ol>",
+		},
+		{
+			name:  "code",
+			lines: []string{"```", "<a>", "```", "`<b>`"},
+			want:  "<pre><code>&lt;a&gt;</code></pre><p><code>&lt;b&gt;</code></p>",
+		},
+		{
+			name:  "links",
+			lines: []string{"[ok](https://ptt.ai) [bad](javascript:alert(1)) [f](media:file)"},
+			want:  `<p><a href="https://ptt.ai" rel="nofollow noopener noreferrer">ok</a> bad) <a href="/api/file/board/file">f</a></p>`,
+		},
+		{
+			name:  "images",
+			lines: []string{"![pic](media:img) ![missing](media:none) ![remote](https://ptt.ai/a.png)"},
+			want:  `<p><img src="/api/img/board/img" alt="pic"> missing remote</p>`,
+		},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := make([][]byte, len(tt.lines))
+			for i, line := range tt.lines {
+				lines[i] = []byte(line)
+			}
+
+			got := RenderMarkdown(lines, resolver)
+			if got != tt.want {
+				t.Errorf("RenderMarkdown() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// teardown test
+}
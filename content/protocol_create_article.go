@@ -29,9 +29,16 @@ type CreateArticle struct {
 	Title    []byte
 	Article  [][]byte
 	MediaIDs []*types.PttID
+	Format   ArticleFormat
+
+	Provenance *ArticleProvenance
+}
+
+func (pm *ProtocolManager) CreateArticle(title []byte, articleBytes [][]byte, mediaIDs []*types.PttID, format ArticleFormat) (*Article, error) {
+	return pm.createArticle(title, articleBytes, mediaIDs, format, nil)
 }
 
-func (pm *ProtocolManager) CreateArticle(title []byte, articleBytes [][]byte, mediaIDs []*types.PttID) (*Article, error) {
+func (pm *ProtocolManager) createArticle(title []byte, articleBytes [][]byte, mediaIDs []*types.PttID, format ArticleFormat, provenance *ArticleProvenance) (*Article, error) {
 
 	myID := pm.Ptt().GetMyEntity().GetID()
 
@@ -39,10 +46,21 @@ func (pm *ProtocolManager) CreateArticle(title []byte, articleBytes [][]byte, me
 		return nil, types.ErrInvalidID
 	}
 
+	if pm.isMuted(myID) {
+		return nil, pkgservice.ErrMuted
+	}
+
+	if format >= NArticleFormat {
+		return nil, ErrInvalidArticleFormat
+	}
+
 	data := &CreateArticle{
 		Title:    title,
 		Article:  articleBytes,
 		MediaIDs: mediaIDs,
+		Format:   format,
+
+		Provenance: provenance,
 	}
 
 	theArticle, err := pm.CreateObject(
@@ -90,10 +108,11 @@ func (pm *ProtocolManager) NewArticle(theData pkgservice.CreateData) (pkgservice
 
 	opData := &BoardOpCreateArticle{}
 
-	theArticle, err := NewArticle(ts, myID, entityID, nil, types.StatusInit, data.Title)
+	theArticle, err := NewArticle(ts, myID, entityID, nil, types.StatusInit, data.Title, data.Format)
 	if err != nil {
 		return nil, nil, err
 	}
+	theArticle.Provenance = data.Provenance
 	pm.SetArticleDB(theArticle)
 
 	return theArticle, opData, nil
@@ -139,6 +158,8 @@ func (pm *ProtocolManager) increateArticle(theObj pkgservice.Object, theData pkg
 	opData.MediaIDs = data.MediaIDs
 
 	opData.TitleHash = types.Hash(obj.Title)
+	opData.Format = obj.Format
+	opData.Provenance = obj.Provenance
 
 	return nil
 }
@@ -157,14 +178,33 @@ func (pm *ProtocolManager) postcreateArticle(theObj pkgservice.Object, oplog *pk
 	entity := pm.Entity().(*Board)
 	entity.SaveArticleCreateTS(oplog.UpdateTS)
 
+	err := article.SaveRevision(oplog)
+	if err != nil {
+		log.Warn("postcreateArticle: unable to save revision", "e", err)
+	}
+
 	if reflect.DeepEqual(article.CreatorID, myID) {
 		pm.SaveLastSeen(oplog.UpdateTS)
 		return nil
 	}
 
+	err = pm.increaseUnread(article.CreateTS, true)
+	if err != nil {
+		log.Warn("postcreateArticle: unable to increase unread", "e", err)
+	}
+
+	isMentioned := pm.isMentioned(article.GetBlockInfo(), article.ID)
+	if isMentioned {
+		pm.createNotification(pkgservice.NotificationTypeMention, article.CreatorID, article.ID, nil)
+	}
+
+	if !pkgservice.LoadNotifySetting(entity.GetID()).IsNotify(isMentioned) {
+		return nil
+	}
+
 	// I can get only my name and my friends' user name
 	accountSPM := pm.Entity().Service().(*Backend).accountBackend.SPM().(*account.ServiceProtocolManager)
-	_, err := accountSPM.GetUserNameByID(article.CreatorID)
+	_, err = accountSPM.GetUserNameByID(article.CreatorID)
 	if err != nil {
 		return nil
 	}
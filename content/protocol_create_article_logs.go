@@ -86,6 +86,8 @@ func (pm *ProtocolManager) newArticleWithOplog(oplog *pkgservice.BaseOplog, theO
 	obj := NewEmptyArticle()
 	pm.SetArticleDB(obj)
 	pkgservice.NewObjectWithOplog(obj, oplog)
+	obj.Format = opData.Format
+	obj.Provenance = opData.Provenance
 
 	blockInfo, err := pkgservice.NewBlockInfo(opData.BlockInfoID, opData.Hashs, opData.MediaIDs, oplog.CreatorID)
 	if err != nil {
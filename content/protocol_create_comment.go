@@ -33,6 +33,11 @@ type CreateComment struct {
 
 func (pm *ProtocolManager) CreateComment(articleID *types.PttID, commentType CommentType, commentBytes []byte, mediaID *types.PttID) (*Comment, error) {
 
+	myID := pm.Ptt().GetMyEntity().GetID()
+	if pm.isMuted(myID) {
+		return nil, pkgservice.ErrMuted
+	}
+
 	var mediaIDs []*types.PttID
 	if mediaID != nil {
 		mediaIDs = []*types.PttID{mediaID}
@@ -173,10 +178,31 @@ func (pm *ProtocolManager) postcreateComment(theObj pkgservice.Object, oplog *pk
 		article.SaveLastSeen(oplog.UpdateTS)
 		return nil
 	}
+
+	err := pm.increaseUnread(comment.CreateTS, false)
+	if err != nil {
+		log.Warn("postcreateComment: unable to increase unread", "e", err)
+	}
+
+	isMentioned := pm.isMentioned(comment.GetBlockInfo(), comment.ID)
+	if isMentioned {
+		pm.createNotification(pkgservice.NotificationTypeMention, comment.CreatorID, comment.ArticleID, comment.ID)
+	}
+
 	if !reflect.DeepEqual(comment.ArticleCreatorID, myID) {
 		return nil
 	}
 
+	notificationType := pkgservice.NotificationTypeReply
+	if comment.CommentType == CommentTypePush {
+		notificationType = pkgservice.NotificationTypePush
+	}
+	pm.createNotification(notificationType, comment.CreatorID, comment.ArticleID, comment.ID)
+
+	if !pkgservice.LoadNotifySetting(comment.EntityID).IsNotify(isMentioned) {
+		return nil
+	}
+
 	opData := &pkgservice.PttOpCreateComment{
 		BoardID:   comment.EntityID,
 		ArticleID: comment.ArticleID,
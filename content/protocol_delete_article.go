@@ -17,7 +17,11 @@
 package content
 
 import (
+	"reflect"
+
 	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/pttdb"
 	pkgservice "github.com/ailabstw/go-pttai/service"
 )
 
@@ -68,8 +72,55 @@ func (pm *ProtocolManager) postdeleteArticle(id *types.PttID, oplog *pkgservice.
 	comment := NewEmptyComment()
 	pm.SetCommentDB(comment)
 
+	// unread
+	myID := pm.Ptt().GetMyEntity().GetID()
+
+	articleTSs := make([]types.Timestamp, 0, 1)
+	if !reflect.DeepEqual(article.CreatorID, myID) {
+		articleTSs = append(articleTSs, article.CreateTS)
+	}
+
+	commentTSs, err := pm.unreadCommentTSs(article.ID, myID)
+	if err != nil {
+		log.Warn("postdeleteArticle: unable to get comments", "e", err)
+	}
+
+	err = pm.decreaseUnread(articleTSs, commentTSs)
+	if err != nil {
+		log.Warn("postdeleteArticle: unable to decrease unread", "e", err)
+	}
+
 	// postdelete
 	article.Postdelete(comment, true)
 
 	return nil
 }
+
+/*
+unreadCommentTSs returns the create-ts of the comments of the article not created by me.
+*/
+func (pm *ProtocolManager) unreadCommentTSs(articleID *types.PttID, myID *types.PttID) ([]types.Timestamp, error) {
+	comment := NewEmptyComment()
+	pm.SetCommentDB(comment)
+
+	iter, err := comment.GetCrossObjIterWithObj(articleID[:], nil, pttdb.ListOrderNext, false)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	tss := make([]types.Timestamp, 0)
+	for iter.Next() {
+		eachComment := &Comment{}
+		err = eachComment.Unmarshal(iter.Value())
+		if err != nil {
+			continue
+		}
+		if reflect.DeepEqual(eachComment.CreatorID, myID) {
+			continue
+		}
+		tss = append(tss, eachComment.CreateTS)
+	}
+
+	return tss, nil
+}
@@ -55,6 +55,10 @@ func (pm *ProtocolManager) postdeleteBoard(theOpData pkgservice.OpData, isForce
 
 	pm.CleanObject()
 
+	pm.DeleteDrafts()
+
+	pm.Entity().(*Board).DeleteUnread()
+
 	pm.DefaultPostdeleteEntity(theOpData, isForce)
 
 	return nil
@@ -17,7 +17,10 @@
 package content
 
 import (
+	"reflect"
+
 	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
 	pkgservice "github.com/ailabstw/go-pttai/service"
 )
 
@@ -59,5 +62,21 @@ func (pm *ProtocolManager) setPendingDeleteCommentSyncInfo(obj pkgservice.Object
 
 func (pm *ProtocolManager) postdeleteComment(id *types.PttID, oplog *pkgservice.BaseOplog, opData pkgservice.OpData, obj pkgservice.Object, blockInfo *pkgservice.BlockInfo) error {
 
+	comment, ok := obj.(*Comment)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	// unread
+	myID := pm.Ptt().GetMyEntity().GetID()
+	if reflect.DeepEqual(comment.CreatorID, myID) {
+		return nil
+	}
+
+	err := pm.decreaseUnread(nil, []types.Timestamp{comment.CreateTS})
+	if err != nil {
+		log.Warn("postdeleteComment: unable to decrease unread", "e", err)
+	}
+
 	return nil
 }
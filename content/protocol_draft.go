@@ -0,0 +1,297 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"time"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+)
+
+func (pm *ProtocolManager) CreateDraft(title []byte, articleBytes [][]byte, mediaIDs []*types.PttID, format ArticleFormat, publishTS types.Timestamp) (*Draft, error) {
+
+	if format >= NArticleFormat {
+		return nil, ErrInvalidArticleFormat
+	}
+
+	draft, err := NewDraft(pm.Entity().GetID(), title, articleBytes, mediaIDs, format, publishTS)
+	if err != nil {
+		return nil, err
+	}
+
+	err = draft.Save()
+	if err != nil {
+		return nil, err
+	}
+
+	pm.scheduleDrafts()
+
+	return draft, nil
+}
+
+func (pm *ProtocolManager) UpdateDraft(draftID *types.PttID, title []byte, articleBytes [][]byte, mediaIDs []*types.PttID, format ArticleFormat, publishTS types.Timestamp) (*Draft, error) {
+
+	if format >= NArticleFormat {
+		return nil, ErrInvalidArticleFormat
+	}
+
+	err := pm.DBObjLock().Lock(draftID)
+	if err != nil {
+		return nil, err
+	}
+	defer pm.DBObjLock().Unlock(draftID)
+
+	draft, err := pm.GetDraft(draftID)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return nil, err
+	}
+
+	draft.UpdateTS = ts
+	draft.Title = title
+	draft.Article = articleBytes
+	draft.MediaIDs = mediaIDs
+	draft.Format = format
+	draft.PublishTS = publishTS
+	draft.PublishErr = ""
+
+	err = draft.Save()
+	if err != nil {
+		return nil, err
+	}
+
+	pm.scheduleDrafts()
+
+	return draft, nil
+}
+
+func (pm *ProtocolManager) GetDraft(draftID *types.PttID) (*Draft, error) {
+	draft := &Draft{EntityID: pm.Entity().GetID(), ID: draftID}
+
+	err := draft.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return draft, nil
+}
+
+func (pm *ProtocolManager) GetDraftList() ([]*Draft, error) {
+	return GetDraftList(pm.Entity().GetID())
+}
+
+func (pm *ProtocolManager) DeleteDraft(draftID *types.PttID) error {
+
+	err := pm.DBObjLock().Lock(draftID)
+	if err != nil {
+		return err
+	}
+	defer pm.DBObjLock().Unlock(draftID)
+
+	draft, err := pm.GetDraft(draftID)
+	if err != nil {
+		return err
+	}
+
+	return draft.Delete()
+}
+
+/*
+DeleteDrafts deletes all the drafts of the board.
+*/
+func (pm *ProtocolManager) DeleteDrafts() error {
+	drafts, err := pm.GetDraftList()
+	if err != nil {
+		return err
+	}
+
+	for _, draft := range drafts {
+		draft.Delete()
+	}
+
+	return nil
+}
+
+/*
+PublishDraft publishes the draft through CreateArticle, and deletes the draft.
+*/
+func (pm *ProtocolManager) PublishDraft(draftID *types.PttID) (*Article, error) {
+
+	err := pm.DBObjLock().Lock(draftID)
+	if err != nil {
+		return nil, err
+	}
+	defer pm.DBObjLock().Unlock(draftID)
+
+	draft, err := pm.GetDraft(draftID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pm.publishDraft(draft)
+}
+
+func (pm *ProtocolManager) publishDraft(draft *Draft) (*Article, error) {
+	if pm.Entity().GetStatus() != types.StatusAlive {
+		return nil, types.ErrInvalidStatus
+	}
+
+	article, err := publishDraftArticle(pm, draft)
+	if err != nil {
+		return nil, err
+	}
+
+	err = draft.Delete()
+	if err != nil {
+		return nil, err
+	}
+
+	return article, nil
+}
+
+/*
+publishDraftArticle creates the article of the draft. Replaceable in the tests.
+*/
+var publishDraftArticle = func(pm *ProtocolManager, draft *Draft) (*Article, error) {
+	return pm.CreateArticle(draft.Title, draft.Article, draft.MediaIDs, draft.Format)
+}
+
+/*
+scheduleDrafts notifies PublishDraftLoop to reschedule with the updated drafts.
+*/
+func (pm *ProtocolManager) scheduleDrafts() {
+	select {
+	case pm.draftScheduleC <- struct{}{}:
+	default:
+	}
+}
+
+/*
+PublishDraftLoop publishes the scheduled drafts at the publish-ts of the next scheduled draft,
+and reschedules when the drafts are created / updated.
+*/
+func (pm *ProtocolManager) PublishDraftLoop() {
+	timer := time.NewTimer(pm.publishDraftLoopCore())
+
+loop:
+	for {
+		select {
+		case <-timer.C:
+			timer.Stop()
+			timer = time.NewTimer(pm.publishDraftLoopCore())
+		case <-pm.draftScheduleC:
+			timer.Stop()
+			timer = time.NewTimer(pm.publishDraftLoopCore())
+		case <-pm.QuitSync():
+			log.Debug("PublishDraftLoop: QuitSync", "entity", pm.Entity().GetID())
+			break loop
+		}
+	}
+
+	timer.Stop()
+}
+
+/*
+publishDraftLoopCore publishes the drafts reaching the publish-ts, and returns the duration to the next scheduled draft.
+*/
+func (pm *ProtocolManager) publishDraftLoopCore() time.Duration {
+	if pm.Entity().GetStatus() != types.StatusAlive {
+		return PublishDraftRetryTime
+	}
+
+	drafts, err := pm.GetDraftList()
+	if err != nil {
+		return PublishDraftRetryTime
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return PublishDraftRetryTime
+	}
+
+	toPublishDrafts := make([]*Draft, 0)
+	for _, draft := range drafts {
+		if draft.IsToPublish(ts) {
+			pm.publishScheduledDraft(draft.ID, ts)
+			continue
+		}
+		toPublishDrafts = append(toPublishDrafts, draft)
+	}
+
+	return nextDraftPublishDuration(toPublishDrafts, ts)
+}
+
+/*
+nextDraftPublishDuration returns the duration from ts to the earliest publish-ts of the scheduled drafts,
+PublishDraftIdleTime if no draft is scheduled.
+*/
+func nextDraftPublishDuration(drafts []*Draft, ts types.Timestamp) time.Duration {
+	duration := PublishDraftIdleTime
+	for _, draft := range drafts {
+		if draft.PublishTS.IsEqual(types.ZeroTimestamp) {
+			continue
+		}
+
+		eachDuration := time.Duration(draft.PublishTS.Ts-ts.Ts)*time.Second + time.Duration(int64(draft.PublishTS.NanoTs)-int64(ts.NanoTs))
+		if eachDuration < 0 {
+			eachDuration = 0
+		}
+		if eachDuration < duration {
+			duration = eachDuration
+		}
+	}
+
+	return duration
+}
+
+/*
+publishScheduledDraft publishes the scheduled draft.
+The schedule is cleared if failed, to avoid retrying forever.
+*/
+func (pm *ProtocolManager) publishScheduledDraft(draftID *types.PttID, ts types.Timestamp) error {
+	err := pm.DBObjLock().Lock(draftID)
+	if err != nil {
+		return err
+	}
+	defer pm.DBObjLock().Unlock(draftID)
+
+	// the draft may be updated or deleted in the meantime.
+	draft, err := pm.GetDraft(draftID)
+	if err != nil {
+		return err
+	}
+	if !draft.IsToPublish(ts) {
+		return nil
+	}
+
+	_, err = pm.publishDraft(draft)
+	if err == nil {
+		return nil
+	}
+
+	log.Warn("publishScheduledDraft: unable to publish", "draft", draftID, "entity", pm.Entity().GetID(), "e", err)
+
+	draft.PublishTS = types.ZeroTimestamp
+	draft.PublishErr = err.Error()
+
+	return draft.Save()
+}
@@ -0,0 +1,228 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+ForwardArticle forwards the article in fromPM to the board of pm as a new article (轉錄).
+
+The content-blocks and the referred medias are copied to the board,
+and the provenance of the original article is carried with the new article.
+*/
+func (pm *ProtocolManager) ForwardArticle(fromPM *ProtocolManager, articleID *types.PttID) (*Article, error) {
+	article, err := fromPM.GetArticle(articleID)
+	if err != nil {
+		return nil, err
+	}
+	if article.Status != types.StatusAlive {
+		return nil, types.ErrInvalidStatus
+	}
+
+	blockInfo := article.GetBlockInfo()
+	if blockInfo == nil {
+		return nil, pkgservice.ErrInvalidBlock
+	}
+	fromPM.SetBlockInfoDB(blockInfo, articleID)
+
+	// content
+	contentBlockList, err := pkgservice.GetContentBlockList(blockInfo, 0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([][]byte, 0)
+	for _, contentBlock := range contentBlockList {
+		lines = append(lines, contentBlock.Buf...)
+	}
+
+	// provenance
+	provenance := article.Provenance
+	if provenance == nil {
+		provenance, err = fromPM.newArticleProvenance(article)
+		if err != nil {
+			return nil, err
+		}
+
+		err = fromPM.VerifyArticleProvenance(provenance)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// medias
+	var mediaIDs []*types.PttID
+	if len(blockInfo.MediaIDs) != 0 {
+		mediaIDs = make([]*types.PttID, 0, len(blockInfo.MediaIDs))
+	}
+	for _, mediaID := range blockInfo.MediaIDs {
+		newMediaID, err := pm.forwardMedia(fromPM, mediaID)
+		if err != nil {
+			log.Warn("ForwardArticle: unable to forward media", "media", mediaID, "e", err)
+			continue
+		}
+		mediaIDs = append(mediaIDs, newMediaID)
+
+		lines = replaceMediaID(lines, mediaID, newMediaID)
+	}
+
+	return pm.createArticle(article.Title, lines, mediaIDs, article.Format, provenance)
+}
+
+func (pm *ProtocolManager) newArticleProvenance(article *Article) (*ArticleProvenance, error) {
+	blockInfo := article.GetBlockInfo()
+	if blockInfo == nil {
+		return nil, pkgservice.ErrInvalidBlock
+	}
+	pm.SetBlockInfoDB(blockInfo, article.ID)
+
+	sigHash, err := pkgservice.GetBlockSigHash(blockInfo, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ArticleProvenance{
+		BoardID:        article.EntityID,
+		ArticleID:      article.ID,
+		CreatorID:      article.CreatorID,
+		ContentBlockID: blockInfo.ID,
+		SigHash:        sigHash,
+	}, nil
+}
+
+/*
+VerifyArticleProvenance verifies the provenance with the original article in the board of pm:
+
+    1. the original article is created by the creator, with the content-block.
+    2. the content-blocks are signed by the creator.
+    3. SigHash matches the signatures of the content-blocks.
+*/
+func (pm *ProtocolManager) VerifyArticleProvenance(provenance *ArticleProvenance) error {
+	if provenance == nil {
+		return ErrInvalidProvenance
+	}
+
+	if !reflect.DeepEqual(provenance.BoardID, pm.Entity().GetID()) {
+		return ErrInvalidProvenance
+	}
+
+	article, err := pm.GetArticle(provenance.ArticleID)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(article.CreatorID, provenance.CreatorID) {
+		return ErrInvalidProvenance
+	}
+
+	blockInfo := article.GetBlockInfo()
+	if blockInfo == nil {
+		return pkgservice.ErrInvalidBlock
+	}
+	if !reflect.DeepEqual(blockInfo.ID, provenance.ContentBlockID) {
+		return ErrInvalidProvenance
+	}
+	if !reflect.DeepEqual(blockInfo.UpdaterID, provenance.CreatorID) {
+		return ErrInvalidProvenance
+	}
+	pm.SetBlockInfoDB(blockInfo, article.ID)
+
+	blocks, err := pkgservice.GetBlockList(blockInfo, 0, false)
+	if err != nil {
+		return err
+	}
+	for _, block := range blocks {
+		if int(block.BlockID) >= blockInfo.NBlock || int(block.SubBlockID) >= len(blockInfo.Hashs[block.BlockID]) {
+			return ErrInvalidProvenance
+		}
+		err = block.Verify(blockInfo.Hashs[block.BlockID][block.SubBlockID], provenance.CreatorID)
+		if err != nil {
+			return ErrInvalidProvenance
+		}
+	}
+
+	sigHash, err := pkgservice.GetBlockSigHash(blockInfo, false)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(sigHash, provenance.SigHash) {
+		return ErrInvalidProvenance
+	}
+
+	return nil
+}
+
+func (pm *ProtocolManager) forwardMedia(fromPM *ProtocolManager, mediaID *types.PttID) (*types.PttID, error) {
+	media, err := fromPM.GetMedia(mediaID)
+	if err != nil {
+		return nil, err
+	}
+	if media.Status != types.StatusAlive {
+		return nil, types.ErrInvalidStatus
+	}
+
+	var newMedia *pkgservice.Media
+	switch media.MediaType {
+	case pkgservice.MediaTypeFile:
+		// MediaData is unmarshaled as map from the db.
+		mediaData := &pkgservice.MediaDataFile{}
+		marshaled, err := json.Marshal(media.MediaData)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(marshaled, mediaData)
+		if err != nil {
+			return nil, err
+		}
+
+		newMedia, err = pm.UploadFile(mediaData.Filename, media.Buf)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		newMedia, err = pm.UploadImage("", media.Buf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newMedia.ID, nil
+}
+
+func replaceMediaID(lines [][]byte, mediaID *types.PttID, newMediaID *types.PttID) [][]byte {
+	mediaIDBytes, err := mediaID.MarshalText()
+	if err != nil {
+		return lines
+	}
+	newMediaIDBytes, err := newMediaID.MarshalText()
+	if err != nil {
+		return lines
+	}
+
+	for i, line := range lines {
+		lines[i] = bytes.Replace(line, mediaIDBytes, newMediaIDBytes, -1)
+	}
+
+	return lines
+}
@@ -0,0 +1,129 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/crypto"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func tCreateSignedArticle(t *testing.T, pm *ProtocolManager, creatorID *types.PttID, keyInfo *pkgservice.KeyInfo) *Article {
+	ts, _ := types.GetTimestamp()
+	article, err := NewArticle(ts, creatorID, pm.Entity().GetID(), nil, types.StatusAlive, []byte("title"), ArticleFormatPlain)
+	if err != nil {
+		t.Fatalf("NewArticle() error = %v", err)
+	}
+
+	blockInfoID, _ := types.NewPttID()
+	hashs := [][][]byte{make([][]byte, pkgservice.NSubBlock)}
+	blockInfo, _ := pkgservice.NewBlockInfo(blockInfoID, hashs, nil, creatorID)
+	pm.SetBlockInfoDB(blockInfo, article.ID)
+
+	for subBlockID := 0; subBlockID < pkgservice.NSubBlock; subBlockID++ {
+		block, _ := pkgservice.NewBlock(0, uint8(subBlockID), []byte("content"))
+		blockInfo.SetBlockDB(block)
+		err = block.Sign(keyInfo)
+		if err != nil {
+			t.Fatalf("Block.Sign() error = %v", err)
+		}
+		err = block.Save()
+		if err != nil {
+			t.Fatalf("Block.Save() error = %v", err)
+		}
+		hashs[0][subBlockID] = block.Hash
+	}
+	blockInfo.SetIsAllGood()
+	article.SetBlockInfo(blockInfo)
+
+	pm.SetArticleDB(article)
+	err = article.Save(false)
+	if err != nil {
+		t.Fatalf("Article.Save() error = %v", err)
+	}
+
+	return article
+}
+
+func TestVerifyArticleProvenance(t *testing.T) {
+	// setup test
+	pm, teardown := tNewBoardPM(t)
+	defer teardown()
+
+	key, _ := crypto.GenerateKey()
+	creatorID, _ := types.NewPttIDFromKey(key)
+	keyInfo, _ := pkgservice.NewSignKeyInfo(creatorID, key)
+
+	otherKey, _ := crypto.GenerateKey()
+	otherID, _ := types.NewPttIDFromKey(otherKey)
+
+	article := tCreateSignedArticle(t, pm, creatorID, keyInfo)
+	forged := tCreateSignedArticle(t, pm, creatorID, keyInfo)
+
+	// forward: the provenance of the original article
+	provenance, err := pm.newArticleProvenance(article)
+	if err != nil {
+		t.Errorf("newArticleProvenance() error = %v", err)
+		return
+	}
+
+	// forge the content-block of the other article, keeping the creator.
+	forgedInfo := forged.GetBlockInfo()
+	forgedInfo.UpdaterID = creatorID
+	otherKeyInfo, _ := pkgservice.NewSignKeyInfo(otherID, otherKey)
+	pm.SetBlockInfoDB(forgedInfo, forged.ID)
+	forgedBlocks, _ := pkgservice.GetBlockList(forgedInfo, 0, false)
+	for _, block := range forgedBlocks {
+		forgedInfo.SetBlockDB(block)
+		block.Sign(otherKeyInfo)
+		block.Save()
+	}
+	forgedProvenance, _ := pm.newArticleProvenance(forged)
+
+	// prepare test-cases
+	tamper := func(f func(p *ArticleProvenance)) *ArticleProvenance {
+		p := *provenance
+		f(&p)
+		return &p
+	}
+
+	tests := []struct {
+		name       string
+		provenance *ArticleProvenance
+		wantErr    bool
+	}{
+		{"forwarded", provenance, false},
+		{"tampered sig-hash", tamper(func(p *ArticleProvenance) { p.SigHash = crypto.Keccak256([]byte("tampered")) }), true},
+		{"tampered creator", tamper(func(p *ArticleProvenance) { p.CreatorID = otherID }), true},
+		{"tampered content-block", tamper(func(p *ArticleProvenance) { p.ContentBlockID = forged.GetBlockInfo().ID }), true},
+		{"tampered article", tamper(func(p *ArticleProvenance) { p.ArticleID = otherID }), true},
+		{"tampered board", tamper(func(p *ArticleProvenance) { p.BoardID = otherID }), true},
+		{"forged blocks", forgedProvenance, true},
+		{"no provenance", nil, true},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := pm.VerifyArticleProvenance(tt.provenance); (err != nil) != tt.wantErr {
+				t.Errorf("VerifyArticleProvenance() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func (pm *ProtocolManager) GetArticleRendered(articleID *types.PttID) (*Article, string, error) {
+	article, err := pm.GetArticle(articleID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	blockInfo := article.GetBlockInfo()
+	if blockInfo == nil {
+		return nil, "", pkgservice.ErrInvalidBlock
+	}
+	pm.SetBlockInfoDB(blockInfo, articleID)
+
+	contentBlockList, err := pkgservice.GetContentBlockList(blockInfo, 0, false)
+	if err != nil {
+		return nil, "", err
+	}
+
+	lines := make([][]byte, 0)
+	for _, contentBlock := range contentBlockList {
+		lines = append(lines, contentBlock.Buf...)
+	}
+
+	switch article.Format {
+	case ArticleFormatMarkdown:
+		return article, RenderMarkdown(lines, pm.resolveMedia), nil
+	default:
+		return article, RenderPlain(lines), nil
+	}
+}
+
+/*
+resolveMedia resolves the media-id to the url of the media in the board.
+*/
+func (pm *ProtocolManager) resolveMedia(mediaIDStr string) (string, bool, bool) {
+	mediaID, err := types.UnmarshalTextPttID([]byte(mediaIDStr), false)
+	if err != nil || mediaID == nil {
+		return "", false, false
+	}
+
+	media := pkgservice.NewEmptyMedia()
+	pm.SetMediaDB(media)
+	media.SetID(mediaID)
+
+	err = media.GetByID(false)
+	if err != nil {
+		return "", false, false
+	}
+	if media.Status != types.StatusAlive {
+		return "", false, false
+	}
+
+	entityIDBytes, err := pm.Entity().GetID().MarshalText()
+	if err != nil {
+		return "", false, false
+	}
+	mediaIDBytes, err := mediaID.MarshalText()
+	if err != nil {
+		return "", false, false
+	}
+
+	isImage := media.MediaType != pkgservice.MediaTypeFile
+	urlPath := URLImagePath
+	if !isImage {
+		urlPath = URLFilePath
+	}
+
+	return urlPath + "/" + string(entityIDBytes) + "/" + string(mediaIDBytes), isImage, true
+}
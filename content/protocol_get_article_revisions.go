@@ -0,0 +1,72 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func (pm *ProtocolManager) GetArticleRevisions(articleID *types.PttID) ([]*ArticleRevision, error) {
+	article, err := pm.GetArticle(articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return article.GetRevisions()
+}
+
+func (pm *ProtocolManager) GetArticleDiff(articleID *types.PttID, fromRev uint32, toRev uint32) ([]*DiffLine, error) {
+	article, err := pm.GetArticle(articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromLines, err := pm.getArticleRevisionLines(article, fromRev)
+	if err != nil {
+		return nil, err
+	}
+
+	toLines, err := pm.getArticleRevisionLines(article, toRev)
+	if err != nil {
+		return nil, err
+	}
+
+	return DiffLines(fromLines, toLines), nil
+}
+
+func (pm *ProtocolManager) getArticleRevisionLines(article *Article, rev uint32) ([][]byte, error) {
+	r, err := article.GetRevision(rev)
+	if err != nil {
+		return nil, err
+	}
+
+	// the blocks of the revision are all in the db.
+	r.BlockInfo.SetIsAllGood()
+
+	contentBlockList, err := pkgservice.GetContentBlockList(r.BlockInfo, 0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([][]byte, 0)
+	for _, contentBlock := range contentBlockList {
+		lines = append(lines, contentBlock.Buf...)
+	}
+
+	return lines, nil
+}
@@ -123,6 +123,13 @@ func (pm *ProtocolManager) processPendingBoardLog(oplog *pkgservice.BaseOplog, p
 		return false, nil, pkgservice.ErrInvalidData
 	}
 
+	// The creator is checked only before signing the pending oplog.
+	// The valid oplogs are already signed by the masters, and are accepted regardless of the current membership,
+	// because the member-oplogs are in the other merkle-tree and the members may leave afterwards.
+	if !pm.isValidBoardOplogCreator(oplog) {
+		return false, nil, pkgservice.ErrSkipOplog
+	}
+
 	switch oplog.Op {
 	case BoardOpTypeDeleteBoard:
 		isToSign, origLogs, err = pm.handlePendingDeleteBoardLogs(oplog, info)
@@ -17,6 +17,8 @@
 package content
 
 import (
+	"sync"
+
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/log"
 	pkgservice "github.com/ailabstw/go-pttai/service"
@@ -40,6 +42,12 @@ type ProtocolManager struct {
 	// comment
 	dbCommentPrefix    []byte
 	dbCommentIdxPrefix []byte
+
+	// unread
+	lockUnread sync.Mutex
+
+	// draft
+	draftScheduleC chan struct{}
 }
 
 func newBaseProtocolManager(pm *ProtocolManager, ptt pkgservice.Ptt, entity pkgservice.Entity) *pkgservice.BaseProtocolManager {
@@ -104,6 +112,8 @@ func NewProtocolManager(b *Board, ptt pkgservice.Ptt) (*ProtocolManager, error)
 	pm := &ProtocolManager{
 		dbBoardLock:      dbBoardLock,
 		boardOplogMerkle: boardOplogMerkle,
+
+		draftScheduleC: make(chan struct{}, 1),
 	}
 	pm.BaseProtocolManager = newBaseProtocolManager(pm, ptt, b)
 
@@ -120,6 +130,10 @@ func NewProtocolManager(b *Board, ptt pkgservice.Ptt) (*ProtocolManager, error)
 	pm.dbCommentPrefix = append(DBCommentPrefix, entityID[:]...)
 	pm.dbCommentIdxPrefix = append(DBCommentIdxPrefix, entityID[:]...)
 
+	// master-quorum
+	pm.RegisterCriticalOp(DBBoardOplogPrefix, BoardOpTypeDeleteBoard)
+	pm.RegisterCriticalOp(DBBoardOplogPrefix, BoardOpTypeMigrateBoard)
+
 	return pm, nil
 }
 
@@ -151,6 +165,13 @@ func (pm *ProtocolManager) Start() error {
 		pkgservice.PMOplogMerkleTreeLoop(pm, pm.boardOplogMerkle)
 	}()
 
+	// draft
+	syncWG.Add(1)
+	go func() {
+		defer syncWG.Done()
+		pm.PublishDraftLoop()
+	}()
+
 	return nil
 }
 
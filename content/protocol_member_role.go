@@ -0,0 +1,197 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+isValidBoardOplogCreator checks whether the creator of the oplog is permitted by the member-role:
+
+    1. the readers, the read-only members and the banned users are not able to write.
+    2. the posters are not able to create / update the title.
+    3. the muted users are not able to create the articles / comments.
+    4. only the moderators (as of the time) are able to delete the articles / comments created by the others.
+
+The member-role, the ban and the mute are checked as of the time of the oplog (oplogCreatorTS),
+and are not applied retroactively to the oplogs created before they are set.
+*/
+func (pm *ProtocolManager) isValidBoardOplogCreator(oplog *pkgservice.BaseOplog) bool {
+	creatorID := oplog.CreatorID
+
+	ts, err := oplogCreatorTS(oplog)
+	if err != nil {
+		return false
+	}
+
+	// The object to delete may not be synced yet.
+	// The oplog is skipped (kept pending, and synced again) until the object is synced.
+	var objCreatorID *types.PttID
+	switch oplog.Op {
+	case BoardOpTypeDeleteArticle:
+		article, err := pm.GetArticle(oplog.ObjID)
+		if err != nil {
+			return false
+		}
+		objCreatorID = article.CreatorID
+	case BoardOpTypeDeleteComment:
+		comment, err := pm.GetComment(oplog.ObjID)
+		if err != nil {
+			return false
+		}
+		objCreatorID = comment.CreatorID
+	}
+
+	return pm.memberPermAt(creatorID, ts).isValidOplogCreator(oplog.Op, reflect.DeepEqual(creatorID, objCreatorID))
+}
+
+/*
+oplogCreatorTS returns the time to check the member-role, the ban and the mute of the oplog creator with.
+
+The CreateTS set by the creator is bounded by the time the oplog is received:
+not after now, and not before ExpireOplogSeconds ago (the oplogs older than that are not master-signed.)
+*/
+func oplogCreatorTS(oplog *pkgservice.BaseOplog) (types.Timestamp, error) {
+	now, err := types.GetTimestamp()
+	if err != nil {
+		return types.ZeroTimestamp, err
+	}
+
+	if now.IsLess(oplog.CreateTS) {
+		return now, nil
+	}
+
+	expireTS := now
+	expireTS.Ts -= int64(pkgservice.ExpireOplogSeconds)
+	if oplog.CreateTS.IsLess(expireTS) {
+		return expireTS, nil
+	}
+
+	return oplog.CreateTS, nil
+}
+
+/*
+memberPerm is the membership, the member-role, the ban and the mute of the user as of the time.
+*/
+type memberPerm struct {
+	isPublic bool
+	isMember bool
+	isMaster bool
+
+	role        pkgservice.MemberRole
+	isRoleKnown bool
+
+	isBanned bool
+	isMuted  bool
+}
+
+func (pm *ProtocolManager) memberPermAt(id *types.PttID, ts types.Timestamp) *memberPerm {
+	role, isRoleKnown := pm.GetMemberRoleAt(id, ts)
+
+	return &memberPerm{
+		isPublic: pm.IsPublic(),
+		isMember: pm.IsMember(id, false),
+		isMaster: pm.IsMaster(id, false),
+
+		role:        role,
+		isRoleKnown: isRoleKnown,
+
+		isBanned: pm.IsBannedAt(id, ts),
+		isMuted:  pm.IsMuted(id, ts),
+	}
+}
+
+func (p *memberPerm) isValidOplogCreator(op pkgservice.OpType, isObjCreator bool) bool {
+	if !p.isWritable() {
+		return false
+	}
+
+	if p.isBanned {
+		return false
+	}
+
+	switch op {
+	case BoardOpTypeCreateArticle, BoardOpTypeCreateComment:
+		return !p.isMuted
+	case BoardOpTypeCreateTitle, BoardOpTypeUpdateTitle:
+		return p.isValidSetTitleCreator()
+	case BoardOpTypeDeleteArticle, BoardOpTypeDeleteComment:
+		return p.isValidDeleteCreator(isObjCreator)
+	}
+
+	return true
+}
+
+/*
+isWritable checks the member-role. The role unknown at the time (set afterwards) is the default member.
+*/
+func (p *memberPerm) isWritable() bool {
+	if p.isPublic && !p.isMember {
+		return false
+	}
+
+	if p.isMaster {
+		return true
+	}
+
+	return !p.isRoleKnown || p.role != pkgservice.MemberRoleReadOnly
+}
+
+func (p *memberPerm) isValidSetTitleCreator() bool {
+	if p.isMaster {
+		return true
+	}
+
+	return !p.isRoleKnown || p.role != pkgservice.MemberRolePoster
+}
+
+/*
+isValidDeleteCreator checks whether the user is able to delete the object.
+Deleting the objects created by the others requires the moderator-role known at the time.
+*/
+func (p *memberPerm) isValidDeleteCreator(isObjCreator bool) bool {
+	if isObjCreator {
+		return true
+	}
+
+	if p.isMaster {
+		return true
+	}
+
+	if !p.isMember {
+		return false
+	}
+
+	return p.isRoleKnown && p.role == pkgservice.MemberRoleModerator
+}
+
+func (pm *ProtocolManager) isValidSetTitleCreator(id *types.PttID, ts types.Timestamp) bool {
+	return pm.memberPermAt(id, ts).isValidSetTitleCreator()
+}
+
+func (pm *ProtocolManager) isMuted(id *types.PttID) bool {
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return false
+	}
+
+	return pm.IsMuted(id, ts)
+}
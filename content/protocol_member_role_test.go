@@ -0,0 +1,134 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"testing"
+
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func TestMemberPerm_isValidOplogCreator(t *testing.T) {
+	// prepare test-cases
+	member := memberPerm{isMember: true, role: pkgservice.MemberRoleMember, isRoleKnown: true}
+
+	moderator := member
+	moderator.role = pkgservice.MemberRoleModerator
+
+	poster := member
+	poster.role = pkgservice.MemberRolePoster
+
+	readOnly := member
+	readOnly.role = pkgservice.MemberRoleReadOnly
+
+	noRecord := member
+	noRecord.isRoleKnown = false
+
+	noRecordReadOnly := readOnly
+	noRecordReadOnly.isRoleKnown = false
+
+	master := member
+	master.isMaster = true
+
+	banned := member
+	banned.isBanned = true
+
+	muted := member
+	muted.isMuted = true
+
+	publicReader := memberPerm{isPublic: true}
+
+	publicMember := member
+	publicMember.isPublic = true
+
+	tests := []struct {
+		name         string
+		perm         memberPerm
+		op           pkgservice.OpType
+		isObjCreator bool
+		want         bool
+	}{
+		{"member create-article", member, BoardOpTypeCreateArticle, false, true},
+		{"member update-title", member, BoardOpTypeUpdateTitle, false, true},
+		{"member delete own", member, BoardOpTypeDeleteArticle, true, true},
+		{"member delete others", member, BoardOpTypeDeleteComment, false, false},
+
+		{"moderator create-comment", moderator, BoardOpTypeCreateComment, false, true},
+		{"moderator delete others article", moderator, BoardOpTypeDeleteArticle, false, true},
+		{"moderator delete others comment", moderator, BoardOpTypeDeleteComment, false, true},
+
+		{"poster create-article", poster, BoardOpTypeCreateArticle, false, true},
+		{"poster create-title", poster, BoardOpTypeCreateTitle, false, false},
+		{"poster update-title", poster, BoardOpTypeUpdateTitle, false, false},
+		{"poster delete others", poster, BoardOpTypeDeleteArticle, false, false},
+
+		{"read-only create-article", readOnly, BoardOpTypeCreateArticle, false, false},
+		{"read-only delete own", readOnly, BoardOpTypeDeleteArticle, true, false},
+
+		{"master create-title", master, BoardOpTypeCreateTitle, false, true},
+		{"master delete others", master, BoardOpTypeDeleteArticle, false, true},
+
+		{"banned create-article", banned, BoardOpTypeCreateArticle, false, false},
+		{"banned delete own", banned, BoardOpTypeDeleteArticle, true, false},
+
+		{"muted create-article", muted, BoardOpTypeCreateArticle, false, false},
+		{"muted create-comment", muted, BoardOpTypeCreateComment, false, false},
+		{"muted delete own", muted, BoardOpTypeDeleteComment, true, true},
+
+		{"no-record create-article", noRecord, BoardOpTypeCreateArticle, false, true},
+		{"no-record update-title", noRecord, BoardOpTypeUpdateTitle, false, true},
+		{"no-record delete others", noRecord, BoardOpTypeDeleteArticle, false, false},
+		{"no-record read-only create-article", noRecordReadOnly, BoardOpTypeCreateArticle, false, true},
+
+		{"public reader create-article", publicReader, BoardOpTypeCreateArticle, false, false},
+		{"public member create-article", publicMember, BoardOpTypeCreateArticle, false, true},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.perm.isValidOplogCreator(tt.op, tt.isObjCreator); got != tt.want {
+				t.Errorf("memberPerm.isValidOplogCreator() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemberPerm_isValidDeleteCreator(t *testing.T) {
+	// prepare test-cases
+	tests := []struct {
+		name         string
+		perm         memberPerm
+		isObjCreator bool
+		want         bool
+	}{
+		{"own object", memberPerm{}, true, true},
+		{"not a member", memberPerm{role: pkgservice.MemberRoleModerator, isRoleKnown: true}, false, false},
+		{"moderator", memberPerm{isMember: true, role: pkgservice.MemberRoleModerator, isRoleKnown: true}, false, true},
+		{"moderator set afterwards", memberPerm{isMember: true, role: pkgservice.MemberRoleMember}, false, false},
+		{"master", memberPerm{isMaster: true}, false, true},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.perm.isValidDeleteCreator(tt.isObjCreator); got != tt.want {
+				t.Errorf("memberPerm.isValidDeleteCreator() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
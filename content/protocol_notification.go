@@ -0,0 +1,54 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+isMentioned checks whether I am @mentioned in the content blocks of the object.
+*/
+func (pm *ProtocolManager) isMentioned(blockInfo *pkgservice.BlockInfo, objID *types.PttID) bool {
+	if blockInfo == nil {
+		return false
+	}
+	pm.SetBlockInfoDB(blockInfo, objID)
+
+	contentBlockList, err := pkgservice.GetContentBlockList(blockInfo, 0, false)
+	if err != nil {
+		return false
+	}
+
+	lines := make([][]byte, 0)
+	for _, contentBlock := range contentBlockList {
+		lines = append(lines, contentBlock.Buf...)
+	}
+
+	myID := pm.Ptt().GetMyEntity().GetID()
+
+	return pkgservice.IsMentioned(lines, myID)
+}
+
+func (pm *ProtocolManager) createNotification(theType pkgservice.NotificationType, fromID *types.PttID, objID *types.PttID, subObjID *types.PttID) {
+	err := pm.Ptt().CreateNotification(theType, fromID, pm.Entity().GetID(), objID, subObjID)
+	if err != nil {
+		log.Warn("createNotification: unable to create notification", "type", theType, "e", err)
+	}
+}
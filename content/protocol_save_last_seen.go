@@ -30,10 +30,107 @@ func (pm *ProtocolManager) SaveLastSeen(ts types.Timestamp) (types.Timestamp, er
 	}
 
 	board := pm.Entity().(*Board)
+
+	pm.lockUnread.Lock()
+	defer pm.lockUnread.Unlock()
+
+	lastSeen, err := board.LoadLastSeen()
+	if err != nil {
+		return types.ZeroTimestamp, err
+	}
+
 	err = board.SaveLastSeen(ts)
 	if err != nil {
 		return types.ZeroTimestamp, err
 	}
 
+	if lastSeen.IsLess(ts) {
+		err = board.SaveUnread(&BoardUnread{})
+		if err != nil {
+			return types.ZeroTimestamp, err
+		}
+	}
+
 	return ts, nil
 }
+
+/*
+increaseUnread increases the unread counters of the board if the object is created after the last-seen.
+ts is the create-ts of the object, the same as decreaseUnread.
+*/
+func (pm *ProtocolManager) increaseUnread(ts types.Timestamp, isArticle bool) error {
+	board := pm.Entity().(*Board)
+
+	pm.lockUnread.Lock()
+	defer pm.lockUnread.Unlock()
+
+	lastSeen, err := board.LoadLastSeen()
+	if err != nil {
+		return err
+	}
+	if !lastSeen.IsLess(ts) {
+		return nil
+	}
+
+	unread, err := board.LoadUnread()
+	if err != nil {
+		return err
+	}
+
+	if isArticle {
+		unread.NArticle++
+	} else {
+		unread.NComment++
+	}
+
+	return board.SaveUnread(unread)
+}
+
+/*
+decreaseUnread decreases the unread counters of the board with the deleted articles / comments created after the last-seen.
+The unread counters are reset by SaveLastSeen, so the objects created after the last-seen are the ones counted.
+*/
+func (pm *ProtocolManager) decreaseUnread(articleTSs []types.Timestamp, commentTSs []types.Timestamp) error {
+	board := pm.Entity().(*Board)
+
+	pm.lockUnread.Lock()
+	defer pm.lockUnread.Unlock()
+
+	lastSeen, err := board.LoadLastSeen()
+	if err != nil {
+		return err
+	}
+
+	nArticle := countUnread(articleTSs, lastSeen)
+	nComment := countUnread(commentTSs, lastSeen)
+	if nArticle == 0 && nComment == 0 {
+		return nil
+	}
+
+	unread, err := board.LoadUnread()
+	if err != nil {
+		return err
+	}
+
+	unread.NArticle = subUnread(unread.NArticle, nArticle)
+	unread.NComment = subUnread(unread.NComment, nComment)
+
+	return board.SaveUnread(unread)
+}
+
+func countUnread(tss []types.Timestamp, lastSeen types.Timestamp) uint32 {
+	n := uint32(0)
+	for _, ts := range tss {
+		if lastSeen.IsLess(ts) {
+			n++
+		}
+	}
+	return n
+}
+
+func subUnread(n uint32, m uint32) uint32 {
+	if n < m {
+		return 0
+	}
+	return n - m
+}
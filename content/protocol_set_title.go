@@ -16,10 +16,22 @@
 
 package content
 
-import "github.com/syndtr/goleveldb/leveldb"
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/syndtr/goleveldb/leveldb"
+)
 
 func (pm *ProtocolManager) SetTitle(title []byte) error {
 
+	myID := pm.Ptt().GetMyEntity().GetID()
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return err
+	}
+	if !pm.isValidSetTitleCreator(myID, ts) {
+		return types.ErrInvalidID
+	}
+
 	isExists, err := pm.setTitleCheckIsExists()
 	if err != nil {
 		return err
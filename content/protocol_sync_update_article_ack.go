@@ -50,7 +50,7 @@ func (pm *ProtocolManager) HandleSyncUpdateArticleAck(dataBytes []byte, peer *pk
 
 			pm.SetBoardDB,
 			pm.updateSyncArticle,
-			nil,
+			pm.postupdateArticle,
 			pm.broadcastBoardOplogCore,
 		)
 	}
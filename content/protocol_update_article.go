@@ -50,7 +50,7 @@ func (pm *ProtocolManager) UpdateArticle(articleID *types.PttID, articleBytes []
 		pm.inupdateArticle,
 		nil,
 		pm.broadcastBoardOplogCore,
-		nil,
+		pm.postupdateArticle,
 	)
 	if err != nil {
 		return nil, err
@@ -106,3 +106,17 @@ func (pm *ProtocolManager) inupdateArticle(theObj pkgservice.Object, theData pkg
 
 	return syncInfo, nil
 }
+
+func (pm *ProtocolManager) postupdateArticle(theObj pkgservice.Object, oplog *pkgservice.BaseOplog) error {
+	article, ok := theObj.(*Article)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	err := article.SaveRevision(oplog)
+	if err != nil {
+		log.Warn("postupdateArticle: unable to save revision", "e", err)
+	}
+
+	return nil
+}
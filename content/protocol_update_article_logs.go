@@ -38,7 +38,7 @@ func (pm *ProtocolManager) handleUpdateArticleLogs(oplog *pkgservice.BaseOplog,
 		pm.syncArticleInfoFromOplog,
 		pm.SetBoardDB,
 		nil,
-		nil,
+		pm.postupdateArticle,
 		pm.updateUpdateArticleInfo,
 	)
 }
@@ -60,7 +60,7 @@ func (pm *ProtocolManager) handlePendingUpdateArticleLogs(oplog *pkgservice.Base
 		pm.syncArticleInfoFromOplog,
 		pm.SetBoardDB,
 		nil,
-		nil,
+		pm.postupdateArticle,
 		pm.updateUpdateArticleInfo,
 	)
 }
@@ -30,6 +30,16 @@ const (
 	ContentTypeReply
 )
 
+// article format
+type ArticleFormat uint8
+
+const (
+	ArticleFormatPlain ArticleFormat = iota
+	ArticleFormatMarkdown
+
+	NArticleFormat
+)
+
 // comment type
 type CommentType int
 
@@ -0,0 +1,347 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package keystore stores the secp256k1 private-keys encrypted by passphrase (scrypt + AES-GCM).
+
+The key-file is either the plain hex-encoded key (as crypto.SaveECDSA),
+or the encrypted json as the following:
+
+	{
+	  "version": 1,
+	  "crypto": {
+	    "cipher": "aes-256-gcm",
+	    "ciphertext": "...",
+	    "nonce": "...",
+	    "kdf": "scrypt",
+	    "kdfparams": {"n": 262144, "r": 8, "p": 1, "dklen": 32, "salt": "..."}
+	  }
+	}
+*/
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/crypto"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	Version = 1
+
+	CipherAESGCM = "aes-256-gcm"
+	KDFScrypt    = "scrypt"
+
+	// StandardScryptN and StandardScryptP are the scrypt parameters for the keys on disk.
+	StandardScryptN = 1 << 18
+	StandardScryptP = 1
+
+	// LightScryptN and LightScryptP are the scrypt parameters for the tests.
+	LightScryptN = 1 << 12
+	LightScryptP = 6
+
+	scryptR     = 8
+	scryptDKLen = 32
+
+	sizeSalt = 32
+)
+
+var (
+	ErrLocked  = errors.New("keystore locked")
+	ErrDecrypt = errors.New("could not decrypt key with given passphrase")
+
+	ErrInvalidKeyFile = errors.New("invalid key file")
+)
+
+// rename is os.Rename, replaced in the tests to inject the failures.
+var rename = os.Rename
+
+type KDFParams struct {
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+}
+
+type CryptoJSON struct {
+	Cipher     string     `json:"cipher"`
+	CipherText string     `json:"ciphertext"`
+	Nonce      string     `json:"nonce"`
+	KDF        string     `json:"kdf"`
+	KDFParams  *KDFParams `json:"kdfparams"`
+}
+
+type EncryptedKeyJSON struct {
+	Version int         `json:"version"`
+	Crypto  *CryptoJSON `json:"crypto"`
+}
+
+/*
+EncryptKey encrypts the key with the passphrase.
+*/
+func EncryptKey(key *ecdsa.PrivateKey, passphrase string, scryptN int, scryptP int) ([]byte, error) {
+	salt := make([]byte, sizeSalt)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return nil, err
+	}
+	defer common.Bzero(derivedKey)
+
+	aead, err := newGCM(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	keyBytes := crypto.FromECDSA(key)
+	defer common.Bzero(keyBytes)
+
+	cipherText := aead.Seal(nil, nonce, keyBytes, nil)
+
+	encryptedKey := &EncryptedKeyJSON{
+		Version: Version,
+		Crypto: &CryptoJSON{
+			Cipher:     CipherAESGCM,
+			CipherText: hex.EncodeToString(cipherText),
+			Nonce:      hex.EncodeToString(nonce),
+			KDF:        KDFScrypt,
+			KDFParams: &KDFParams{
+				N:     scryptN,
+				R:     scryptR,
+				P:     scryptP,
+				DKLen: scryptDKLen,
+				Salt:  hex.EncodeToString(salt),
+			},
+		},
+	}
+
+	return json.MarshalIndent(encryptedKey, "", "  ")
+}
+
+/*
+DecryptKey decrypts the encrypted json with the passphrase.
+*/
+func DecryptKey(keyJSON []byte, passphrase string) (*ecdsa.PrivateKey, error) {
+	encryptedKey := &EncryptedKeyJSON{}
+	err := json.Unmarshal(keyJSON, encryptedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	c := encryptedKey.Crypto
+	if encryptedKey.Version != Version || c == nil || c.Cipher != CipherAESGCM || c.KDF != KDFScrypt || c.KDFParams == nil {
+		return nil, ErrInvalidKeyFile
+	}
+
+	salt, err := hex.DecodeString(c.KDFParams.Salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hex.DecodeString(c.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	cipherText, err := hex.DecodeString(c.CipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	p := c.KDFParams
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, p.N, p.R, p.P, p.DKLen)
+	if err != nil {
+		return nil, err
+	}
+	defer common.Bzero(derivedKey)
+
+	aead, err := newGCM(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, ErrInvalidKeyFile
+	}
+
+	keyBytes, err := aead.Open(nil, nonce, cipherText, nil)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	defer common.Bzero(keyBytes)
+
+	return crypto.ToECDSA(keyBytes)
+}
+
+func newGCM(derivedKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+/*
+IsEncrypted checks whether the content of the key-file is the encrypted json.
+*/
+func IsEncrypted(content []byte) bool {
+	return len(content) != 0 && bytes.TrimSpace(content)[0] == '{'
+}
+
+/*
+IsEncryptedFile checks whether the key-file exists and is encrypted.
+*/
+func IsEncryptedFile(filename string) bool {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return false
+	}
+
+	return IsEncrypted(content)
+}
+
+/*
+LoadECDSA loads the key from the key-file, decrypting with the passphrase if the key-file is encrypted.
+Returns ErrLocked if the key-file is encrypted and the passphrase is empty.
+*/
+func LoadECDSA(filename string, passphrase string) (*ecdsa.PrivateKey, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if !IsEncrypted(content) {
+		return crypto.LoadECDSA(filename)
+	}
+
+	if passphrase == "" {
+		return nil, ErrLocked
+	}
+
+	return DecryptKey(content, passphrase)
+}
+
+/*
+SaveECDSA saves the key to the key-file, encrypted if the passphrase is not empty.
+*/
+func SaveECDSA(filename string, key *ecdsa.PrivateKey, passphrase string) error {
+	if passphrase == "" {
+		return crypto.SaveECDSA(filename, key)
+	}
+
+	content, err := EncryptKey(key, passphrase, StandardScryptN, StandardScryptP)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filename, content, 0600)
+}
+
+/*
+ChangePassphrase re-encrypts the key-file with the new passphrase.
+The plain key-file is encrypted if the old passphrase is empty,
+and the key-file is stored in plain if the new passphrase is empty.
+*/
+func ChangePassphrase(filename string, passphrase string, newPassphrase string) error {
+	return ChangePassphraseFiles([]string{filename}, passphrase, newPassphrase)
+}
+
+/*
+ChangePassphraseFiles re-encrypts the key-files with the new passphrase all or none.
+
+    1. all the key-files are written to the tmp-files.
+    2. each key-file is renamed to the backup-file, and the tmp-file is renamed to the key-file.
+    3. the key-files are rolled back from the backup-files if any rename fails.
+    4. the backup-files are removed after all the key-files are renamed.
+*/
+func ChangePassphraseFiles(filenames []string, passphrase string, newPassphrase string) error {
+	tmpFilenames := make([]string, 0, len(filenames))
+	removeTmpFiles := func() {
+		for _, tmpFilename := range tmpFilenames {
+			os.Remove(tmpFilename)
+		}
+	}
+
+	// 1. tmp-files
+	for _, filename := range filenames {
+		key, err := LoadECDSA(filename, passphrase)
+		if err != nil {
+			removeTmpFiles()
+			return err
+		}
+
+		tmpFilename := filename + ".tmp"
+		tmpFilenames = append(tmpFilenames, tmpFilename)
+		err = SaveECDSA(tmpFilename, key, newPassphrase)
+		if err != nil {
+			removeTmpFiles()
+			return err
+		}
+	}
+
+	// 2. rename
+	backupFilenames := make([]string, 0, len(filenames))
+	rollback := func() {
+		for i, backupFilename := range backupFilenames {
+			rename(backupFilename, filenames[i])
+		}
+		removeTmpFiles()
+	}
+
+	for i, tmpFilename := range tmpFilenames {
+		backupFilename := filenames[i] + ".bak"
+		err := rename(filenames[i], backupFilename)
+		if err != nil {
+			rollback()
+			return err
+		}
+		backupFilenames = append(backupFilenames, backupFilename)
+
+		err = rename(tmpFilename, filenames[i])
+		if err != nil {
+			// 3. rollback
+			rollback()
+			return err
+		}
+	}
+
+	// 4. remove backup-files
+	for _, backupFilename := range backupFilenames {
+		os.Remove(backupFilename)
+	}
+
+	return nil
+}
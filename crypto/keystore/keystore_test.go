@@ -0,0 +1,179 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/crypto"
+)
+
+func TestEncryptDecryptKey(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: e: %v", err)
+	}
+
+	keyJSON, err := EncryptKey(key, "foo", LightScryptN, LightScryptP)
+	if err != nil {
+		t.Fatalf("EncryptKey: e: %v", err)
+	}
+	if !IsEncrypted(keyJSON) {
+		t.Errorf("IsEncrypted: false")
+	}
+
+	decrypted, err := DecryptKey(keyJSON, "foo")
+	if err != nil {
+		t.Fatalf("DecryptKey: e: %v", err)
+	}
+	if !reflect.DeepEqual(crypto.FromECDSA(decrypted), crypto.FromECDSA(key)) {
+		t.Errorf("DecryptKey: key mismatch")
+	}
+
+	_, err = DecryptKey(keyJSON, "bar")
+	if err != ErrDecrypt {
+		t.Errorf("DecryptKey: wrong passphrase: e: %v", err)
+	}
+}
+
+func TestLoadSaveECDSA(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore-test")
+	if err != nil {
+		t.Fatalf("TempDir: e: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	key, _ := crypto.GenerateKey()
+	filename := filepath.Join(dir, "key")
+
+	// plain
+	err = SaveECDSA(filename, key, "")
+	if err != nil {
+		t.Fatalf("SaveECDSA: e: %v", err)
+	}
+	if IsEncryptedFile(filename) {
+		t.Errorf("IsEncryptedFile: plain: true")
+	}
+
+	// plain => encrypted
+	err = ChangePassphrase(filename, "", "foo")
+	if err != nil {
+		t.Fatalf("ChangePassphrase: e: %v", err)
+	}
+	if !IsEncryptedFile(filename) {
+		t.Errorf("IsEncryptedFile: encrypted: false")
+	}
+
+	_, err = LoadECDSA(filename, "")
+	if err != ErrLocked {
+		t.Errorf("LoadECDSA: locked: e: %v", err)
+	}
+
+	loaded, err := LoadECDSA(filename, "foo")
+	if err != nil {
+		t.Fatalf("LoadECDSA: e: %v", err)
+	}
+	if !reflect.DeepEqual(crypto.FromECDSA(loaded), crypto.FromECDSA(key)) {
+		t.Errorf("LoadECDSA: key mismatch")
+	}
+}
+
+func TestChangePassphraseFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+	if err != nil {
+		t.Fatalf("TempDir: e: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	key, _ := crypto.GenerateKey()
+	filename := filepath.Join(dir, "key")
+	filename2 := filepath.Join(dir, "key2")
+
+	SaveECDSA(filename, key, "foo")
+	SaveECDSA(filename2, key, "bar")
+
+	// the 2nd key-file is not with the passphrase, none is changed.
+	err = ChangePassphraseFiles([]string{filename, filename2}, "foo", "baz")
+	if err == nil {
+		t.Fatalf("ChangePassphraseFiles: mismatched passphrase: no error")
+	}
+	if _, err = LoadECDSA(filename, "foo"); err != nil {
+		t.Errorf("LoadECDSA: unchanged: e: %v", err)
+	}
+	if _, err = os.Stat(filename + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("ChangePassphraseFiles: tmp-file is not removed")
+	}
+
+	// all changed.
+	SaveECDSA(filename2, key, "foo")
+	err = ChangePassphraseFiles([]string{filename, filename2}, "foo", "baz")
+	if err != nil {
+		t.Fatalf("ChangePassphraseFiles: e: %v", err)
+	}
+	for _, each := range []string{filename, filename2} {
+		if _, err = LoadECDSA(each, "baz"); err != nil {
+			t.Errorf("LoadECDSA: changed: %v e: %v", each, err)
+		}
+	}
+}
+
+func TestChangePassphraseFiles_RenameFailed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+	if err != nil {
+		t.Fatalf("TempDir: e: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	key, _ := crypto.GenerateKey()
+	filename := filepath.Join(dir, "key")
+	filename2 := filepath.Join(dir, "key2")
+
+	SaveECDSA(filename, key, "foo")
+	SaveECDSA(filename2, key, "foo")
+
+	// the rename of the 2nd tmp-file fails after the 1st key-file is renamed.
+	origRename := rename
+	defer func() { rename = origRename }()
+	rename = func(oldpath string, newpath string) error {
+		if oldpath == filename2+".tmp" {
+			return errors.New("injected")
+		}
+		return origRename(oldpath, newpath)
+	}
+
+	err = ChangePassphraseFiles([]string{filename, filename2}, "foo", "baz")
+	if err == nil {
+		t.Fatalf("ChangePassphraseFiles: rename failed: no error")
+	}
+
+	// all rolled back to the old passphrase.
+	for _, each := range []string{filename, filename2} {
+		if _, err = LoadECDSA(each, "foo"); err != nil {
+			t.Errorf("LoadECDSA: rolled back: %v e: %v", each, err)
+		}
+		for _, postfix := range []string{".tmp", ".bak"} {
+			if _, err = os.Stat(each + postfix); !os.IsNotExist(err) {
+				t.Errorf("ChangePassphraseFiles: %v is not removed", each+postfix)
+			}
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package shamir implements Shamir's secret sharing over GF(2^8).
+
+A share is the evaluations of the random polynomials (one per secret byte) at x,
+followed by x as the last byte.
+*/
+package shamir
+
+import (
+	"crypto/rand"
+	"errors"
+)
+
+const (
+	MaxShares = 255
+)
+
+var (
+	ErrInvalidThreshold = errors.New("invalid threshold")
+	ErrInvalidSecret    = errors.New("invalid secret")
+	ErrInvalidShares    = errors.New("invalid shares")
+)
+
+var (
+	expTable [510]uint8
+	logTable [256]uint8
+)
+
+func init() {
+	// generator 3 with the AES polynomial x^8 + x^4 + x^3 + x + 1
+	x := uint8(1)
+	for i := 0; i < 255; i++ {
+		expTable[i] = x
+		expTable[i+255] = x
+		logTable[x] = uint8(i)
+		x = mulNoTable(x, 3)
+	}
+}
+
+func mulNoTable(a, b uint8) uint8 {
+	var p uint8
+	for b != 0 {
+		if b&1 != 0 {
+			p ^= a
+		}
+		isHigh := a&0x80 != 0
+		a <<= 1
+		if isHigh {
+			a ^= 0x1b
+		}
+		b >>= 1
+	}
+	return p
+}
+
+func mul(a, b uint8) uint8 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return expTable[int(logTable[a])+int(logTable[b])]
+}
+
+func div(a, b uint8) uint8 {
+	if a == 0 {
+		return 0
+	}
+	return expTable[int(logTable[a])+255-int(logTable[b])]
+}
+
+/*
+Split splits the secret into n shares, any k of which are able to recover the secret.
+*/
+func Split(secret []byte, n int, k int) ([][]byte, error) {
+	if len(secret) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	if k < 2 || k > n || n > MaxShares {
+		return nil, ErrInvalidThreshold
+	}
+
+	lenSecret := len(secret)
+	shares := make([][]byte, n)
+	for i := range shares {
+		shares[i] = make([]byte, lenSecret+1)
+		shares[i][lenSecret] = uint8(i + 1)
+	}
+
+	coeffs := make([]byte, k-1)
+	for j, s := range secret {
+		_, err := rand.Read(coeffs)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, share := range shares {
+			x := share[lenSecret]
+
+			// horner
+			var y uint8
+			for c := len(coeffs) - 1; c >= 0; c-- {
+				y = mul(y, x) ^ coeffs[c]
+			}
+			share[j] = mul(y, x) ^ s
+		}
+	}
+
+	return shares, nil
+}
+
+/*
+Combine recovers the secret from the shares.
+The shares need to be at least the threshold used in Split, otherwise the result is garbage.
+*/
+func Combine(shares [][]byte) ([]byte, error) {
+	if len(shares) < 2 {
+		return nil, ErrInvalidShares
+	}
+
+	lenShare := len(shares[0])
+	if lenShare < 2 {
+		return nil, ErrInvalidShares
+	}
+
+	xs := make([]uint8, len(shares))
+	isSeen := make(map[uint8]bool)
+	for i, share := range shares {
+		if len(share) != lenShare {
+			return nil, ErrInvalidShares
+		}
+		x := share[lenShare-1]
+		if x == 0 || isSeen[x] {
+			return nil, ErrInvalidShares
+		}
+		isSeen[x] = true
+		xs[i] = x
+	}
+
+	// lagrange basis at 0
+	basis := make([]uint8, len(shares))
+	for i, xi := range xs {
+		b := uint8(1)
+		for j, xj := range xs {
+			if i == j {
+				continue
+			}
+			b = mul(b, div(xj, xj^xi))
+		}
+		basis[i] = b
+	}
+
+	secret := make([]byte, lenShare-1)
+	for j := range secret {
+		var s uint8
+		for i, share := range shares {
+			s ^= mul(share[j], basis[i])
+		}
+		secret[j] = s
+	}
+
+	return secret, nil
+}
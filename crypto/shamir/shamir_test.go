@@ -0,0 +1,78 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package shamir
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSplitCombine(t *testing.T) {
+	secret := []byte("the master key and the postfix")
+
+	shares, err := Split(secret, 5, 3)
+	if err != nil {
+		t.Fatalf("Split: e: %v", err)
+	}
+	if len(shares) != 5 {
+		t.Fatalf("Split: len: %v", len(shares))
+	}
+
+	subsets := [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3, 4}}
+	for _, subset := range subsets {
+		theShares := make([][]byte, len(subset))
+		for i, idx := range subset {
+			theShares[i] = shares[idx]
+		}
+
+		recovered, err := Combine(theShares)
+		if err != nil {
+			t.Errorf("Combine: subset: %v e: %v", subset, err)
+			continue
+		}
+		if !bytes.Equal(recovered, secret) {
+			t.Errorf("Combine: subset: %v recovered: %v", subset, recovered)
+		}
+	}
+
+	recovered, err := Combine(shares[:2])
+	if err != nil {
+		t.Errorf("Combine: below threshold: e: %v", err)
+	}
+	if bytes.Equal(recovered, secret) {
+		t.Errorf("Combine: below threshold should not recover the secret")
+	}
+}
+
+func TestSplitInvalid(t *testing.T) {
+	secret := []byte{1, 2, 3}
+
+	if _, err := Split(secret, 3, 4); err != ErrInvalidThreshold {
+		t.Errorf("Split: k > n: e: %v", err)
+	}
+	if _, err := Split(secret, 3, 1); err != ErrInvalidThreshold {
+		t.Errorf("Split: k < 2: e: %v", err)
+	}
+	if _, err := Split(nil, 3, 2); err != ErrInvalidSecret {
+		t.Errorf("Split: empty: e: %v", err)
+	}
+
+	shares, _ := Split(secret, 3, 2)
+	if _, err := Combine([][]byte{shares[0], shares[0]}); err != ErrInvalidShares {
+		t.Errorf("Combine: duplicated: e: %v", err)
+	}
+}
@@ -42,10 +42,40 @@ func (api *PrivateAPI) CreateMessage(entityID string, message [][]byte, mediaIDs
 	)
 }
 
+/*
+GetRecoveryShares gets the recovery-shares of my friends kept by me.
+*/
+func (api *PrivateAPI) GetRecoveryShares() ([]*BackendRecoveryShare, error) {
+	return api.b.GetRecoveryShares()
+}
+
+func (api *PrivateAPI) DeleteRecoveryShare(ownerID string) (bool, error) {
+	return api.b.DeleteRecoveryShare([]byte(ownerID))
+}
+
+/*
+GetRecoveryRequests gets the pending requests from my friends to release the recovery-shares.
+*/
+func (api *PrivateAPI) GetRecoveryRequests() ([]*RecoveryRequest, error) {
+	return api.b.GetRecoveryRequests()
+}
+
+func (api *PrivateAPI) ApproveRecoveryRequest(entityID string) (bool, error) {
+	return api.b.ApproveRecoveryRequest([]byte(entityID))
+}
+
+func (api *PrivateAPI) RejectRecoveryRequest(entityID string) (bool, error) {
+	return api.b.RejectRecoveryRequest([]byte(entityID))
+}
+
 func (api *PrivateAPI) DeleteFriend(entityID string) (bool, error) {
 	return api.b.DeleteFriend([]byte(entityID))
 }
 
+func (api *PrivateAPI) SetFriendNotify(entityID string, setting pkgservice.NotifySetting) (bool, error) {
+	return api.b.SetFriendNotify([]byte(entityID), setting)
+}
+
 func (api *PrivateAPI) MarkFriendSeen(entityID string) (types.Timestamp, error) {
 	return api.b.MarkFriendSeen([]byte(entityID))
 }
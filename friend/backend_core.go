@@ -36,7 +36,7 @@ func (b *Backend) GetFriend(entityIDBytes []byte) (*BackendGetFriend, error) {
 		userName = account.NewEmptyUserName()
 	}
 
-	return friendToBackendGetFriend(theFriend, userName), nil
+	return friendToBackendGetFriend(theFriend, userName, b.Ptt()), nil
 }
 
 func (b *Backend) GetRawFriend(entityIDBytes []byte) (*Friend, error) {
@@ -66,7 +66,7 @@ func (b *Backend) GetFriendByFriendID(friendIDBytes []byte) (*BackendGetFriend,
 		return nil, err
 	}
 
-	return friendToBackendGetFriend(theFriend, userName), nil
+	return friendToBackendGetFriend(theFriend, userName, b.Ptt()), nil
 }
 
 func (b *Backend) DeleteFriend(entityIDBytes []byte) (bool, error) {
@@ -84,6 +84,23 @@ func (b *Backend) DeleteFriend(entityIDBytes []byte) (bool, error) {
 	return true, nil
 }
 
+/*
+SetFriendNotify sets the local-only notification preference of the friend.
+*/
+func (b *Backend) SetFriendNotify(entityIDBytes []byte, setting pkgservice.NotifySetting) (bool, error) {
+	entity, err := b.EntityIDToEntity(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+
+	err = pkgservice.SetNotifySetting(entity.GetID(), setting)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (b *Backend) GetFriendList(startIDBytes []byte, limit int, listOrder pttdb.ListOrder) ([]*BackendGetFriend, error) {
 
 	startID, err := types.UnmarshalTextPttID(startIDBytes, true)
@@ -104,7 +121,7 @@ func (b *Backend) GetFriendList(startIDBytes []byte, limit int, listOrder pttdb.
 		if err != nil {
 			userName = account.NewEmptyUserName()
 		}
-		backendFriendList[i] = friendToBackendGetFriend(f, userName)
+		backendFriendList[i] = friendToBackendGetFriend(f, userName, b.Ptt())
 	}
 
 	return backendFriendList, nil
@@ -129,7 +146,7 @@ func (b *Backend) GetFriendListByMsgCreateTS(theTS int64, nanoTS uint32, limit i
 		if err != nil {
 			userName = account.NewEmptyUserName()
 		}
-		backendFriendList[i] = friendToBackendGetFriend(f, userName)
+		backendFriendList[i] = friendToBackendGetFriend(f, userName, b.Ptt())
 	}
 
 	return backendFriendList, nil
@@ -302,7 +319,17 @@ func (b *Backend) MarkFriendSeen(entityIDBytes []byte) (types.Timestamp, error)
 	}
 	pm := thePM.(*ProtocolManager)
 
-	return pm.SaveLastSeen(types.ZeroTimestamp)
+	ts, err := pm.SaveLastSeen(types.ZeroTimestamp)
+	if err != nil {
+		return types.ZeroTimestamp, err
+	}
+
+	err = b.Ptt().GetMyEntity().SyncSeen(pkgservice.SeenTypeFriend, pm.Entity().GetID(), nil, ts)
+	if err != nil {
+		log.Warn("MarkFriendSeen: unable to sync seen", "e", err)
+	}
+
+	return ts, nil
 }
 
 func (b *Backend) MarkFriendListSeen() (types.Timestamp, error) {
@@ -337,3 +364,69 @@ func (b *Backend) GetFriendListSeen() (types.Timestamp, error) {
 
 	return ts, nil
 }
+
+/**********
+ * Recovery Share
+ **********/
+
+func (b *Backend) GetRecoveryShares() ([]*BackendRecoveryShare, error) {
+	shares, err := getRecoveryShareList()
+	if err != nil {
+		return nil, err
+	}
+
+	theList := make([]*BackendRecoveryShare, len(shares))
+	for i, share := range shares {
+		theList[i] = recoveryShareToBackendRecoveryShare(share)
+	}
+
+	return theList, nil
+}
+
+func (b *Backend) DeleteRecoveryShare(ownerIDBytes []byte) (bool, error) {
+	ownerID, err := types.UnmarshalTextPttID(ownerIDBytes, false)
+	if err != nil {
+		return false, err
+	}
+
+	err = deleteRecoveryShare(ownerID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) GetRecoveryRequests() ([]*RecoveryRequest, error) {
+	return getRecoveryRequestList()
+}
+
+func (b *Backend) ApproveRecoveryRequest(entityIDBytes []byte) (bool, error) {
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	err = pm.ApproveRecoveryRequest()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) RejectRecoveryRequest(entityIDBytes []byte) (bool, error) {
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	err = pm.RejectRecoveryRequest()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
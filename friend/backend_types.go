@@ -34,9 +34,16 @@ type BackendGetFriend struct {
 	Status          types.Status    `json:"S"`
 	ArticleCreateTS types.Timestamp //`json:"ACT"`
 	LastSeen        types.Timestamp `json:"LT"`
+
+	Online         bool            `json:"o"`
+	LastSeenOnline types.Timestamp `json:"lo"`
+
+	Notify pkgservice.NotifySetting `json:"NS"`
+
+	NUnreadMessage uint32 `json:"nM"`
 }
 
-func friendToBackendGetFriend(f *Friend, userName *account.UserName) *BackendGetFriend {
+func friendToBackendGetFriend(f *Friend, userName *account.UserName, ptt pkgservice.Ptt) *BackendGetFriend {
 	messageCreateTS := f.MessageCreateTS
 	if messageCreateTS.IsLess(f.CreateTS) {
 		messageCreateTS = f.CreateTS
@@ -47,6 +54,20 @@ func friendToBackendGetFriend(f *Friend, userName *account.UserName) *BackendGet
 		lastSeen = f.CreateTS
 	}
 
+	isOnline, lastOnlineTS := ptt.GetPresence(f.FriendID)
+
+	// the muted friends are always seen in the friend-list.
+	friendLastSeen := f.LastSeen
+	notify := pkgservice.LoadNotifySetting(f.ID)
+	if notify != pkgservice.NotifySettingAll && friendLastSeen.IsLess(messageCreateTS) {
+		friendLastSeen = messageCreateTS
+	}
+
+	unread, err := f.LoadUnread()
+	if err != nil || notify != pkgservice.NotifySettingAll {
+		unread = &FriendUnread{}
+	}
+
 	return &BackendGetFriend{
 		ID:              f.ID,
 		FriendID:        f.FriendID,
@@ -54,7 +75,11 @@ func friendToBackendGetFriend(f *Friend, userName *account.UserName) *BackendGet
 		Status:          f.Status,
 		BoardID:         f.BoardID,
 		ArticleCreateTS: messageCreateTS,
-		LastSeen:        f.LastSeen,
+		LastSeen:        friendLastSeen,
+		Online:          isOnline,
+		LastSeenOnline:  lastOnlineTS,
+		Notify:          notify,
+		NUnreadMessage:  unread.NMessage,
 	}
 }
 
@@ -138,3 +163,24 @@ func contentBlockToBackendMessageBlock(msg *Message, blockInfoID *types.PttID, c
 		Buf: contentBlock.Buf,
 	}
 }
+
+/*
+BackendRecoveryShare hides the share itself.
+*/
+type BackendRecoveryShare struct {
+	OwnerID   *types.PttID    `json:"OID"`
+	SetID     *types.PttID    `json:"SID"`
+	Threshold int             `json:"k"`
+	NShare    int             `json:"n"`
+	CreateTS  types.Timestamp `json:"CT"`
+}
+
+func recoveryShareToBackendRecoveryShare(s *RecoveryShare) *BackendRecoveryShare {
+	return &BackendRecoveryShare{
+		OwnerID:   s.OwnerID,
+		SetID:     s.SetID,
+		Threshold: s.Threshold,
+		NShare:    s.NShare,
+		CreateTS:  s.CreateTS,
+	}
+}
@@ -20,4 +20,6 @@ import "errors"
 
 var (
 	ErrInvalidFriend = errors.New("invalid friend")
+
+	ErrInvalidRecoveryShare = errors.New("invalid recovery share")
 )
@@ -0,0 +1,81 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package friend
+
+import (
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/*
+FriendUnread is the local counter of the unseen messages since the last-seen of the friend.
+*/
+type FriendUnread struct {
+	NMessage uint32 `json:"M"`
+}
+
+func (f *Friend) SaveUnread(unread *FriendUnread) error {
+	key, err := f.MarshalUnreadKey()
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := json.Marshal(unread)
+	if err != nil {
+		return err
+	}
+
+	return dbFriendCore.Put(key, marshaled)
+}
+
+func (f *Friend) LoadUnread() (*FriendUnread, error) {
+	unread := &FriendUnread{}
+
+	key, err := f.MarshalUnreadKey()
+	if err != nil {
+		return nil, err
+	}
+	data, err := dbFriendCore.Get(key)
+	if err == leveldb.ErrNotFound {
+		return unread, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, unread)
+	if err != nil {
+		return nil, err
+	}
+
+	return unread, nil
+}
+
+func (f *Friend) DeleteUnread() error {
+	key, err := f.MarshalUnreadKey()
+	if err != nil {
+		return err
+	}
+
+	return dbFriendCore.Delete(key)
+}
+
+func (f *Friend) MarshalUnreadKey() ([]byte, error) {
+	return common.Concat([][]byte{DBFriendUnreadPrefix, f.ID[:]})
+}
@@ -0,0 +1,89 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package friend
+
+import (
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+func TestFriendUnread(t *testing.T) {
+	pm, teardown := tNewFriendPM(t)
+	defer teardown()
+
+	f := pm.Entity().(*Friend)
+
+	_, err := pm.SaveLastSeen(types.Timestamp{Ts: 1000})
+	if err != nil {
+		t.Fatalf("SaveLastSeen() error = %v", err)
+	}
+
+	// prepare test-cases
+	tests := []struct {
+		name string
+		run  func() error
+		want FriendUnread
+	}{
+		{
+			name: "create message",
+			run:  func() error { return pm.increaseUnread(types.Timestamp{Ts: 1100}) },
+			want: FriendUnread{NMessage: 1},
+		},
+		{
+			name: "create before last-seen",
+			run:  func() error { return pm.increaseUnread(types.Timestamp{Ts: 900}) },
+			want: FriendUnread{NMessage: 1},
+		},
+		{
+			name: "seen",
+			run: func() error {
+				_, err := pm.SaveLastSeen(types.Timestamp{Ts: 1200})
+				return err
+			},
+			want: FriendUnread{},
+		},
+		{
+			name: "create after seen",
+			run:  func() error { return pm.increaseUnread(types.Timestamp{Ts: 1300}) },
+			want: FriendUnread{NMessage: 1},
+		},
+		{
+			name: "delete friend",
+			run:  f.DeleteUnread,
+			want: FriendUnread{},
+		},
+	}
+
+	// run test
+	for _, tt := range tests {
+		err := tt.run()
+		if err != nil {
+			t.Errorf("%v: error = %v", tt.name, err)
+			continue
+		}
+
+		got, err := f.LoadUnread()
+		if err != nil {
+			t.Errorf("%v: LoadUnread() error = %v", tt.name, err)
+			continue
+		}
+		if *got != tt.want {
+			t.Errorf("%v: LoadUnread() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -56,8 +56,14 @@ var (
 	DBMessageCreateTSPrefix    = []byte(".frmc")
 	DBMessageCreateTSIdxPrefix = []byte(".mcix")
 	DBMessageCreateTS2Prefix   = []byte(".mcdb")
+	DBFriendUnreadPrefix       = []byte(".frur")
 
 	DBFriendListSeenPrefix = []byte(".frsn")
+
+	DBRecoverySharePrefix        = []byte(".frrs")
+	DBPendingRecoverySharePrefix = []byte(".frrp")
+	DBRecoveryRequestPrefix      = []byte(".frrq")
+	DBRecoveredSharePrefix       = []byte(".frrd")
 )
 
 // protocol
@@ -90,6 +96,13 @@ const (
 	// init friend info
 	InitFriendInfoMsg
 	InitFriendInfoAckMsg
+
+	// recovery share
+	SendRecoveryShareMsg
+	RequestRecoveryShareMsg
+	ReleaseRecoveryShareMsg
+	CommitRecoveryShareMsg
+	AbortRecoveryShareMsg
 )
 
 // max-masters
@@ -16,7 +16,14 @@
 
 package friend
 
-import "testing"
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
 
 const ()
 
@@ -27,3 +34,42 @@ func setupTest(t *testing.T) {
 
 func teardownTest(t *testing.T) {
 }
+
+/*
+tNewFriendPM creates the protocol-manager of an alive friend with the friend-db in a temp-dir.
+*/
+func tNewFriendPM(t *testing.T) (*ProtocolManager, func()) {
+	dir, err := ioutil.TempDir("", "friend-test")
+	if err != nil {
+		t.Fatalf("TempDir() error = %v", err)
+	}
+
+	err = InitFriend(dir)
+	if err != nil {
+		t.Fatalf("InitFriend() error = %v", err)
+	}
+
+	teardown := func() {
+		TeardownFriend()
+		os.RemoveAll(dir)
+	}
+
+	myID, _ := types.NewPttID()
+	friendID, _ := types.NewPttID()
+	ts, _ := types.GetTimestamp()
+	dbLock, _ := types.NewLockMap(0)
+
+	f := &Friend{
+		BaseEntity: pkgservice.NewBaseEntity(friendID, ts, myID, types.StatusAlive, dbFriend, dbLock),
+		FriendID:   friendID,
+	}
+
+	pm, err := NewProtocolManager(f, nil)
+	if err != nil {
+		teardown()
+		t.Fatalf("NewProtocolManager() error = %v", err)
+	}
+	f.BaseEntity.Init(pm, nil, nil)
+
+	return pm, teardown
+}
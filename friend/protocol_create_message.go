@@ -145,6 +145,12 @@ func (pm *ProtocolManager) postcreateMessage(theObj pkgservice.Object, oplog *pk
 
 	if reflect.DeepEqual(myID, creatorID) {
 		pm.SaveLastSeen(oplog.UpdateTS)
+		return nil
+	}
+
+	err := pm.increaseUnread(oplog.UpdateTS)
+	if err != nil {
+		log.Warn("postcreateMessage: unable to increase unread", "e", err)
 	}
 
 	return nil
@@ -74,6 +74,8 @@ func (pm *ProtocolManager) postdeleteFriend(theOpData pkgservice.OpData, isForce
 
 	pm.CleanObject()
 
+	f.DeleteUnread()
+
 	pm.DefaultPostdeleteEntity(theOpData, isForce)
 
 	origFriend, err := pm.Entity().Service().SPM().(*ServiceProtocolManager).GetFriendByFriendID(friendID)
@@ -17,6 +17,8 @@
 package friend
 
 import (
+	"sync"
+
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/log"
 	pkgservice "github.com/ailabstw/go-pttai/service"
@@ -32,6 +34,9 @@ type ProtocolManager struct {
 	// message
 	dbMessagePrefix    []byte
 	dbMessageIdxPrefix []byte
+
+	// unread
+	lockUnread sync.Mutex
 }
 
 func NewProtocolManager(f *Friend, ptt pkgservice.Ptt) (*ProtocolManager, error) {
@@ -74,6 +74,18 @@ func (pm *ProtocolManager) HandleMessage(op pkgservice.OpType, dataBytes []byte,
 	case SyncCreateMessageBlockAckMsg:
 		err = pm.HandleSyncCreateMessageBlockAck(dataBytes, peer)
 
+	// recovery share
+	case SendRecoveryShareMsg:
+		err = pm.HandleSendRecoveryShare(dataBytes, peer)
+	case RequestRecoveryShareMsg:
+		err = pm.HandleRequestRecoveryShare(dataBytes, peer)
+	case ReleaseRecoveryShareMsg:
+		err = pm.HandleReleaseRecoveryShare(dataBytes, peer)
+	case CommitRecoveryShareMsg:
+		err = pm.HandleCommitRecoveryShare(dataBytes, peer)
+	case AbortRecoveryShareMsg:
+		err = pm.HandleAbortRecoveryShare(dataBytes, peer)
+
 	default:
 		log.Error("invalid op", "op", op, "InitFriendInfoMsg", InitFriendInfoMsg)
 		err = pkgservice.ErrInvalidMsgCode
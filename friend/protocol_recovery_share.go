@@ -0,0 +1,375 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package friend
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+type SendRecoveryShare struct {
+	SetID     *types.PttID `json:"SID"`
+	Share     []byte       `json:"s"`
+	Threshold int          `json:"k"`
+	NShare    int          `json:"n"`
+}
+
+/*
+RecoveryShareSet is to commit / to abort the pending recovery-shares of the set.
+*/
+type RecoveryShareSet struct {
+	SetID *types.PttID `json:"SID"`
+}
+
+type RequestRecoveryShare struct {
+	OwnerID *types.PttID `json:"OID"`
+}
+
+type ReleaseRecoveryShare struct {
+	OwnerID   *types.PttID `json:"OID"`
+	SetID     *types.PttID `json:"SID"`
+	Share     []byte       `json:"s"`
+	Threshold int          `json:"k"`
+}
+
+/*
+friendPeerList returns the peers of my friend (excluding my own nodes).
+*/
+func (pm *ProtocolManager) friendPeerList() []*pkgservice.PttPeer {
+	f := pm.Entity().(*Friend)
+
+	peerList := pm.Peers().PeerList(false)
+	friendPeerList := make([]*pkgservice.PttPeer, 0, len(peerList))
+	for _, peer := range peerList {
+		if !reflect.DeepEqual(peer.UserID, f.FriendID) {
+			continue
+		}
+		friendPeerList = append(friendPeerList, peer)
+	}
+
+	return friendPeerList
+}
+
+func (pm *ProtocolManager) IsFriendOnline() bool {
+	return len(pm.friendPeerList()) != 0
+}
+
+/**********
+ * SPM
+ **********/
+
+/*
+RequestRecoveryShares requests all my online friends to release the recovery-share of the owner.
+Returns the number of the friends requested.
+*/
+func (spm *ServiceProtocolManager) RequestRecoveryShares(ownerID *types.PttID) (int, error) {
+	count := 0
+	for _, entity := range spm.Entities() {
+		if entity.GetStatus() != types.StatusAlive {
+			continue
+		}
+
+		pm := entity.PM().(*ProtocolManager)
+		err := pm.RequestRecoveryShare(ownerID)
+		if err != nil {
+			continue
+		}
+		count++
+	}
+
+	if count == 0 {
+		return 0, pkgservice.ErrNotSent
+	}
+
+	return count, nil
+}
+
+func (spm *ServiceProtocolManager) GetRecoveredShareList(ownerID *types.PttID) ([]*RecoveredShare, error) {
+	return getRecoveredShareList(ownerID)
+}
+
+/**********
+ * Send (owner => holder)
+ **********/
+
+/*
+SendRecoveryShare sends my recovery-share to my friend (encrypted with the op-key of the friend-entity).
+The share is pending at my friend until CommitRecoveryShare.
+*/
+func (pm *ProtocolManager) SendRecoveryShare(setID *types.PttID, share []byte, threshold int, nShare int) error {
+	peerList := pm.friendPeerList()
+	if len(peerList) == 0 {
+		return pkgservice.ErrNotSent
+	}
+
+	data := &SendRecoveryShare{
+		SetID:     setID,
+		Share:     share,
+		Threshold: threshold,
+		NShare:    nShare,
+	}
+
+	return pm.SendDataToPeers(SendRecoveryShareMsg, data, peerList)
+}
+
+func (pm *ProtocolManager) HandleSendRecoveryShare(dataBytes []byte, peer *pkgservice.PttPeer) error {
+	f := pm.Entity().(*Friend)
+	if !reflect.DeepEqual(peer.UserID, f.FriendID) {
+		return types.ErrInvalidID
+	}
+
+	data := &SendRecoveryShare{}
+	err := json.Unmarshal(dataBytes, data)
+	if err != nil {
+		return err
+	}
+	if data.SetID == nil || len(data.Share) == 0 || data.Threshold < 2 || data.Threshold > data.NShare {
+		return ErrInvalidRecoveryShare
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return err
+	}
+
+	s := &RecoveryShare{
+		V:         types.CurrentVersion,
+		OwnerID:   f.FriendID,
+		SetID:     data.SetID,
+		Share:     data.Share,
+		Threshold: data.Threshold,
+		NShare:    data.NShare,
+		CreateTS:  ts,
+	}
+
+	log.Debug("HandleSendRecoveryShare: to save pending", "owner", f.FriendID, "setID", data.SetID)
+
+	return s.SavePending()
+}
+
+/*
+CommitRecoveryShare requests my friend to keep the pending recovery-share of the set,
+replacing the share of the previous set.
+*/
+func (pm *ProtocolManager) CommitRecoveryShare(setID *types.PttID) error {
+	return pm.sendRecoveryShareSet(CommitRecoveryShareMsg, setID)
+}
+
+/*
+AbortRecoveryShare requests my friend to discard the pending recovery-share of the set.
+*/
+func (pm *ProtocolManager) AbortRecoveryShare(setID *types.PttID) error {
+	return pm.sendRecoveryShareSet(AbortRecoveryShareMsg, setID)
+}
+
+func (pm *ProtocolManager) sendRecoveryShareSet(op pkgservice.OpType, setID *types.PttID) error {
+	peerList := pm.friendPeerList()
+	if len(peerList) == 0 {
+		return pkgservice.ErrNotSent
+	}
+
+	data := &RecoveryShareSet{
+		SetID: setID,
+	}
+
+	return pm.SendDataToPeers(op, data, peerList)
+}
+
+func (pm *ProtocolManager) HandleCommitRecoveryShare(dataBytes []byte, peer *pkgservice.PttPeer) error {
+	s, err := pm.getPendingRecoveryShareWithSet(dataBytes, peer)
+	if err != nil {
+		return err
+	}
+
+	err = s.Save()
+	if err != nil {
+		return err
+	}
+
+	log.Debug("HandleCommitRecoveryShare: saved", "owner", s.OwnerID, "setID", s.SetID)
+
+	return deletePendingRecoveryShare(s.OwnerID)
+}
+
+func (pm *ProtocolManager) HandleAbortRecoveryShare(dataBytes []byte, peer *pkgservice.PttPeer) error {
+	s, err := pm.getPendingRecoveryShareWithSet(dataBytes, peer)
+	if err != nil {
+		return err
+	}
+
+	return deletePendingRecoveryShare(s.OwnerID)
+}
+
+func (pm *ProtocolManager) getPendingRecoveryShareWithSet(dataBytes []byte, peer *pkgservice.PttPeer) (*RecoveryShare, error) {
+	f := pm.Entity().(*Friend)
+	if !reflect.DeepEqual(peer.UserID, f.FriendID) {
+		return nil, types.ErrInvalidID
+	}
+
+	data := &RecoveryShareSet{}
+	err := json.Unmarshal(dataBytes, data)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := getPendingRecoveryShare(f.FriendID)
+	if err != nil {
+		return nil, err
+	}
+	if !reflect.DeepEqual(s.SetID, data.SetID) {
+		return nil, ErrInvalidRecoveryShare
+	}
+
+	return s, nil
+}
+
+/**********
+ * Request (requester => holder)
+ **********/
+
+/*
+RequestRecoveryShare requests my friend to release the recovery-share of the owner.
+*/
+func (pm *ProtocolManager) RequestRecoveryShare(ownerID *types.PttID) error {
+	peerList := pm.friendPeerList()
+	if len(peerList) == 0 {
+		return pkgservice.ErrNotSent
+	}
+
+	data := &RequestRecoveryShare{
+		OwnerID: ownerID,
+	}
+
+	return pm.SendDataToPeers(RequestRecoveryShareMsg, data, peerList)
+}
+
+func (pm *ProtocolManager) HandleRequestRecoveryShare(dataBytes []byte, peer *pkgservice.PttPeer) error {
+	f := pm.Entity().(*Friend)
+	if !reflect.DeepEqual(peer.UserID, f.FriendID) {
+		return types.ErrInvalidID
+	}
+
+	data := &RequestRecoveryShare{}
+	err := json.Unmarshal(dataBytes, data)
+	if err != nil {
+		return err
+	}
+
+	// I do not keep the share of the owner.
+	_, err = getRecoveryShare(data.OwnerID)
+	if err != nil {
+		return nil
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return err
+	}
+
+	r := &RecoveryRequest{
+		V:              types.CurrentVersion,
+		RequesterID:    f.FriendID,
+		FriendEntityID: f.ID,
+		OwnerID:        data.OwnerID,
+		CreateTS:       ts,
+	}
+
+	return r.Save()
+}
+
+/**********
+ * Release (holder => requester)
+ **********/
+
+/*
+ApproveRecoveryRequest releases the recovery-share requested by my friend.
+*/
+func (pm *ProtocolManager) ApproveRecoveryRequest() error {
+	f := pm.Entity().(*Friend)
+
+	r, err := getRecoveryRequest(f.FriendID)
+	if err != nil {
+		return err
+	}
+
+	s, err := getRecoveryShare(r.OwnerID)
+	if err != nil {
+		return err
+	}
+
+	peerList := pm.friendPeerList()
+	if len(peerList) == 0 {
+		return pkgservice.ErrNotSent
+	}
+
+	data := &ReleaseRecoveryShare{
+		OwnerID:   s.OwnerID,
+		SetID:     s.SetID,
+		Share:     s.Share,
+		Threshold: s.Threshold,
+	}
+
+	err = pm.SendDataToPeers(ReleaseRecoveryShareMsg, data, peerList)
+	if err != nil {
+		return err
+	}
+
+	return deleteRecoveryRequest(f.FriendID)
+}
+
+func (pm *ProtocolManager) RejectRecoveryRequest() error {
+	f := pm.Entity().(*Friend)
+
+	return deleteRecoveryRequest(f.FriendID)
+}
+
+func (pm *ProtocolManager) HandleReleaseRecoveryShare(dataBytes []byte, peer *pkgservice.PttPeer) error {
+	f := pm.Entity().(*Friend)
+	if !reflect.DeepEqual(peer.UserID, f.FriendID) {
+		return types.ErrInvalidID
+	}
+
+	data := &ReleaseRecoveryShare{}
+	err := json.Unmarshal(dataBytes, data)
+	if err != nil {
+		return err
+	}
+	if data.OwnerID == nil || data.SetID == nil || len(data.Share) == 0 || data.Threshold < 2 {
+		return ErrInvalidRecoveryShare
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return err
+	}
+
+	s := &RecoveredShare{
+		V:         types.CurrentVersion,
+		OwnerID:   data.OwnerID,
+		HolderID:  f.FriendID,
+		SetID:     data.SetID,
+		Share:     data.Share,
+		Threshold: data.Threshold,
+		CreateTS:  ts,
+	}
+
+	return s.Save()
+}
@@ -28,10 +28,53 @@ func (pm *ProtocolManager) SaveLastSeen(ts types.Timestamp) (types.Timestamp, er
 	}
 
 	f := pm.Entity().(*Friend)
+
+	pm.lockUnread.Lock()
+	defer pm.lockUnread.Unlock()
+
+	lastSeen, err := f.LoadLastSeen()
+	if err != nil {
+		return types.ZeroTimestamp, err
+	}
+
 	err = f.SaveLastSeen(ts)
 	if err != nil {
 		return types.ZeroTimestamp, err
 	}
 
+	if lastSeen.IsLess(ts) {
+		err = f.SaveUnread(&FriendUnread{})
+		if err != nil {
+			return types.ZeroTimestamp, err
+		}
+	}
+
 	return ts, nil
 }
+
+/*
+increaseUnread increases the unread counter of the friend if the message is created after the last-seen.
+*/
+func (pm *ProtocolManager) increaseUnread(ts types.Timestamp) error {
+	f := pm.Entity().(*Friend)
+
+	pm.lockUnread.Lock()
+	defer pm.lockUnread.Unlock()
+
+	lastSeen, err := f.LoadLastSeen()
+	if err != nil {
+		return err
+	}
+	if !lastSeen.IsLess(ts) {
+		return nil
+	}
+
+	unread, err := f.LoadUnread()
+	if err != nil {
+		return err
+	}
+
+	unread.NMessage++
+
+	return f.SaveUnread(unread)
+}
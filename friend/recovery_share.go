@@ -0,0 +1,292 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package friend
+
+import (
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/pttdb"
+)
+
+/*
+RecoveryShare is the shamir-share of the master-key of my friend (the owner), kept by me.
+The share is pending until the owner commits the set of the shares (sent to all the holders.)
+*/
+type RecoveryShare struct {
+	V         types.Version
+	OwnerID   *types.PttID    `json:"OID"`
+	SetID     *types.PttID    `json:"SID"`
+	Share     []byte          `json:"s"`
+	Threshold int             `json:"k"`
+	NShare    int             `json:"n"`
+	CreateTS  types.Timestamp `json:"CT"`
+}
+
+/*
+RecoveryRequest is the request from my friend (the requester, usually the fresh identity of the owner)
+to release the recovery-share of the owner. The request is pending until I approve it.
+*/
+type RecoveryRequest struct {
+	V              types.Version
+	RequesterID    *types.PttID    `json:"RID"`
+	FriendEntityID *types.PttID    `json:"FID"`
+	OwnerID        *types.PttID    `json:"OID"`
+	CreateTS       types.Timestamp `json:"CT"`
+}
+
+/*
+RecoveredShare is the recovery-share released to me by my friend (the holder).
+*/
+type RecoveredShare struct {
+	V         types.Version
+	OwnerID   *types.PttID    `json:"OID"`
+	HolderID  *types.PttID    `json:"HID"`
+	SetID     *types.PttID    `json:"SID"`
+	Share     []byte          `json:"s"`
+	Threshold int             `json:"k"`
+	CreateTS  types.Timestamp `json:"CT"`
+}
+
+/**********
+ * RecoveryShare
+ **********/
+
+func marshalRecoveryShareKey(ownerID *types.PttID) ([]byte, error) {
+	return common.Concat([][]byte{DBRecoverySharePrefix, ownerID[:]})
+}
+
+func marshalPendingRecoveryShareKey(ownerID *types.PttID) ([]byte, error) {
+	return common.Concat([][]byte{DBPendingRecoverySharePrefix, ownerID[:]})
+}
+
+func (s *RecoveryShare) Save() error {
+	key, err := marshalRecoveryShareKey(s.OwnerID)
+	if err != nil {
+		return err
+	}
+
+	return s.save(key)
+}
+
+func (s *RecoveryShare) SavePending() error {
+	key, err := marshalPendingRecoveryShareKey(s.OwnerID)
+	if err != nil {
+		return err
+	}
+
+	return s.save(key)
+}
+
+func (s *RecoveryShare) save(key []byte) error {
+	marshaled, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	return dbMeta.Put(key, marshaled)
+}
+
+func getRecoveryShare(ownerID *types.PttID) (*RecoveryShare, error) {
+	key, err := marshalRecoveryShareKey(ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return getRecoveryShareByKey(key)
+}
+
+func getPendingRecoveryShare(ownerID *types.PttID) (*RecoveryShare, error) {
+	key, err := marshalPendingRecoveryShareKey(ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return getRecoveryShareByKey(key)
+}
+
+func getRecoveryShareByKey(key []byte) (*RecoveryShare, error) {
+	theBytes, err := dbMeta.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &RecoveryShare{}
+	err = json.Unmarshal(theBytes, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func getRecoveryShareList() ([]*RecoveryShare, error) {
+	iter, err := dbMeta.NewIteratorWithPrefix(nil, DBRecoverySharePrefix, pttdb.ListOrderNext)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	shares := make([]*RecoveryShare, 0)
+	for iter.Next() {
+		s := &RecoveryShare{}
+		err = json.Unmarshal(iter.Value(), s)
+		if err != nil {
+			continue
+		}
+		shares = append(shares, s)
+	}
+
+	return shares, nil
+}
+
+func deleteRecoveryShare(ownerID *types.PttID) error {
+	key, err := marshalRecoveryShareKey(ownerID)
+	if err != nil {
+		return err
+	}
+
+	return dbMeta.Delete(key)
+}
+
+func deletePendingRecoveryShare(ownerID *types.PttID) error {
+	key, err := marshalPendingRecoveryShareKey(ownerID)
+	if err != nil {
+		return err
+	}
+
+	return dbMeta.Delete(key)
+}
+
+/**********
+ * RecoveryRequest
+ **********/
+
+func marshalRecoveryRequestKey(requesterID *types.PttID) ([]byte, error) {
+	return common.Concat([][]byte{DBRecoveryRequestPrefix, requesterID[:]})
+}
+
+func (r *RecoveryRequest) Save() error {
+	key, err := marshalRecoveryRequestKey(r.RequesterID)
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	return dbMeta.Put(key, marshaled)
+}
+
+func getRecoveryRequest(requesterID *types.PttID) (*RecoveryRequest, error) {
+	key, err := marshalRecoveryRequestKey(requesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	theBytes, err := dbMeta.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &RecoveryRequest{}
+	err = json.Unmarshal(theBytes, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func getRecoveryRequestList() ([]*RecoveryRequest, error) {
+	iter, err := dbMeta.NewIteratorWithPrefix(nil, DBRecoveryRequestPrefix, pttdb.ListOrderNext)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	requests := make([]*RecoveryRequest, 0)
+	for iter.Next() {
+		r := &RecoveryRequest{}
+		err = json.Unmarshal(iter.Value(), r)
+		if err != nil {
+			continue
+		}
+		requests = append(requests, r)
+	}
+
+	return requests, nil
+}
+
+func deleteRecoveryRequest(requesterID *types.PttID) error {
+	key, err := marshalRecoveryRequestKey(requesterID)
+	if err != nil {
+		return err
+	}
+
+	return dbMeta.Delete(key)
+}
+
+/**********
+ * RecoveredShare
+ **********/
+
+func marshalRecoveredShareKey(ownerID *types.PttID, holderID *types.PttID) ([]byte, error) {
+	return common.Concat([][]byte{DBRecoveredSharePrefix, ownerID[:], holderID[:]})
+}
+
+func (s *RecoveredShare) Save() error {
+	key, err := marshalRecoveredShareKey(s.OwnerID, s.HolderID)
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	return dbMeta.Put(key, marshaled)
+}
+
+func getRecoveredShareList(ownerID *types.PttID) ([]*RecoveredShare, error) {
+	prefix, err := common.Concat([][]byte{DBRecoveredSharePrefix, ownerID[:]})
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := dbMeta.NewIteratorWithPrefix(nil, prefix, pttdb.ListOrderNext)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	shares := make([]*RecoveredShare, 0)
+	for iter.Next() {
+		s := &RecoveredShare{}
+		err = json.Unmarshal(iter.Value(), s)
+		if err != nil {
+			continue
+		}
+		shares = append(shares, s)
+	}
+
+	return shares, nil
+}
@@ -44,10 +44,22 @@ func (api *PrivateAPI) SetMyNameCard(nameCard []byte) (*account.NameCard, error)
 	return api.b.SetMyNameCard(nameCard)
 }
 
+/*
+SetMyProfile sets the structured profile-fields.
+Visibility: 1: everyone, 2: friends, 3: the members of the boards in BoardIDs.
+*/
+func (api *PrivateAPI) SetMyProfile(fields []*account.ProfileField) (*account.UserProfile, error) {
+	return api.b.SetMyProfile(fields)
+}
+
 func (api *PrivateAPI) SetMyNodeName(nodeID string, name []byte) (*MyNode, error) {
 	return api.b.SetMyNodeName([]byte(nodeID), name)
 }
 
+func (api *PrivateAPI) SetMyNodePermission(nodeID string, perm NodePermission) (bool, error) {
+	return api.b.SetMyNodePermission([]byte(nodeID), perm)
+}
+
 func (api *PrivateAPI) SetMyImage(imgStr string) (*account.UserImg, error) {
 	return api.b.SetMyImage(imgStr)
 }
@@ -68,6 +80,13 @@ func (api *PrivateAPI) ShowMeURL() (*pkgservice.BackendJoinURL, error) {
 	return api.b.ShowMeURL()
 }
 
+/*
+ShowMeURLQRCode renders the url of ShowMeURL as a QR-code. format: png (default) or svg. size: width in pixels, 0 as default.
+*/
+func (api *PrivateAPI) ShowMeURLQRCode(format string, size int) (*pkgservice.BackendJoinURLQRCode, error) {
+	return api.b.ShowMeURLQRCode([]byte(format), size)
+}
+
 func (api *PrivateAPI) JoinMe(meURL string, myKey string, dummy bool) (*pkgservice.BackendJoinRequest, error) {
 
 	return api.b.JoinMe([]byte(meURL), []byte(myKey))
@@ -85,7 +104,8 @@ func (api *PrivateAPI) GetJoinKeyInfos(entityID string) ([]*pkgservice.KeyInfo,
 }
 
 /*
-GetMeRequests get the me-requests from me to the others.
+GetMeRequests get the me-requests from me to the others,
+and the join-me from the new node waiting for confirmation, with the SAS codes.
 */
 func (api *PrivateAPI) GetMeRequests(entityID string) ([]*pkgservice.BackendJoinRequest, error) {
 	var err error
@@ -109,6 +129,17 @@ func (api *PrivateAPI) RemoveMeRequests(entityID string, hash []byte) (bool, err
 	return api.b.RemoveMeRequests([]byte(entityID), hash)
 }
 
+/*
+ConfirmJoinMe confirms the join-me from the new node with the SAS code shown on the new node.
+*/
+func (api *PrivateAPI) ConfirmJoinMe(nodeID string, sas string) (bool, error) {
+	return api.b.ConfirmJoinMe([]byte(nodeID), []byte(sas))
+}
+
+func (api *PrivateAPI) RejectJoinMe(nodeID string) (bool, error) {
+	return api.b.RejectJoinMe([]byte(nodeID))
+}
+
 /**********
  * JoinFriend
  **********/
@@ -150,6 +181,17 @@ func (api *PrivateAPI) JoinBoard(friendURL string) (*pkgservice.BackendJoinReque
 	return api.b.JoinBoard([]byte(friendURL))
 }
 
+/*
+JoinPublicBoard joins the public board with the board-id. nodeID is optional.
+*/
+func (api *PrivateAPI) JoinPublicBoard(entityID string, nodeID *string) (*pkgservice.BackendJoinRequest, error) {
+	theNodeID := ""
+	if nodeID != nil {
+		theNodeID = *nodeID
+	}
+	return api.b.JoinPublicBoard([]byte(entityID), []byte(theNodeID))
+}
+
 /*
 GetBoardRequests get the friend-requests from me to the others.
 */
@@ -474,6 +516,73 @@ func (api *PrivateAPI) RefreshMyNodeSignKey() (*pkgservice.KeyInfo, error) {
 	return api.b.RefreshMyNodeSignKey()
 }
 
+/**********
+ * Recovery
+ **********/
+
+/*
+SetupRecovery splits my master-key into the shares and sends each share to the friends.
+Any threshold of the friends are able to help restore my identity.
+*/
+func (api *PrivateAPI) SetupRecovery(friendIDs []string, threshold int) (bool, error) {
+	friendIDsBytes := make([][]byte, len(friendIDs))
+	for i, friendID := range friendIDs {
+		friendIDsBytes[i] = []byte(friendID)
+	}
+	return api.b.SetupRecovery(friendIDsBytes, threshold)
+}
+
+/*
+RequestRecovery requests the friends (of this fresh identity) to release the recovery-shares of the owner.
+The friends need to approve the requests with friend_approveRecoveryRequest.
+*/
+func (api *PrivateAPI) RequestRecovery(ownerID string) (int, error) {
+	return api.b.RequestRecovery([]byte(ownerID))
+}
+
+/*
+RecoverMe restores the identity of the owner with the released recovery-shares, and restarts the node.
+*/
+func (api *PrivateAPI) RecoverMe(ownerID string) (bool, error) {
+	return api.b.RecoverMe([]byte(ownerID))
+}
+
+/**********
+ * Blocklist
+ **********/
+
+/*
+BlockUser blocks the user locally.
+The articles / comments from the user are hidden and the friend-requests / peers from the user are refused.
+*/
+func (api *PrivateAPI) BlockUser(userID string) (bool, error) {
+	return api.b.BlockUser([]byte(userID))
+}
+
+func (api *PrivateAPI) UnblockUser(userID string) (bool, error) {
+	return api.b.UnblockUser([]byte(userID))
+}
+
+func (api *PrivateAPI) GetBlockedUserList() ([]*pkgservice.BlockedUser, error) {
+	return api.b.GetBlockedUserList()
+}
+
+/**********
+ * Bookmark
+ **********/
+
+func (api *PrivateAPI) AddBookmark(boardID string, articleID string, folder string) (*BackendBookmark, error) {
+	return api.b.AddBookmark([]byte(boardID), []byte(articleID), []byte(folder))
+}
+
+func (api *PrivateAPI) DeleteBookmark(boardID string, articleID string) (bool, error) {
+	return api.b.DeleteBookmark([]byte(boardID), []byte(articleID))
+}
+
+func (api *PrivateAPI) GetBookmarkList(folder string) ([]*BackendBookmark, error) {
+	return api.b.GetBookmarkList([]byte(folder))
+}
+
 /**********
  * Misc
  **********/
@@ -501,3 +610,7 @@ func (api *PublicAPI) Get() (*BackendMyInfo, error) {
 func (api *PublicAPI) ShowURL() (*pkgservice.BackendJoinURL, error) {
 	return api.b.ShowURL()
 }
+
+func (api *PublicAPI) ShowURLQRCode(format string, size int) (*pkgservice.BackendJoinURLQRCode, error) {
+	return api.b.ShowURLQRCode([]byte(format), size)
+}
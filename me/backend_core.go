@@ -23,6 +23,7 @@ import (
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/content"
 	"github.com/ailabstw/go-pttai/crypto"
+	"github.com/ailabstw/go-pttai/friend"
 	"github.com/ailabstw/go-pttai/log"
 	"github.com/ailabstw/go-pttai/p2p/discover"
 	"github.com/ailabstw/go-pttai/pttdb"
@@ -47,10 +48,36 @@ func (b *Backend) SetMyNameCard(nameCard []byte) (*account.NameCard, error) {
 	return myProfilePM.UpdateNameCard(nameCard)
 }
 
+func (b *Backend) SetMyProfile(fields []*account.ProfileField) (*account.UserProfile, error) {
+
+	myInfo := b.SPM().(*ServiceProtocolManager).MyInfo
+
+	myProfilePM := myInfo.Profile.PM().(*account.ProtocolManager)
+
+	return myProfilePM.UpdateUserProfile(fields)
+}
+
 func (b *Backend) SetMyNodeName(nodeIDBytes []byte, name []byte) (*MyNode, error) {
 	return nil, types.ErrNotImplemented
 }
 
+func (b *Backend) SetMyNodePermission(nodeIDBytes []byte, perm NodePermission) (bool, error) {
+	nodeID, err := discover.HexID(string(nodeIDBytes))
+	if err != nil {
+		return false, err
+	}
+
+	myInfo := b.SPM().(*ServiceProtocolManager).MyInfo
+	pm := myInfo.PM().(*ProtocolManager)
+
+	err = pm.ProposeRaftSetNodePermission(&nodeID, perm&NodePermAll)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (b *Backend) SetMyImage(imgStr string) (*account.UserImg, error) {
 	myInfo := b.SPM().(*ServiceProtocolManager).MyInfo
 
@@ -66,6 +93,11 @@ func (b *Backend) SetMyImage(imgStr string) (*account.UserImg, error) {
 func (b *Backend) ShowMyMasterKey() ([]byte, error) {
 	myInfo := b.SPM().(*ServiceProtocolManager).MyInfo
 
+	pm := myInfo.PM().(*ProtocolManager)
+	if !pm.IsMyNodePermitted(NodePermExportKey, false) {
+		return nil, pkgservice.ErrNotPermitted
+	}
+
 	masterKey := myInfo.GetMasterKey()
 
 	theBytes := crypto.FromECDSA(masterKey)
@@ -148,6 +180,62 @@ func (b *Backend) RefreshMyNodeSignKey() (*pkgservice.KeyInfo, error) {
 	return key, nil
 }
 
+/**********
+ * Recovery
+ **********/
+
+func (b *Backend) SetupRecovery(friendIDsBytes [][]byte, threshold int) (bool, error) {
+	friendIDs := make([]*types.PttID, len(friendIDsBytes))
+	for i, friendIDBytes := range friendIDsBytes {
+		friendID, err := types.UnmarshalTextPttID(friendIDBytes, false)
+		if err != nil {
+			return false, err
+		}
+		friendIDs[i] = friendID
+	}
+
+	myInfo := b.SPM().(*ServiceProtocolManager).MyInfo
+	pm := myInfo.PM().(*ProtocolManager)
+	if !pm.IsMyNodePermitted(NodePermExportKey, false) {
+		return false, pkgservice.ErrNotPermitted
+	}
+
+	err := pm.SetupRecovery(friendIDs, threshold)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) RequestRecovery(ownerIDBytes []byte) (int, error) {
+	ownerID, err := types.UnmarshalTextPttID(ownerIDBytes, false)
+	if err != nil {
+		return 0, err
+	}
+
+	friendSPM := b.friendBackend.SPM().(*friend.ServiceProtocolManager)
+
+	return friendSPM.RequestRecoveryShares(ownerID)
+}
+
+func (b *Backend) RecoverMe(ownerIDBytes []byte) (bool, error) {
+	ownerID, err := types.UnmarshalTextPttID(ownerIDBytes, false)
+	if err != nil {
+		return false, err
+	}
+
+	myInfo := b.SPM().(*ServiceProtocolManager).MyInfo
+	pm := myInfo.PM().(*ProtocolManager)
+
+	err = pm.RecoverMe(ownerID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 /**********
  * Join Me
  **********/
@@ -173,6 +261,15 @@ func (b *Backend) ShowMeURL() (*pkgservice.BackendJoinURL, error) {
 	return pkgservice.MarshalBackendJoinURL(myID, myNodeID, keyInfo, myUserName.Name, pkgservice.PathJoinMe)
 }
 
+func (b *Backend) ShowMeURLQRCode(format []byte, size int) (*pkgservice.BackendJoinURLQRCode, error) {
+	joinURL, err := b.ShowMeURL()
+	if err != nil {
+		return nil, err
+	}
+
+	return pkgservice.JoinURLToQRCode(joinURL, string(format), size)
+}
+
 func (b *Backend) JoinMe(meURL []byte, myKeyBytes []byte) (*pkgservice.BackendJoinRequest, error) {
 
 	joinRequest, err := pkgservice.ParseBackendJoinURL(meURL, pkgservice.PathJoinMe)
@@ -212,15 +309,66 @@ func (b *Backend) GetMeRequests(entityIDBytes []byte) ([]*pkgservice.BackendJoin
 		return nil, err
 	}
 
-	results := make([]*pkgservice.BackendJoinRequest, len(joinMeRequests))
+	myNodeID := b.myPtt.MyNodeID()
+
+	results := make([]*pkgservice.BackendJoinRequest, len(joinMeRequests), len(joinMeRequests)+1)
 
 	for i, joinRequest := range joinMeRequests {
 		results[i] = pkgservice.JoinRequestToBackendJoinRequest(joinRequest)
+		results[i].SAS = JoinMeSAS(myNodeID, joinRequest.NodeID)
+	}
+
+	// the join-me from the new node waiting for confirmation
+	confirmJoin, err := pm.GetConfirmJoinMe()
+	if err == nil {
+		nodeID := confirmJoin.Peer.GetID()
+		results = append(results, &pkgservice.BackendJoinRequest{
+			CreatorID: confirmJoin.JoinEntity.ID,
+			NodeID:    nodeID,
+			Hash:      confirmJoin.KeyInfo.Hash[:],
+			Name:      confirmJoin.JoinEntity.Name,
+			Status:    pkgservice.JoinStatusWaitConfirmed,
+			SAS:       JoinMeSAS(myNodeID, nodeID),
+		})
 	}
 
 	return results, nil
 }
 
+func (b *Backend) ConfirmJoinMe(nodeIDBytes []byte, sas []byte) (bool, error) {
+	nodeID, err := discover.HexID(string(nodeIDBytes))
+	if err != nil {
+		return false, err
+	}
+
+	myInfo := b.SPM().(*ServiceProtocolManager).MyInfo
+	pm := myInfo.PM().(*ProtocolManager)
+
+	err = pm.ConfirmJoinMe(&nodeID, string(sas))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) RejectJoinMe(nodeIDBytes []byte) (bool, error) {
+	nodeID, err := discover.HexID(string(nodeIDBytes))
+	if err != nil {
+		return false, err
+	}
+
+	myInfo := b.SPM().(*ServiceProtocolManager).MyInfo
+	pm := myInfo.PM().(*ProtocolManager)
+
+	err = pm.RejectJoinMe(&nodeID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (b *Backend) RemoveMeRequests(entityIDBytes []byte, hash []byte) (bool, error) {
 	thePM, err := b.EntityIDToPM(entityIDBytes)
 	if err != nil {
@@ -267,6 +415,15 @@ func (b *Backend) ShowURL() (*pkgservice.BackendJoinURL, error) {
 	return pkgservice.MarshalBackendJoinURL(myID, myNodeID, keyInfo, myUserName.Name, pkgservice.PathJoinFriend)
 }
 
+func (b *Backend) ShowURLQRCode(format []byte, size int) (*pkgservice.BackendJoinURLQRCode, error) {
+	joinURL, err := b.ShowURL()
+	if err != nil {
+		return nil, err
+	}
+
+	return pkgservice.JoinURLToQRCode(joinURL, string(format), size)
+}
+
 func (b *Backend) JoinFriend(friendURL []byte) (*pkgservice.BackendJoinRequest, error) {
 	joinRequest, err := pkgservice.ParseBackendJoinURL(friendURL, pkgservice.PathJoinFriend)
 	if err != nil {
@@ -320,6 +477,168 @@ func (b *Backend) RemoveFriendRequests(entityIDBytes []byte, hash []byte) (bool,
 	return pm.RemoveFriendRequests(hash)
 }
 
+/**********
+ * Blocklist
+ **********/
+
+func (b *Backend) BlockUser(userIDBytes []byte) (bool, error) {
+	userID, err := types.UnmarshalTextPttID(userIDBytes, false)
+	if err != nil {
+		return false, err
+	}
+
+	err = b.myPtt.BlockUser(userID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) UnblockUser(userIDBytes []byte) (bool, error) {
+	userID, err := types.UnmarshalTextPttID(userIDBytes, false)
+	if err != nil {
+		return false, err
+	}
+
+	err = b.myPtt.UnblockUser(userID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) GetBlockedUserList() ([]*pkgservice.BlockedUser, error) {
+	return b.myPtt.GetBlockedUserList()
+}
+
+/**********
+ * Bookmark
+ **********/
+
+func (b *Backend) AddBookmark(boardIDBytes []byte, articleIDBytes []byte, folder []byte) (*BackendBookmark, error) {
+	boardID, err := types.UnmarshalTextPttID(boardIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+
+	articleID, err := types.UnmarshalTextPttID(articleIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = b.contentBackend.GetRawArticle(boardIDBytes, articleIDBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	myInfo := b.SPM().(*ServiceProtocolManager).MyInfo
+	pm := myInfo.PM().(*ProtocolManager)
+
+	bookmark, err := pm.AddBookmark(boardID, articleID, folder)
+	if err != nil {
+		return nil, err
+	}
+
+	return bookmarkToBackendBookmark(bookmark), nil
+}
+
+func (b *Backend) DeleteBookmark(boardIDBytes []byte, articleIDBytes []byte) (bool, error) {
+	boardID, err := types.UnmarshalTextPttID(boardIDBytes, false)
+	if err != nil {
+		return false, err
+	}
+
+	articleID, err := types.UnmarshalTextPttID(articleIDBytes, false)
+	if err != nil {
+		return false, err
+	}
+
+	myInfo := b.SPM().(*ServiceProtocolManager).MyInfo
+	pm := myInfo.PM().(*ProtocolManager)
+
+	_, err = pm.DeleteBookmark(boardID, articleID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+/*
+GetBookmarkList gets the bookmarks in the folder (all the bookmarks if the folder is empty),
+with the titles and the summaries resolved from the boards.
+*/
+func (b *Backend) GetBookmarkList(folder []byte) ([]*BackendBookmark, error) {
+	myInfo := b.SPM().(*ServiceProtocolManager).MyInfo
+
+	bookmarks, err := GetBookmarkList(myInfo.ID, folder)
+	if err != nil {
+		return nil, err
+	}
+
+	backendBookmarks := make([]*BackendBookmark, len(bookmarks))
+	summaryParams := make(map[types.PttID][]*content.BackendArticleSummaryParams)
+	for i, bookmark := range bookmarks {
+		backendBookmark := bookmarkToBackendBookmark(bookmark)
+		backendBookmarks[i] = backendBookmark
+
+		boardIDBytes, err := bookmark.BoardID.MarshalText()
+		if err != nil {
+			continue
+		}
+		articleIDBytes, err := bookmark.ArticleID.MarshalText()
+		if err != nil {
+			continue
+		}
+
+		article, err := b.contentBackend.GetRawArticle(boardIDBytes, articleIDBytes)
+		if err != nil {
+			continue
+		}
+		backendBookmark.Title = article.Title
+
+		blockInfo := article.GetBlockInfo()
+		if blockInfo == nil {
+			continue
+		}
+		contentBlockIDBytes, err := blockInfo.ID.MarshalText()
+		if err != nil {
+			continue
+		}
+
+		summaryParams[*bookmark.BoardID] = append(summaryParams[*bookmark.BoardID], &content.BackendArticleSummaryParams{
+			ArticleID:      string(articleIDBytes),
+			ContentBlockID: string(contentBlockIDBytes),
+		})
+	}
+
+	summaries := make(map[types.PttID]map[string]*content.ArticleBlock)
+	for boardID, params := range summaryParams {
+		boardIDBytes, err := boardID.MarshalText()
+		if err != nil {
+			continue
+		}
+
+		summaries[boardID], err = b.contentBackend.GetArticleSummaryByIDs(boardIDBytes, params)
+		if err != nil {
+			continue
+		}
+	}
+
+	for _, backendBookmark := range backendBookmarks {
+		articleIDBytes, err := backendBookmark.ArticleID.MarshalText()
+		if err != nil {
+			continue
+		}
+
+		backendBookmark.Summary = summaries[*backendBookmark.BoardID][string(articleIDBytes)]
+	}
+
+	return backendBookmarks, nil
+}
+
 /**********
  * JoinBoard
  **********/
@@ -347,6 +666,61 @@ func (b *Backend) JoinBoard(friendURL []byte) (*pkgservice.BackendJoinRequest, e
 	return backendJoinRequest, nil
 }
 
+/*
+JoinPublicBoard joins the public board as a reader with the public join-key.
+The node is looked up from the public-board directory if not provided.
+*/
+func (b *Backend) JoinPublicBoard(entityIDBytes []byte, nodeIDBytes []byte) (*pkgservice.BackendJoinRequest, error) {
+	entityID, err := types.UnmarshalTextPttID(entityIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodeID *discover.NodeID
+	if len(nodeIDBytes) == 0 {
+		nodeID, err = b.myPtt.GetPublicEntityNodeID(entityID)
+	} else {
+		nodeID = &discover.NodeID{}
+		err = nodeID.UnmarshalText(nodeIDBytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if reflect.DeepEqual(b.myPtt.MyNodeID(), nodeID) {
+		return nil, ErrInvalidNode
+	}
+
+	keyInfo, err := pkgservice.NewPublicJoinKeyInfo(entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return nil, err
+	}
+
+	joinRequest := &pkgservice.JoinRequest{
+		CreatorID: entityID,
+		CreateTS:  ts,
+		NodeID:    nodeID,
+		Hash:      keyInfo.Hash,
+		Key:       keyInfo.Key,
+		Status:    pkgservice.JoinStatusPending,
+		Challenge: pkgservice.GenChallenge(),
+	}
+
+	myInfo := b.SPM().(*ServiceProtocolManager).MyInfo
+	pm := myInfo.PM().(*ProtocolManager)
+	err = pm.JoinBoard(joinRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return pkgservice.JoinRequestToBackendJoinRequest(joinRequest), nil
+}
+
 func (b *Backend) GetBoardRequests(entityIDBytes []byte) ([]*pkgservice.BackendJoinRequest, error) {
 
 	thePM, err := b.EntityIDToPM(entityIDBytes)
@@ -435,6 +809,9 @@ func (b *Backend) Revoke(myKey []byte) (bool, error) {
 	myInfo := b.SPM().(*ServiceProtocolManager).MyInfo
 
 	pm := myInfo.PM().(*ProtocolManager)
+	if !pm.IsMyNodePermitted(NodePermManageNodes, false) {
+		return false, pkgservice.ErrNotPermitted
+	}
 
 	err = pm.DeleteMe()
 	if err != nil {
@@ -18,6 +18,7 @@ package me
 
 import (
 	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/content"
 	"github.com/ailabstw/go-pttai/p2p/discover"
 	pkgservice "github.com/ailabstw/go-pttai/service"
 )
@@ -53,3 +54,24 @@ func MarshalBackendMyInfo(m *MyInfo, ptt pkgservice.MyPtt) *BackendMyInfo {
 		NodeID: myNodeID,
 	}
 }
+
+type BackendBookmark struct {
+	ID        *types.PttID
+	CreateTS  types.Timestamp `json:"CT"`
+	BoardID   *types.PttID    `json:"BID"`
+	ArticleID *types.PttID    `json:"AID"`
+	Folder    []byte          `json:"f,omitempty"`
+
+	Title   []byte                `json:"T,omitempty"`
+	Summary *content.ArticleBlock `json:"s,omitempty"`
+}
+
+func bookmarkToBackendBookmark(b *Bookmark) *BackendBookmark {
+	return &BackendBookmark{
+		ID:        b.ID,
+		CreateTS:  b.CreateTS,
+		BoardID:   b.BoardID,
+		ArticleID: b.ArticleID,
+		Folder:    b.Folder,
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package me
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/crypto"
+	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/*
+Bookmark is the article saved for later, synced across my nodes with me-oplogs.
+The id is derived from the board-id and the article-id, so bookmarking the same article on different nodes results in the same bookmark.
+*/
+type Bookmark struct {
+	V         types.Version
+	ID        *types.PttID
+	MyID      *types.PttID    `json:"m"`
+	CreateTS  types.Timestamp `json:"CT"`
+	UpdateTS  types.Timestamp `json:"UT"`
+	BoardID   *types.PttID    `json:"BID"`
+	ArticleID *types.PttID    `json:"AID"`
+	Folder    []byte          `json:"f,omitempty"`
+	Status    types.Status    `json:"S"`
+	LogID     *types.PttID    `json:"l"`
+}
+
+func NewBookmarkID(myID *types.PttID, boardID *types.PttID, articleID *types.PttID) *types.PttID {
+	hash := crypto.Keccak256(myID[:], boardID[:], articleID[:])
+
+	id := &types.PttID{}
+	copy(id[:common.AddressLength], hash)
+	copy(id[common.AddressLength:], myID[:common.AddressLength])
+
+	return id
+}
+
+func (b *Bookmark) Save() error {
+	key, err := b.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+
+	return dbMeCore.Put(key, marshaled)
+}
+
+func (b *Bookmark) Get(myID *types.PttID, id *types.PttID) error {
+	b.MyID = myID
+	b.ID = id
+
+	key, err := b.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	theBytes, err := dbMeCore.Get(key)
+	if err != nil {
+		return err
+	}
+	if len(theBytes) == 0 {
+		return leveldb.ErrNotFound
+	}
+
+	return json.Unmarshal(theBytes, b)
+}
+
+func (b *Bookmark) MarshalKey() ([]byte, error) {
+	return common.Concat([][]byte{DBBookmarkPrefix, b.MyID[:], b.ID[:]})
+}
+
+/*
+GetBookmarkList gets the alive bookmarks of myID, filtered by the folder if the folder is not empty.
+*/
+func GetBookmarkList(myID *types.PttID, folder []byte) ([]*Bookmark, error) {
+	prefix, err := common.Concat([][]byte{DBBookmarkPrefix, myID[:]})
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := dbMeCore.NewIteratorWithPrefix(nil, prefix, pttdb.ListOrderNext)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	bookmarks := make([]*Bookmark, 0)
+	for iter.Next() {
+		bookmark := &Bookmark{}
+		err = json.Unmarshal(iter.Value(), bookmark)
+		if err != nil {
+			continue
+		}
+		if bookmark.Status != types.StatusAlive {
+			continue
+		}
+		if len(folder) != 0 && !bytes.Equal(bookmark.Folder, folder) {
+			continue
+		}
+
+		bookmarks = append(bookmarks, bookmark)
+	}
+
+	return bookmarks, nil
+}
@@ -22,11 +22,13 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/ailabstw/go-pttai/common"
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/crypto"
+	"github.com/ailabstw/go-pttai/crypto/keystore"
 	"github.com/ailabstw/go-pttai/log"
 )
 
@@ -36,6 +38,8 @@ type Config struct {
 	PrivateKey *ecdsa.PrivateKey `toml:"-"`
 	ID         *types.PttID      `toml:"-"` // we also need ID because other services need to know ID, but cannot directly acccess private-key and postfix.
 	Postfix    string
+
+	Passphrase string `toml:"-"` // passphrase of the encrypted keystore, empty as the plain key-files.
 }
 
 func (c *Config) SetMyKey(hex string, file string, postfix string, isSave bool) error {
@@ -49,7 +53,7 @@ func (c *Config) SetMyKey(hex string, file string, postfix string, isSave bool)
 	case file != "" && hex != "":
 		return ErrInvalidPrivateKeyFileHex
 	case file != "":
-		if key, err = crypto.LoadECDSA(file); err != nil {
+		if key, err = keystore.LoadECDSA(file, c.Passphrase); err != nil {
 			return ErrInvalidPrivateKeyFile
 		}
 		c.PrivateKey = key
@@ -142,7 +146,11 @@ func (c *Config) myKey() (*ecdsa.PrivateKey, string, *types.PttID, error) {
 
 	// retrieve key / id from file
 	keyfile := c.ResolvePath(DataDirPrivateKey)
-	key, err := crypto.LoadECDSA(keyfile)
+	key, err := keystore.LoadECDSA(keyfile, c.Passphrase)
+	if err == keystore.ErrLocked || err == keystore.ErrDecrypt {
+		// never overwrite the encrypted key
+		return nil, "", nil, err
+	}
 	postfixBytes, err2 := ioutil.ReadFile(keyfile + ".postfix")
 	if err == nil && err2 == nil {
 		id, err := types.NewPttIDFromKeyPostfix(key, postfixBytes)
@@ -185,7 +193,7 @@ func (c *Config) GetDataPrivateKeyByID(myID *types.PttID) (*ecdsa.PrivateKey, er
 	if err != nil {
 		return nil, err
 	}
-	return crypto.LoadECDSA(keyfile)
+	return keystore.LoadECDSA(keyfile, c.Passphrase)
 }
 
 func (c *Config) ResolvePrivateKeyWithIDPath(myID *types.PttID) (string, error) {
@@ -214,7 +222,7 @@ func (c *Config) ResolvePath(path string) string {
 }
 
 func (c *Config) SaveKey(filename string, key *ecdsa.PrivateKey, postfix string) error {
-	err := crypto.SaveECDSA(filename, key)
+	err := keystore.SaveECDSA(filename, key, c.Passphrase)
 	if err != nil {
 		return err
 	}
@@ -229,7 +237,7 @@ func (c *Config) SaveKey(filename string, key *ecdsa.PrivateKey, postfix string)
 }
 
 func (c *Config) LoadKey(filename string) (*ecdsa.PrivateKey, *types.PttID, error) {
-	key, err := crypto.LoadECDSA(filename)
+	key, err := keystore.LoadECDSA(filename, c.Passphrase)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -275,3 +283,101 @@ func (c *Config) RevokeKey() error {
 
 	return os.Remove(keyfile)
 }
+
+/**********
+ * Keystore
+ **********/
+
+/*
+KeyFiles returns the key-files of me (the current one, the ones with id and the db-key).
+*/
+func (c *Config) KeyFiles() ([]string, error) {
+	if c.DataDir == "" {
+		return nil, nil
+	}
+
+	pattern := c.ResolvePath(DataDirPrivateKey + "*")
+	filenames, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	keyFiles := make([]string, 0, len(filenames)+1)
+	for _, filename := range filenames {
+		switch {
+		case strings.HasSuffix(filename, ".postfix"):
+		case strings.HasSuffix(filename, ".deleted"):
+		case strings.HasSuffix(filename, ".tmp"):
+		default:
+			keyFiles = append(keyFiles, filename)
+		}
+	}
+
+	dbKeyFile := c.ResolvePath(DataDirDBKey)
+	if _, err := os.Stat(dbKeyFile); err == nil {
+		keyFiles = append(keyFiles, dbKeyFile)
+	}
+
+	return keyFiles, nil
+}
+
+/*
+DBKey returns the key to encrypt the keys stored in the db (KeyInfo.KeyBytes).
+The db-key is stored in the keystore with my key, and is created if not exists.
+*/
+func (c *Config) DBKey() ([]byte, error) {
+	keyfile := c.ResolvePath(DataDirDBKey)
+
+	key, err := keystore.LoadECDSA(keyfile, c.Passphrase)
+	if os.IsNotExist(err) {
+		key, err = crypto.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+
+		err = os.MkdirAll(c.DataDir, 0700)
+		if err != nil {
+			return nil, err
+		}
+
+		err = keystore.SaveECDSA(keyfile, key, c.Passphrase)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	keyBytes := crypto.FromECDSA(key)
+	defer common.Bzero(keyBytes)
+
+	return crypto.Keccak256(keyBytes), nil
+}
+
+/*
+IsLocked checks whether my key-file is encrypted and not able to be decrypted with the current passphrase.
+*/
+func (c *Config) IsLocked() bool {
+	keyfile := c.ResolvePath(DataDirPrivateKey)
+	if !keystore.IsEncryptedFile(keyfile) {
+		return false
+	}
+
+	_, err := keystore.LoadECDSA(keyfile, c.Passphrase)
+	return err != nil
+}
+
+/*
+Unlock validates the passphrase with my key-file and sets the passphrase.
+*/
+func (c *Config) Unlock(passphrase string) error {
+	keyfile := c.ResolvePath(DataDirPrivateKey)
+	if keystore.IsEncryptedFile(keyfile) {
+		_, err := keystore.LoadECDSA(keyfile, passphrase)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.Passphrase = passphrase
+
+	return nil
+}
@@ -31,10 +31,14 @@ var (
 
 	ErrAlreadyMyNode = errors.New("already my node")
 
+	ErrInvalidSAS = errors.New("invalid sas")
+
 	ErrInvalidEntry     = errors.New("invalid raft entry")
 	ErrInvalidRaftIndex = errors.New("invalid raft index")
 
 	ErrUnableToBeLead = errors.New("unable to be lead")
 
 	ErrWithLead = errors.New("with lead")
+
+	ErrNotEnoughRecoveryShares = errors.New("not enough recovery shares")
 )
@@ -20,7 +20,9 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/node"
+	"github.com/ailabstw/go-pttai/p2p/discover"
 	"github.com/ailabstw/go-pttai/pttdb"
 	pkgservice "github.com/ailabstw/go-pttai/service"
 )
@@ -35,6 +37,7 @@ var (
 // defaults
 var (
 	DataDirPrivateKey = "mykey"
+	DataDirDBKey      = "dbkey"
 
 	DefaultTitle = []byte("")
 )
@@ -80,6 +83,9 @@ const (
 	// sync-friend
 	InternalSyncFriendMsg
 	InternalSyncFriendAckMsg
+
+	// raft-snapshot
+	SendRaftSnapshotMsg
 )
 
 // db
@@ -92,6 +98,10 @@ var (
 
 	DBMyNodePrefix = []byte(".mndb")
 
+	DBBookmarkPrefix = []byte(".bkdb")
+
+	DBSyncLogInfoPrefix = []byte(".sldb")
+
 	DBRaftPrefix                    = []byte(".rfdb")
 	dbRaft       *pttdb.LDBDatabase = nil
 
@@ -111,6 +121,7 @@ var (
 	DBKeyRaftSnapshotIndex = []byte(".rfsi")
 	DBKeyRaftConfState     = []byte(".rfcs")
 	DBKeyRaftLead          = []byte(".rfld")
+	DBKeyRaftSignedIndex   = []byte(".rfsg")
 )
 
 // max-masters
@@ -184,6 +195,9 @@ const (
 	RaftMaxInflightMsgs = 16
 
 	NRequestRaftLead = 10
+
+	RaftSnapshotCount          uint64 = 100
+	RaftSnapshotCatchUpEntries uint64 = 50
 )
 
 // weight
@@ -193,6 +207,36 @@ const (
 	WeightMobile  = 2
 )
 
+// raft-ctx: node-id, from-id, restrict, and the signature of the from-node
+const (
+	SizeRaftCtx       = discover.SizeNodeID + types.SizePttID + 1
+	SizeRaftCtxSig    = 65
+	SizeSignedRaftCtx = SizeRaftCtx + SizeRaftCtxSig
+)
+
+// node-permission
+const (
+	NodePermPost NodePermission = 1 << iota
+	NodePermManageNodes
+	NodePermExportKey
+
+	NodePermAll = NodePermPost | NodePermManageNodes | NodePermExportKey
+)
+
+// device-class
+const (
+	NodeClassFull     = NodePermAll
+	NodeClassPoster   = NodePermPost
+	NodeClassReadOnly = NodePermission(0)
+)
+
+// join-me sas
+
+const (
+	JoinMeSASDigits         = 6
+	JoinMeSASModulus uint32 = 1000000
+)
+
 // init-me-info
 
 const (
@@ -16,7 +16,14 @@
 
 package me
 
-import "testing"
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
 
 const ()
 
@@ -27,3 +34,54 @@ func setupTest(t *testing.T) {
 
 func teardownTest(t *testing.T) {
 }
+
+/*
+tNewMePM creates the protocol-manager of my-info with the me-db in a temp-dir, without ptt.
+*/
+func tNewMePM(t *testing.T) (*ProtocolManager, func()) {
+	dir, err := ioutil.TempDir("", "me-test")
+	if err != nil {
+		t.Fatalf("TempDir() error = %v", err)
+	}
+
+	err = InitMe(dir)
+	if err != nil {
+		t.Fatalf("InitMe() error = %v", err)
+	}
+
+	teardown := func() {
+		TeardownMe()
+		os.RemoveAll(dir)
+	}
+
+	myID, _ := types.NewPttID()
+	ts, _ := types.GetTimestamp()
+	dbLock, _ := types.NewLockMap(0)
+
+	myInfo := &MyInfo{
+		BaseEntity: pkgservice.NewBaseEntity(myID, ts, myID, types.StatusAlive, dbMe, dbLock),
+		UpdateTS:   ts,
+	}
+
+	pm := &ProtocolManager{
+		MyNodes:             make(map[uint64]*MyNode),
+		MyNodeByNodeSignIDs: make(map[types.PttID]*MyNode),
+	}
+
+	pm.BaseProtocolManager, err = pkgservice.NewBaseProtocolManager(
+		nil, 0, 0, 0, 0, MaxMasters, nil,
+		nil, nil, nil, nil,
+		nil, nil,
+		nil, nil,
+		nil, nil, nil, nil, nil,
+		nil,
+		nil, nil,
+		myInfo, dbMe,
+	)
+	if err != nil {
+		teardown()
+		t.Fatalf("NewBaseProtocolManager() error = %v", err)
+	}
+
+	return pm, teardown
+}
@@ -29,10 +29,11 @@ const (
 )
 
 type MasterOpAddMaster struct {
-	ID      *discover.NodeID
-	From    *types.PttID               `json:"f"`
-	Masters map[discover.NodeID]uint32 `json:"M"`
-	Weight  uint32                     `json:"W"`
+	ID       *discover.NodeID
+	From     *types.PttID               `json:"f"`
+	Masters  map[discover.NodeID]uint32 `json:"M"`
+	Weight   uint32                     `json:"W"`
+	Restrict NodePermission             `json:"r,omitempty"`
 }
 
 type MasterOpRevokeMaster struct {
@@ -37,6 +37,12 @@ const (
 	MeOpTypeMigrateMe
 	MeOpTypeDeleteMe
 
+	MeOpTypeAddBookmark
+	MeOpTypeDeleteBookmark
+
+	MeOpTypeSetSeen
+	MeOpTypeSetLocale
+
 	NMeOpType
 )
 
@@ -66,3 +72,19 @@ type MeOpMigrateMe struct {
 }
 
 type MeOpDeleteMe struct{}
+
+type MeOpBookmark struct {
+	BoardID   *types.PttID `json:"BID"`
+	ArticleID *types.PttID `json:"AID"`
+	Folder    []byte       `json:"f,omitempty"`
+}
+
+type MeOpSetSeen struct {
+	SeenType pkgservice.SeenType `json:"t"`
+	EntityID *types.PttID        `json:"EID"`
+	ObjID    *types.PttID        `json:"OID,omitempty"`
+}
+
+type MeOpSetLocale struct {
+	Locale pkgservice.Locale `json:"L"`
+}
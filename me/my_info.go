@@ -316,6 +316,14 @@ func (m *MyInfo) IsValidInternalOplog(signInfos []*pkgservice.SignInfo) (*types.
 	return m.PM().(*ProtocolManager).IsValidInternalOplog(signInfos)
 }
 
+func (m *MyInfo) IsPostableInternalOplog(signInfos []*pkgservice.SignInfo) bool {
+	return m.PM().(*ProtocolManager).IsPostableInternalOplog(signInfos)
+}
+
+func (m *MyInfo) IsPostable() bool {
+	return m.PM().(*ProtocolManager).IsMyNodePermitted(NodePermPost, false)
+}
+
 func (m *MyInfo) MyPM() pkgservice.MyProtocolManager {
 	return m.PM().(*ProtocolManager)
 }
@@ -129,6 +129,18 @@ func (m *MyInfo) NodeSignKey() *pkgservice.KeyInfo {
 	return m.nodeSignKeyInfo
 }
 
+/*
+RenewSignKeys renews both the sign-key and the node-sign-key.
+*/
+func (m *MyInfo) RenewSignKeys() error {
+	err := m.CreateSignKeyInfo()
+	if err != nil {
+		return err
+	}
+
+	return m.CreateNodeSignKeyInfo()
+}
+
 func (m *MyInfo) GetNodeKey() *ecdsa.PrivateKey {
 	return m.nodeKey
 }
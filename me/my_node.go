@@ -54,6 +54,9 @@ type MyNode struct {
 	NodeType pkgservice.NodeType `json:"NT"`
 	Weight   uint32              `json:"W"`
 
+	// Restrict is the denied permissions, so the nodes created before the permissions are full-power.
+	Restrict NodePermission `json:"RP,omitempty"`
+
 	LogID *types.PttID `json:"pl,omitempty"`
 
 	RaftID uint64 `json:"R"`
@@ -170,6 +173,10 @@ func (m *MyNode) Unmarshal(theBytes []byte) error {
 	return nil
 }
 
+func (m *MyNode) Permission() NodePermission {
+	return NodePermAll &^ m.Restrict
+}
+
 func (m *MyNode) DeleteRawKey(key []byte) error {
 	err := dbMyNodes.Delete(key)
 	if err != nil {
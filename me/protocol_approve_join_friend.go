@@ -32,6 +32,10 @@ type ApproveJoinFriend struct {
 
 func (pm *ProtocolManager) ApproveJoinFriend(joinEntity *pkgservice.JoinEntity, keyInfo *pkgservice.KeyInfo, peer *pkgservice.PttPeer) (*pkgservice.KeyInfo, interface{}, error) {
 
+	if pm.Ptt().IsBlockedUser(joinEntity.ID) {
+		return nil, nil, pkgservice.ErrBlocked
+	}
+
 	friendSPM := pm.Entity().Service().(*Backend).friendBackend.SPM().(*friend.ServiceProtocolManager)
 
 	// Reset friend
@@ -44,6 +44,10 @@ func (pm *ProtocolManager) ApproveJoinMe(joinEntity *pkgservice.JoinEntity, keyI
 	pm.lockMyNodes.Lock()
 	defer pm.lockMyNodes.Unlock()
 
+	if !pm.IsMyNodePermitted(NodePermManageNodes, true) {
+		return nil, nil, pkgservice.ErrNotPermitted
+	}
+
 	// 1. add my node
 	peerID := peer.GetID()
 	raftID, err := peerID.ToRaftID()
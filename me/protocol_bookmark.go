@@ -0,0 +1,154 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package me
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func (pm *ProtocolManager) AddBookmark(boardID *types.PttID, articleID *types.PttID, folder []byte) (*Bookmark, error) {
+	opData := &MeOpBookmark{
+		BoardID:   boardID,
+		ArticleID: articleID,
+		Folder:    folder,
+	}
+
+	return pm.createBookmarkOplog(MeOpTypeAddBookmark, opData)
+}
+
+func (pm *ProtocolManager) DeleteBookmark(boardID *types.PttID, articleID *types.PttID) (*Bookmark, error) {
+	opData := &MeOpBookmark{
+		BoardID:   boardID,
+		ArticleID: articleID,
+	}
+
+	return pm.createBookmarkOplog(MeOpTypeDeleteBookmark, opData)
+}
+
+func (pm *ProtocolManager) createBookmarkOplog(op pkgservice.OpType, opData *MeOpBookmark) (*Bookmark, error) {
+	myID := pm.Entity().GetID()
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarkID := NewBookmarkID(myID, opData.BoardID, opData.ArticleID)
+
+	oplog, err := pm.CreateMeOplog(bookmarkID, ts, op, opData)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmark, err := pm.applyBookmarkLog(oplog.BaseOplog, opData)
+	if err != nil {
+		return nil, err
+	}
+
+	oplog.IsSync = true
+
+	err = oplog.Save(false, pm.meOplogMerkle)
+	if err != nil {
+		return nil, err
+	}
+
+	pm.BroadcastMeOplog(oplog)
+
+	return bookmark, nil
+}
+
+/*
+applyBookmarkLog applies the bookmark-oplog to the bookmark, the newer oplog wins.
+*/
+func (pm *ProtocolManager) applyBookmarkLog(oplog *pkgservice.BaseOplog, opData *MeOpBookmark) (*Bookmark, error) {
+	myID := pm.Entity().GetID()
+	if !reflect.DeepEqual(oplog.ObjID, NewBookmarkID(myID, opData.BoardID, opData.ArticleID)) {
+		return nil, pkgservice.ErrInvalidData
+	}
+
+	pm.lockBookmark.Lock()
+	defer pm.lockBookmark.Unlock()
+
+	bookmark := &Bookmark{}
+	err := bookmark.Get(myID, oplog.ObjID)
+	switch {
+	case err != nil:
+		bookmark = &Bookmark{
+			V:         types.CurrentVersion,
+			ID:        oplog.ObjID,
+			MyID:      myID,
+			CreateTS:  oplog.UpdateTS,
+			BoardID:   opData.BoardID,
+			ArticleID: opData.ArticleID,
+		}
+	case oplog.UpdateTS.IsLess(bookmark.UpdateTS):
+		return bookmark, nil
+	}
+
+	bookmark.UpdateTS = oplog.UpdateTS
+	bookmark.LogID = oplog.ID
+
+	switch oplog.Op {
+	case MeOpTypeAddBookmark:
+		if bookmark.Status != types.StatusAlive {
+			bookmark.CreateTS = oplog.UpdateTS
+		}
+		bookmark.Status = types.StatusAlive
+		bookmark.Folder = opData.Folder
+	case MeOpTypeDeleteBookmark:
+		bookmark.Status = types.StatusDeleted
+	}
+
+	err = bookmark.Save()
+	if err != nil {
+		return nil, err
+	}
+
+	return bookmark, nil
+}
+
+func (pm *ProtocolManager) handleBookmarkLog(oplog *pkgservice.BaseOplog, info *ProcessMeInfo) ([]*pkgservice.BaseOplog, error) {
+	opData := &MeOpBookmark{}
+	err := oplog.GetData(opData)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = pm.applyBookmarkLog(oplog, opData)
+	if err != nil {
+		log.Warn("handleBookmarkLog: unable to apply", "e", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (pm *ProtocolManager) setNewestBookmarkLog(oplog *pkgservice.BaseOplog) (types.Bool, error) {
+	myID := pm.Entity().GetID()
+
+	bookmark := &Bookmark{}
+	err := bookmark.Get(myID, oplog.ObjID)
+	if err != nil {
+		return true, err
+	}
+
+	return !types.Bool(reflect.DeepEqual(oplog.ID, bookmark.LogID)), nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package me
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/crypto"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/p2p/discover"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+JoinMeSAS derives the short-authentication-string from both node-ids (the node public-keys).
+
+The SAS is the same on both sides, so the user can compare
+the code on the new node with the code on the existing node before confirming the join.
+*/
+func JoinMeSAS(nodeID *discover.NodeID, otherNodeID *discover.NodeID) string {
+	first, second := nodeID, otherNodeID
+	if bytes.Compare(first[:], second[:]) > 0 {
+		first, second = second, first
+	}
+
+	hash := crypto.Keccak256(first[:], second[:])
+	code := binary.BigEndian.Uint32(hash[:4]) % JoinMeSASModulus
+
+	return fmt.Sprintf("%0*d", JoinMeSASDigits, code)
+}
+
+/*
+GetConfirmJoinMe gets the join-me waiting for confirmation (existing node).
+*/
+func (pm *ProtocolManager) GetConfirmJoinMe() (*pkgservice.ConfirmJoin, error) {
+	myID := pm.Entity().GetID()
+	confirmKey := pkgservice.GetConfirmKey(myID, myID)
+
+	confirmJoin, err := pm.myPtt.GetConfirmJoin(confirmKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if confirmJoin.JoinType != pkgservice.JoinTypeMe {
+		return nil, pkgservice.ErrInvalidData
+	}
+
+	return confirmJoin, nil
+}
+
+/*
+ConfirmJoinMe confirms the join-me from the node with the SAS code shown on the new node.
+The raft conf-change adding the node is proposed only after the confirmation (in ApproveJoinMe).
+*/
+func (pm *ProtocolManager) ConfirmJoinMe(nodeID *discover.NodeID, sas string) error {
+	confirmJoin, err := pm.GetConfirmJoinMe()
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(confirmJoin.Peer.GetID(), nodeID) {
+		return ErrInvalidNode
+	}
+
+	if sas != JoinMeSAS(pm.myPtt.MyNodeID(), nodeID) {
+		log.Warn("ConfirmJoinMe: invalid SAS", "nodeID", nodeID)
+		return ErrInvalidSAS
+	}
+
+	myID := pm.Entity().GetID()
+	return pm.myPtt.ApproveJoin(pkgservice.GetConfirmKey(myID, myID))
+}
+
+/*
+RejectJoinMe rejects the join-me from the node.
+*/
+func (pm *ProtocolManager) RejectJoinMe(nodeID *discover.NodeID) error {
+	confirmJoin, err := pm.GetConfirmJoinMe()
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(confirmJoin.Peer.GetID(), nodeID) {
+		return ErrInvalidNode
+	}
+
+	myID := pm.Entity().GetID()
+	return pm.myPtt.RemoveConfirmJoin(pkgservice.GetConfirmKey(myID, myID))
+}
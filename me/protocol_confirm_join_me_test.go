@@ -0,0 +1,47 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package me
+
+import (
+	"testing"
+
+	"github.com/ailabstw/go-pttai/p2p/discover"
+)
+
+func TestJoinMeSAS(t *testing.T) {
+	nodeID := &discover.NodeID{1, 2, 3}
+	otherNodeID := &discover.NodeID{4, 5, 6}
+	anotherNodeID := &discover.NodeID{7, 8, 9}
+
+	sas := JoinMeSAS(nodeID, otherNodeID)
+	if len(sas) != JoinMeSASDigits {
+		t.Errorf("JoinMeSAS() = %v, want %v digits", sas, JoinMeSASDigits)
+	}
+	for _, c := range sas {
+		if c < '0' || c > '9' {
+			t.Errorf("JoinMeSAS() = %v, want numeric", sas)
+		}
+	}
+
+	if got := JoinMeSAS(otherNodeID, nodeID); got != sas {
+		t.Errorf("JoinMeSAS() = %v, want symmetric %v", got, sas)
+	}
+
+	if got := JoinMeSAS(nodeID, anotherNodeID); got == sas {
+		t.Errorf("JoinMeSAS() = %v, want different from %v", got, sas)
+	}
+}
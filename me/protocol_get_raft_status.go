@@ -21,6 +21,16 @@ func (pm *ProtocolManager) GetRaftStatus() (*RaftStatus, error) {
 		return nil, ErrInvalidMe
 	}
 
+	snap, err := pm.rs.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	firstIdx, err := pm.rs.FirstIndex()
+	if err != nil {
+		return nil, err
+	}
+
 	rs := &RaftStatus{
 		Lead:          pm.raftLead,
 		LastIndex:     pm.raftLastIndex,
@@ -29,6 +39,8 @@ func (pm *ProtocolManager) GetRaftStatus() (*RaftStatus, error) {
 		AppliedInex:   pm.raftAppliedIndex,
 		HardState:     pm.rs.hardState,
 		RSLastIndex:   pm.rs.lastIdx,
+		RSFirstIndex:  firstIdx,
+		SnapshotTerm:  snap.Metadata.Term,
 	}
 	return rs, nil
 }
@@ -27,6 +27,7 @@ type ProcessMeInfo struct {
 	MetaInfo     map[types.PttID]*pkgservice.BaseOplog
 	BoardInfo    map[types.PttID]*pkgservice.BaseOplog
 	FriendInfo   map[types.PttID]*pkgservice.BaseOplog
+	SyncLogInfo  map[types.PttID]*pkgservice.BaseOplog
 }
 
 func NewProcessMeInfo() *ProcessMeInfo {
@@ -35,6 +36,7 @@ func NewProcessMeInfo() *ProcessMeInfo {
 		MetaInfo:     make(map[types.PttID]*pkgservice.BaseOplog),
 		BoardInfo:    make(map[types.PttID]*pkgservice.BaseOplog),
 		FriendInfo:   make(map[types.PttID]*pkgservice.BaseOplog),
+		SyncLogInfo:  make(map[types.PttID]*pkgservice.BaseOplog),
 	}
 }
 
@@ -65,6 +67,16 @@ func (pm *ProtocolManager) processMeLog(oplog *pkgservice.BaseOplog, processInfo
 	case MeOpTypeJoinFriend:
 		origLogs, err = pm.handleFriendLog(oplog, info)
 
+	case MeOpTypeAddBookmark:
+		origLogs, err = pm.handleBookmarkLog(oplog, info)
+	case MeOpTypeDeleteBookmark:
+		origLogs, err = pm.handleBookmarkLog(oplog, info)
+
+	case MeOpTypeSetSeen:
+		origLogs, err = pm.handleSeenLog(oplog, info)
+	case MeOpTypeSetLocale:
+		origLogs, err = pm.handleLocaleLog(oplog, info)
+
 	case MeOpTypeSetNodeName:
 	}
 	return
@@ -113,6 +125,11 @@ func (pm *ProtocolManager) postprocessMeOplogs(processInfo pkgservice.ProcessInf
 		pm.InternalSyncFriend(oplog, peer)
 	}
 
+	// sync-log: compact the superseded sync-oplogs after saved.
+	for _, oplog := range info.SyncLogInfo {
+		pm.deleteSyncOplog(oplog.ID)
+	}
+
 	// delete-me
 
 	log.Debug("postprocessMeOplogs: to check delete-me", "isPending", isPending, "DeleteMeInfo", info.DeleteMeInfo)
@@ -149,6 +166,16 @@ func (pm *ProtocolManager) SetNewestMeOplog(oplog *pkgservice.BaseOplog) (err er
 	case MeOpTypeJoinFriend:
 		isNewer, err = pm.setNewestFriendLog(oplog)
 
+	case MeOpTypeAddBookmark:
+		isNewer, err = pm.setNewestBookmarkLog(oplog)
+	case MeOpTypeDeleteBookmark:
+		isNewer, err = pm.setNewestBookmarkLog(oplog)
+
+	case MeOpTypeSetSeen:
+		isNewer, err = pm.setNewestSyncLog(oplog)
+	case MeOpTypeSetLocale:
+		isNewer, err = pm.setNewestSyncLog(oplog)
+
 	case MeOpTypeSetNodeName:
 	}
 
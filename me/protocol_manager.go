@@ -88,11 +88,19 @@ type ProtocolManager struct {
 	lockRaftAppliedIndex sync.RWMutex
 	raftAppliedIndex     uint64
 
+	lockRaftSignedIndex sync.RWMutex
+
 	raftNode        raft.Node
 	rs              *RaftStorage
 	isStartRaftNode bool
 
 	lockRaft sync.Mutex
+
+	// bookmark
+	lockBookmark sync.Mutex
+
+	// sync-log
+	lockSyncLog sync.Mutex
 }
 
 func NewProtocolManager(myInfo *MyInfo, ptt pkgservice.MyPtt) (*ProtocolManager, error) {
@@ -38,6 +38,8 @@ func (pm *ProtocolManager) HandleMessage(op pkgservice.OpType, dataBytes []byte,
 	// raft
 	case SendRaftMsgsMsg:
 		return pm.HandleSendRaftMsgs(dataBytes, peer)
+	case SendRaftSnapshotMsg:
+		return pm.HandleSendRaftSnapshot(dataBytes, peer)
 	}
 
 	fitPeerType := pm.GetPeerType(peer)
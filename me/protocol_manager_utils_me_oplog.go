@@ -44,6 +44,11 @@ func (pm *ProtocolManager) InternalSignMyOplog(oplog *pkgservice.BaseOplog) (boo
 		if idx >= 0 && idx < lenMasterSigns && reflect.DeepEqual(masterSigns[idx].ID, myID) {
 			return false, nil
 		}
+
+		// not co-signing the oplogs from the nodes without NodePermPost
+		if !pm.IsPostableInternalOplog(masterSigns) {
+			return false, nil
+		}
 	}
 
 	err := myEntity.MyMasterSign(oplog)
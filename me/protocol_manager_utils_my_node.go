@@ -41,6 +41,45 @@ func (pm *ProtocolManager) RUnlockMyNodes() {
 	pm.lockMyNodes.RUnlock()
 }
 
+/*
+IsMyNodePermitted checks the permission of my node.
+My node not in my-nodes yet (creating me) is not restricted.
+*/
+func (pm *ProtocolManager) IsMyNodePermitted(perm NodePermission, isLocked bool) bool {
+	if !isLocked {
+		pm.RLockMyNodes()
+		defer pm.RUnlockMyNodes()
+	}
+
+	myNode := pm.MyNodes[pm.myPtt.MyRaftID()]
+	if myNode == nil {
+		return true
+	}
+
+	return myNode.Permission().IsPermitted(perm)
+}
+
+/*
+isWithOtherNodeManager checks whether there is still a node able to manage the nodes
+after setting the restrict of the node. Expected lockMyNodes.
+*/
+func (pm *ProtocolManager) isWithOtherNodeManager(raftID uint64, restrict NodePermission) bool {
+	if restrict&NodePermManageNodes == 0 {
+		return true
+	}
+
+	for eachRaftID, node := range pm.MyNodes {
+		if eachRaftID == raftID || node.Status != types.StatusAlive {
+			continue
+		}
+		if node.Permission().IsPermitted(NodePermManageNodes) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (pm *ProtocolManager) GetMyNodeList(isLocked bool) []*MyNode {
 	if !isLocked {
 		pm.RLockMyNodes()
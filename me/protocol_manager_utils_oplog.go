@@ -21,11 +21,16 @@ import (
 	pkgservice "github.com/ailabstw/go-pttai/service"
 )
 
+/*
+IsValidInternalOplog checks whether the signs reach the quorum.
+The oplog is valid only if it is signed by some node with NodePermPost.
+*/
 func (pm *ProtocolManager) IsValidInternalOplog(signInfos []*pkgservice.SignInfo) (*types.PttID, uint32, bool) {
 	pm.lockMyNodes.RLock()
 	defer pm.lockMyNodes.RUnlock()
 
 	weight := uint32(0)
+	isPostable := false
 	var node *MyNode
 	for _, signInfo := range signInfos {
 		node = pm.MyNodeByNodeSignIDs[*signInfo.ID]
@@ -33,16 +38,41 @@ func (pm *ProtocolManager) IsValidInternalOplog(signInfos []*pkgservice.SignInfo
 			continue
 		}
 		weight += node.Weight
+		if node.Permission().IsPermitted(NodePermPost) {
+			isPostable = true
+		}
 	}
 
 	masterOplogID := pm.GetNewestMasterLogID()
 
-	isValid := weight >= pm.Quorum()
+	isValid := isPostable && weight >= pm.Quorum()
 	if !isValid {
 		return nil, 0, false
 	}
 
-	return masterOplogID, weight, weight >= pm.Quorum()
+	return masterOplogID, weight, true
+}
+
+/*
+IsPostableInternalOplog checks whether the oplog is signed by some node with NodePermPost.
+The oplogs originated from the nodes without NodePermPost are not co-signed.
+*/
+func (pm *ProtocolManager) IsPostableInternalOplog(signInfos []*pkgservice.SignInfo) bool {
+	pm.lockMyNodes.RLock()
+	defer pm.lockMyNodes.RUnlock()
+
+	var node *MyNode
+	for _, signInfo := range signInfos {
+		node = pm.MyNodeByNodeSignIDs[*signInfo.ID]
+		if node == nil {
+			continue
+		}
+		if node.Permission().IsPermitted(NodePermPost) {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (pm *ProtocolManager) Quorum() uint32 {
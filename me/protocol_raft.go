@@ -26,6 +26,7 @@ import (
 	"github.com/ailabstw/go-pttai/log"
 	"github.com/ailabstw/go-pttai/raft"
 	pb "github.com/ailabstw/go-pttai/raft/raftpb"
+	"github.com/syndtr/goleveldb/leveldb"
 )
 
 func (pm *ProtocolManager) StartRaft(peers []raft.Peer, isNew bool) error {
@@ -279,6 +280,67 @@ func (pm *ProtocolManager) LoadRaftAppliedIndex(isLocked bool) error {
 
 }
 
+// raft-signed-index
+
+/*
+setRaftSignedIndex sets the raft-signed-index if not set yet or idx is earlier (the raft-log replayed.)
+*/
+func (pm *ProtocolManager) setRaftSignedIndex(idx uint64) error {
+	pm.lockRaftSignedIndex.Lock()
+	defer pm.lockRaftSignedIndex.Unlock()
+
+	signedIndex, err := pm.getRaftSignedIndex()
+	if err != nil {
+		return err
+	}
+	if signedIndex != 0 && signedIndex <= idx {
+		return nil
+	}
+
+	key, err := pm.MarshalRaftSignedIndexKey()
+	if err != nil {
+		return err
+	}
+
+	val := make([]byte, 8)
+	binary.BigEndian.PutUint64(val, idx)
+
+	return dbMeCore.Put(key, val)
+}
+
+func (pm *ProtocolManager) MarshalRaftSignedIndexKey() ([]byte, error) {
+	myID := pm.Entity().(*MyInfo).ID
+	return common.Concat([][]byte{DBKeyRaftSignedIndex, myID[:]})
+}
+
+/*
+GetRaftSignedIndex returns the index of the first signed conf-change, 0 if not applied yet.
+*/
+func (pm *ProtocolManager) GetRaftSignedIndex() uint64 {
+	pm.lockRaftSignedIndex.RLock()
+	defer pm.lockRaftSignedIndex.RUnlock()
+
+	signedIndex, _ := pm.getRaftSignedIndex()
+	return signedIndex
+}
+
+func (pm *ProtocolManager) getRaftSignedIndex() (uint64, error) {
+	key, err := pm.MarshalRaftSignedIndexKey()
+	if err != nil {
+		return 0, err
+	}
+
+	val, err := dbMeCore.Get(key)
+	if err == leveldb.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(val), nil
+}
+
 // raft-snapshot-index
 
 func (pm *ProtocolManager) SetRaftSnapshotIndex(idx uint64, isLocked bool) error {
@@ -547,6 +609,16 @@ func (pm *ProtocolManager) CleanRaftStorage(isLocked bool) error {
 		return err
 	}
 
+	// signed-index
+	key, err = pm.MarshalRaftSignedIndexKey()
+	if err != nil {
+		return err
+	}
+	err = dbMeCore.Delete(key)
+	if err != nil {
+		return err
+	}
+
 	// raft-storage
 	myID := pm.Entity().GetID()
 	err = CleanRaftStorage(myID, pm.rs, false)
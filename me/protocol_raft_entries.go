@@ -18,11 +18,14 @@ package me
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"reflect"
 
 	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/crypto"
 	"github.com/ailabstw/go-pttai/log"
 	"github.com/ailabstw/go-pttai/p2p/discover"
+	"github.com/ailabstw/go-pttai/raft"
 	pb "github.com/ailabstw/go-pttai/raft/raftpb"
 	pkgservice "github.com/ailabstw/go-pttai/service"
 )
@@ -46,13 +49,61 @@ func (pm *ProtocolManager) raftEntriesToApply(ents []pb.Entry) ([]pb.Entry, erro
 	return newEnts, nil
 }
 
+/*
+ProposeRaftAddNode proposes adding the node or updating the weight of the node.
+The restrict of the existing node is kept. Expected lockMyNodes.
+*/
 func (pm *ProtocolManager) ProposeRaftAddNode(nodeID *discover.NodeID, weight uint32) error {
 	raftID, err := nodeID.ToRaftID()
 	if err != nil {
 		return err
 	}
 
-	ctx := pm.proposeRaftCtx(nodeID)
+	var restrict NodePermission
+	myNode, ok := pm.MyNodes[raftID]
+	if ok {
+		restrict = myNode.Restrict
+	}
+
+	return pm.proposeRaftAddNodeCore(nodeID, raftID, weight, restrict)
+}
+
+/*
+ProposeRaftSetNodePermission proposes changing the permission of the node with the same weight.
+*/
+func (pm *ProtocolManager) ProposeRaftSetNodePermission(nodeID *discover.NodeID, perm NodePermission) error {
+	raftID, err := nodeID.ToRaftID()
+	if err != nil {
+		return err
+	}
+
+	restrict := NodePermAll &^ perm
+
+	pm.lockMyNodes.RLock()
+	myNode, ok := pm.MyNodes[raftID]
+	isAlive := ok && myNode.Status == types.StatusAlive
+	var weight uint32
+	if isAlive {
+		weight = myNode.Weight
+	}
+	isPermitted := pm.IsMyNodePermitted(NodePermManageNodes, true) && pm.isWithOtherNodeManager(raftID, restrict)
+	pm.lockMyNodes.RUnlock()
+
+	if !isAlive {
+		return ErrInvalidNode
+	}
+	if !isPermitted {
+		return pkgservice.ErrNotPermitted
+	}
+
+	return pm.proposeRaftAddNodeCore(nodeID, raftID, weight, restrict)
+}
+
+func (pm *ProtocolManager) proposeRaftAddNodeCore(nodeID *discover.NodeID, raftID uint64, weight uint32, restrict NodePermission) error {
+	ctx, err := pm.proposeRaftCtx(nodeID, restrict)
+	if err != nil {
+		return err
+	}
 
 	cc := pb.ConfChange{
 		Type:    pb.ConfChangeAddNode,
@@ -66,13 +117,85 @@ func (pm *ProtocolManager) ProposeRaftAddNode(nodeID *discover.NodeID, weight ui
 	return nil
 }
 
-func (pm *ProtocolManager) proposeRaftCtx(nodeID *discover.NodeID) []byte {
-	ctx := make([]byte, discover.SizeNodeID+types.SizePttID)
+/*
+proposeRaftCtx composes the context of the conf-change as node-id, from-id (my node-sign-id), and restrict,
+signed with my node-key, so that the proposer can be verified.
+*/
+func (pm *ProtocolManager) proposeRaftCtx(nodeID *discover.NodeID, restrict NodePermission) ([]byte, error) {
+	myInfo := pm.Entity().(*MyInfo)
+
+	return signRaftCtx(nodeID, myInfo.NodeSignID, restrict, myInfo.nodeKey)
+}
+
+func signRaftCtx(nodeID *discover.NodeID, fromID *types.PttID, restrict NodePermission, nodeKey *ecdsa.PrivateKey) ([]byte, error) {
+	ctx := make([]byte, SizeSignedRaftCtx)
 	copy(ctx[:], nodeID[:])
-	myNodeSignID := pm.Entity().(*MyInfo).NodeSignID
-	copy(ctx[discover.SizeNodeID:], myNodeSignID[:])
+	copy(ctx[discover.SizeNodeID:], fromID[:])
+	ctx[discover.SizeNodeID+types.SizePttID] = byte(restrict)
+
+	sig, err := crypto.Sign(crypto.Keccak256(ctx[:SizeRaftCtx]), nodeKey)
+	if err != nil {
+		return nil, err
+	}
+	copy(ctx[SizeRaftCtx:], sig)
 
-	return ctx
+	return ctx, nil
+}
+
+/*
+verifyRaftCtx verifies that the context of the conf-change is signed by the node-key of the from-node.
+*/
+func verifyRaftCtx(ctx []byte, fromID *types.PttID, myID *types.PttID) error {
+	if len(ctx) != SizeSignedRaftCtx {
+		return ErrInvalidEntry
+	}
+
+	pubkey, err := crypto.SigToPub(crypto.Keccak256(ctx[:SizeRaftCtx]), ctx[SizeRaftCtx:])
+	if err != nil {
+		return ErrInvalidEntry
+	}
+
+	signNodeID := discover.PubkeyID(pubkey)
+	signID, err := setNodeSignID(&signNodeID, myID)
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(signID, fromID) {
+		return ErrInvalidEntry
+	}
+
+	return nil
+}
+
+/*
+parseRaftCtx parses the context of the conf-change as node-id, from-id, and restrict.
+The context of the bootstrapping conf-change includes only the node-id (with empty from-id).
+The restrict is not included in the contexts before the node-permissions.
+*/
+func parseRaftCtx(ctx []byte) (*discover.NodeID, *types.PttID, NodePermission, error) {
+	if len(ctx) == discover.SizeNodeID {
+		nodeID := &discover.NodeID{}
+		copy(nodeID[:], ctx)
+		return nodeID, &types.PttID{}, 0, nil
+	}
+
+	if len(ctx) < discover.SizeNodeID+types.SizePttID {
+		return nil, nil, 0, ErrInvalidEntry
+	}
+
+	nodeID := &discover.NodeID{}
+	copy(nodeID[:], ctx[:discover.SizeNodeID])
+
+	fromID := &types.PttID{}
+	copy(fromID[:], ctx[discover.SizeNodeID:])
+
+	var restrict NodePermission
+	if len(ctx) > discover.SizeNodeID+types.SizePttID {
+		restrict = NodePermission(ctx[discover.SizeNodeID+types.SizePttID])
+	}
+
+	return nodeID, fromID, restrict, nil
 }
 
 func (pm *ProtocolManager) ProposeRaftRemoveNode(nodeID *discover.NodeID) error {
@@ -81,7 +204,10 @@ func (pm *ProtocolManager) ProposeRaftRemoveNode(nodeID *discover.NodeID) error
 		return err
 	}
 
-	ctx := pm.proposeRaftCtx(nodeID)
+	ctx, err := pm.proposeRaftCtx(nodeID, 0)
+	if err != nil {
+		return err
+	}
 
 	cc := pb.ConfChange{
 		Type:    pb.ConfChangeRemoveNode,
@@ -114,7 +240,14 @@ func (pm *ProtocolManager) ForceProposeRaftRemoveNode(nodeID *discover.NodeID) e
 		return ErrInvalidNode
 	}
 
-	ctx := pm.proposeRaftCtx(nodeID)
+	if raftID != pm.myPtt.MyRaftID() && !pm.IsMyNodePermitted(NodePermManageNodes, true) {
+		return pkgservice.ErrNotPermitted
+	}
+
+	ctx, err := pm.proposeRaftCtx(nodeID, 0)
+	if err != nil {
+		return err
+	}
 
 	cc := pb.ConfChange{
 		Type:    pb.ConfChangeRemoveNode,
@@ -160,14 +293,22 @@ func (pm *ProtocolManager) PublishRaftEntries(ents []pb.Entry) error {
 
 			log.Info("PublishRaftEntries: ConfChange", "cc", cc, "ent", ents[i])
 
-			switch cc.Type {
-			case pb.ConfChangeAddNode:
+			err = pm.validateRaftConfChange(&ents[i], &cc)
+			if err != nil {
+				// the rejected conf-change is applied as raft.None.
+				log.Warn("PublishRaftEntries: invalid ConfChange", "cc", cc, "e", err)
+				cc.NodeID = raft.None
+			}
+
+			switch {
+			case cc.NodeID == raft.None:
+			case cc.Type == pb.ConfChangeAddNode:
 				err = pm.publishEntriesAddNode(&ents[i], &cc)
 				if err != nil {
 					log.Warn("publishEntriesAddNode: failed", "e", err)
 				}
 
-			case pb.ConfChangeRemoveNode:
+			case cc.Type == pb.ConfChangeRemoveNode:
 				err = pm.publishEntriesRemoveNode(&ents[i], &cc)
 				if err != nil {
 					log.Warn("publishEntriesRemoveNode: failed", "e", err)
@@ -187,16 +328,114 @@ func (pm *ProtocolManager) PublishRaftEntries(ents []pb.Entry) error {
 	return nil
 }
 
+/*
+validateRaftConfChange validates the signature and the permission of the node proposing the conf-change.
+The unsigned context is accepted only for bootstrapping the first node (the first raft-entry),
+and for the legacy conf-changes committed before the first signed conf-change (see isLegacyRaftEntry).
+The proposer must be one of my-nodes, and the node without NodePermManageNodes can only update its own weight or remove itself.
+*/
+func (pm *ProtocolManager) validateRaftConfChange(ent *pb.Entry, cc *pb.ConfChange) error {
+	nodeID, fromID, restrict, err := parseRaftCtx(cc.Context)
+	if err != nil {
+		return err
+	}
+
+	raftID, err := nodeID.ToRaftID()
+	if err != nil {
+		return err
+	}
+	if raftID != cc.NodeID {
+		return ErrInvalidEntry
+	}
+
+	// bootstrap
+	if len(cc.Context) == discover.SizeNodeID {
+		if ent.Index == 1 && cc.Type == pb.ConfChangeAddNode {
+			return nil
+		}
+		return ErrInvalidEntry
+	}
+
+	// legacy
+	if isLegacyRaftCtx(cc.Context) {
+		if pm.isLegacyRaftEntry(ent) {
+			return nil
+		}
+		return ErrInvalidEntry
+	}
+
+	err = verifyRaftCtx(cc.Context, fromID, pm.Entity().GetID())
+	if err != nil {
+		return err
+	}
+
+	err = pm.setRaftSignedIndex(ent.Index)
+	if err != nil {
+		return err
+	}
+
+	pm.lockMyNodes.RLock()
+	defer pm.lockMyNodes.RUnlock()
+
+	fromNode := pm.MyNodeByNodeSignIDs[*fromID]
+	if fromNode == nil || fromNode.Status == types.StatusDeleted {
+		return ErrInvalidNode
+	}
+
+	isSelf := fromNode.RaftID == raftID
+
+	switch cc.Type {
+	case pb.ConfChangeAddNode:
+		if isSelf && restrict == fromNode.Restrict {
+			return nil
+		}
+		if !fromNode.Permission().IsPermitted(NodePermManageNodes) {
+			return pkgservice.ErrNotPermitted
+		}
+		if !pm.isWithOtherNodeManager(raftID, restrict) {
+			return pkgservice.ErrNotPermitted
+		}
+	case pb.ConfChangeRemoveNode:
+		if isSelf {
+			return nil
+		}
+		if !fromNode.Permission().IsPermitted(NodePermManageNodes) {
+			return pkgservice.ErrNotPermitted
+		}
+	}
+
+	return nil
+}
+
+/*
+isLegacyRaftCtx checks whether the context is the unsigned context before the signed contexts,
+as node-id and from-id, with or without restrict.
+*/
+func isLegacyRaftCtx(ctx []byte) bool {
+	return len(ctx) == SizeRaftCtx-1 || len(ctx) == SizeRaftCtx
+}
+
+/*
+isLegacyRaftEntry checks whether the entry is committed before the first signed conf-change (the raft-signed-index.)
+
+The raft-signed-index is set by the first signed conf-change applied, which is the same entry on all my-nodes,
+so that the legacy entries are applied the same on all my-nodes replaying the raft-log.
+*/
+func (pm *ProtocolManager) isLegacyRaftEntry(ent *pb.Entry) bool {
+	signedIndex := pm.GetRaftSignedIndex()
+
+	return signedIndex == 0 || ent.Index < signedIndex
+}
+
 func (pm *ProtocolManager) publishEntriesAddNode(ent *pb.Entry, cc *pb.ConfChange) error {
 	ptt := pm.myPtt
 
 	myInfo := pm.Entity().(*MyInfo)
 
-	nodeID := &discover.NodeID{}
-	copy(nodeID[:], cc.Context[:discover.SizeNodeID])
-
-	fromID := &types.PttID{}
-	copy(fromID[:], cc.Context[discover.SizeNodeID:])
+	nodeID, fromID, restrict, err := parseRaftCtx(cc.Context)
+	if err != nil {
+		return err
+	}
 
 	raftID, err := nodeID.ToRaftID()
 	log.Debug("publishEntriesAddNode: after ToRaftID", "nodeID", nodeID, "e", err)
@@ -215,7 +454,7 @@ func (pm *ProtocolManager) publishEntriesAddNode(ent *pb.Entry, cc *pb.ConfChang
 	}
 
 	// master-oplog and my-node
-	oplog, err := pm.publishEntriesAddNodeCreateMasterOplogAndSetMyNode(ts, raftID, nodeID, weight, ent, fromID)
+	oplog, err := pm.publishEntriesAddNodeCreateMasterOplogAndSetMyNode(ts, raftID, nodeID, weight, restrict, ent, fromID)
 	log.Debug("publishEntriesAddNode: after SetMyNode", "e", err)
 	if err == pkgservice.ErrOplogAlreadyExists {
 		return nil
@@ -262,7 +501,7 @@ func (pm *ProtocolManager) publishEntriesAddNode(ent *pb.Entry, cc *pb.ConfChang
 	return nil
 }
 
-func (pm *ProtocolManager) publishEntriesAddNodeCreateMasterOplogAndSetMyNode(ts types.Timestamp, raftID uint64, nodeID *discover.NodeID, weight uint32, ent *pb.Entry, fromID *types.PttID) (*MasterOplog, error) {
+func (pm *ProtocolManager) publishEntriesAddNodeCreateMasterOplogAndSetMyNode(ts types.Timestamp, raftID uint64, nodeID *discover.NodeID, weight uint32, restrict NodePermission, ent *pb.Entry, fromID *types.PttID) (*MasterOplog, error) {
 
 	myInfo := pm.Entity().(*MyInfo)
 
@@ -294,10 +533,11 @@ func (pm *ProtocolManager) publishEntriesAddNodeCreateMasterOplogAndSetMyNode(ts
 	}
 
 	opData := &MasterOpAddMaster{
-		ID:      nodeID,
-		From:    fromID,
-		Masters: masters,
-		Weight:  totalWeight,
+		ID:       nodeID,
+		From:     fromID,
+		Masters:  masters,
+		Weight:   totalWeight,
+		Restrict: restrict,
 	}
 
 	oplog, err := pm.CreateMasterOplog(ent.Index, ts, MasterOpTypeAddMaster, opData)
@@ -323,6 +563,7 @@ func (pm *ProtocolManager) publishEntriesAddNodeCreateMasterOplogAndSetMyNode(ts
 
 	origWeight := myNode.Weight
 	myNode.Weight = weight
+	myNode.Restrict = restrict
 	myNode.UpdateTS = ts
 	myNode.LogID = oplog.ID
 	pm.totalWeight += weight - origWeight
@@ -345,11 +586,10 @@ func (pm *ProtocolManager) publishEntriesAddNodeCreateMasterOplogAndSetMyNode(ts
 
 func (pm *ProtocolManager) publishEntriesRemoveNode(ent *pb.Entry, cc *pb.ConfChange) error {
 
-	nodeID := &discover.NodeID{}
-	copy(nodeID[:], cc.Context[:discover.SizeNodeID])
-
-	fromID := &types.PttID{}
-	copy(fromID[:], cc.Context[discover.SizeNodeID:])
+	nodeID, fromID, _, err := parseRaftCtx(cc.Context)
+	if err != nil {
+		return err
+	}
 
 	raftID, err := nodeID.ToRaftID()
 	if err != nil {
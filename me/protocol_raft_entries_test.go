@@ -0,0 +1,199 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package me
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/crypto"
+	"github.com/ailabstw/go-pttai/p2p/discover"
+	pb "github.com/ailabstw/go-pttai/raft/raftpb"
+)
+
+func TestParseRaftCtx(t *testing.T) {
+	nodeID := &discover.NodeID{1, 2, 3}
+	fromID := &types.PttID{4, 5, 6}
+
+	ctx := make([]byte, discover.SizeNodeID+types.SizePttID)
+	copy(ctx, nodeID[:])
+	copy(ctx[discover.SizeNodeID:], fromID[:])
+
+	tests := []struct {
+		name         string
+		ctx          []byte
+		wantRestrict NodePermission
+		wantErr      bool
+	}{
+		{
+			name: "without restrict",
+			ctx:  ctx,
+		},
+		{
+			name:         "read-only",
+			ctx:          append(append([]byte{}, ctx...), byte(NodePermAll&^NodeClassReadOnly)),
+			wantRestrict: NodePermAll,
+		},
+		{
+			name:    "too short",
+			ctx:     ctx[:discover.SizeNodeID+1],
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotNodeID, gotFromID, gotRestrict, err := parseRaftCtx(tt.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseRaftCtx() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(gotNodeID, nodeID) {
+				t.Errorf("parseRaftCtx() nodeID = %v, want %v", gotNodeID, nodeID)
+			}
+			if !reflect.DeepEqual(gotFromID, fromID) {
+				t.Errorf("parseRaftCtx() fromID = %v, want %v", gotFromID, fromID)
+			}
+			if gotRestrict != tt.wantRestrict {
+				t.Errorf("parseRaftCtx() restrict = %v, want %v", gotRestrict, tt.wantRestrict)
+			}
+		})
+	}
+}
+
+func TestVerifyRaftCtx(t *testing.T) {
+	// prepare test-cases
+	myID := &types.PttID{7, 8, 9}
+
+	nodeKey, _ := crypto.GenerateKey()
+	nodeID := discover.PubkeyID(&nodeKey.PublicKey)
+	fromID, _ := setNodeSignID(&nodeID, myID)
+
+	otherKey, _ := crypto.GenerateKey()
+	otherNodeID := discover.PubkeyID(&otherKey.PublicKey)
+	otherID, _ := setNodeSignID(&otherNodeID, myID)
+
+	ctx, _ := signRaftCtx(&otherNodeID, fromID, 0, nodeKey)
+
+	forgedCtx, _ := signRaftCtx(&otherNodeID, fromID, 0, otherKey)
+
+	tamperedCtx := append([]byte{}, ctx...)
+	tamperedCtx[discover.SizeNodeID+types.SizePttID] = byte(NodePermAll)
+
+	tests := []struct {
+		name    string
+		ctx     []byte
+		fromID  *types.PttID
+		wantErr bool
+	}{
+		{"signed by the from-node", ctx, fromID, false},
+		{"signed by another node", forgedCtx, fromID, true},
+		{"claimed as another node", ctx, otherID, true},
+		{"tampered restrict", tamperedCtx, fromID, true},
+		{"unsigned", ctx[:SizeRaftCtx], fromID, true},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyRaftCtx(tt.ctx, tt.fromID, myID); (err != nil) != tt.wantErr {
+				t.Errorf("verifyRaftCtx() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRaftConfChange_Legacy(t *testing.T) {
+	pm, teardown := tNewMePM(t)
+	defer teardown()
+
+	myID := pm.Entity().GetID()
+
+	nodeKey, _ := crypto.GenerateKey()
+	nodeID := discover.PubkeyID(&nodeKey.PublicKey)
+	raftID, _ := nodeID.ToRaftID()
+	fromID, _ := setNodeSignID(&nodeID, myID)
+
+	pm.MyNodeByNodeSignIDs[*fromID] = &MyNode{RaftID: raftID, NodeID: &nodeID, Status: types.StatusAlive}
+
+	legacyCtx := make([]byte, SizeRaftCtx-1)
+	copy(legacyCtx, nodeID[:])
+	copy(legacyCtx[discover.SizeNodeID:], fromID[:])
+
+	legacyRestrictCtx := append(append([]byte{}, legacyCtx...), 0)
+
+	signedCtx, _ := signRaftCtx(&nodeID, fromID, 0, nodeKey)
+
+	// prepare test-cases
+	tests := []struct {
+		name    string
+		index   uint64
+		ctx     []byte
+		wantErr bool
+	}{
+		{"legacy before signed", 2, legacyCtx, false},
+		{"legacy with restrict before signed", 3, legacyRestrictCtx, false},
+		{"signed", 5, signedCtx, false},
+		{"legacy replayed", 2, legacyCtx, false},
+		{"legacy with restrict replayed", 3, legacyRestrictCtx, false},
+		{"legacy after signed", 6, legacyCtx, true},
+		{"signed replayed", 5, signedCtx, false},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := &pb.ConfChange{Type: pb.ConfChangeAddNode, NodeID: raftID, Context: tt.ctx}
+			ent := &pb.Entry{Type: pb.EntryConfChange, Index: tt.index}
+
+			if err := pm.validateRaftConfChange(ent, cc); (err != nil) != tt.wantErr {
+				t.Errorf("validateRaftConfChange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if got := pm.GetRaftSignedIndex(); got != 5 {
+		t.Errorf("GetRaftSignedIndex() = %v, want 5", got)
+	}
+}
+
+func TestMyNodePermission(t *testing.T) {
+	tests := []struct {
+		name     string
+		restrict NodePermission
+		perm     NodePermission
+		want     bool
+	}{
+		{"full post", 0, NodePermPost, true},
+		{"full manage", 0, NodePermManageNodes | NodePermExportKey, true},
+		{"poster post", NodePermAll &^ NodeClassPoster, NodePermPost, true},
+		{"poster manage", NodePermAll &^ NodeClassPoster, NodePermManageNodes, false},
+		{"poster export", NodePermAll &^ NodeClassPoster, NodePermExportKey, false},
+		{"read-only post", NodePermAll &^ NodeClassReadOnly, NodePermPost, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &MyNode{Restrict: tt.restrict}
+			if got := m.Permission().IsPermitted(tt.perm); got != tt.want {
+				t.Errorf("IsPermitted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
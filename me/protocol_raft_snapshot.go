@@ -17,10 +17,33 @@
 package me
 
 import (
+	"context"
+	"encoding/json"
+	"math"
+	"sort"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
 	"github.com/ailabstw/go-pttai/raft"
 	pb "github.com/ailabstw/go-pttai/raft/raftpb"
+	pkgservice "github.com/ailabstw/go-pttai/service"
 )
 
+/*
+RaftSnapshotData is the state-machine of the me-raft in the snapshot.
+
+The only meaningful entries are the conf-changes (add-node / remove-node),
+so we keep the newest conf-change entry of each node,
+and the lagging nodes replay the entries to set up the my-nodes and the master-oplogs.
+*/
+type RaftSnapshotData struct {
+	Entries []pb.Entry `json:"E"`
+}
+
+type SendRaftSnapshot struct {
+	Msg pb.Message `json:"M"`
+}
+
 func (pm *ProtocolManager) PublishRaftSnapshot(snap pb.Snapshot) error {
 	if raft.IsEmptySnap(snap) {
 		return nil
@@ -35,12 +58,235 @@ func (pm *ProtocolManager) PublishRaftSnapshot(snap pb.Snapshot) error {
 	}
 
 	pm.SetRaftConfState(snap.Metadata.ConfState, false)
+
+	err := pm.replayRaftSnapshot(snap)
+	if err != nil {
+		log.Warn("PublishRaftSnapshot: unable to replay snapshot", "index", snap.Metadata.Index, "e", err)
+	}
+
 	pm.SetRaftSnapshotIndex(snap.Metadata.Index, false)
 	pm.SetRaftAppliedIndex(snap.Metadata.Index, true)
 
 	return nil
 }
 
+/*
+replayRaftSnapshot replays the conf-change entries in the snapshot.
+The conf-state is already restored by raft from the snapshot, so we do not ApplyConfChange here.
+*/
+func (pm *ProtocolManager) replayRaftSnapshot(snap pb.Snapshot) error {
+	if len(snap.Data) == 0 {
+		return nil
+	}
+
+	data := &RaftSnapshotData{}
+	err := json.Unmarshal(snap.Data, data)
+	if err != nil {
+		return err
+	}
+
+	for i := range data.Entries {
+		var cc pb.ConfChange
+		err = cc.Unmarshal(data.Entries[i].Data)
+		if err != nil {
+			continue
+		}
+
+		switch cc.Type {
+		case pb.ConfChangeAddNode:
+			err = pm.publishEntriesAddNode(&data.Entries[i], &cc)
+		case pb.ConfChangeRemoveNode:
+			err = pm.publishEntriesRemoveNode(&data.Entries[i], &cc)
+		}
+		log.Debug("replayRaftSnapshot: after publish entry", "index", data.Entries[i].Index, "type", cc.Type, "e", err)
+	}
+
+	return nil
+}
+
+/*
+MaybeTriggerRaftSnapshot creates the snapshot if there are more than RaftSnapshotCount applied entries since the last snapshot.
+*/
 func (pm *ProtocolManager) MaybeTriggerRaftSnapshot() error {
+	appliedIndex := pm.GetRaftAppliedIndex(false)
+	snapshotIndex := pm.GetRaftSnapshotIndex(false)
+	if appliedIndex <= snapshotIndex+RaftSnapshotCount {
+		return nil
+	}
+
+	return pm.TriggerRaftSnapshot(appliedIndex)
+}
+
+/*
+TriggerRaftSnapshot creates the snapshot at the applied index,
+and compacts the entries except the last RaftSnapshotCatchUpEntries entries for the slightly lagging nodes.
+*/
+func (pm *ProtocolManager) TriggerRaftSnapshot(appliedIndex uint64) error {
+	origSnap, err := pm.rs.Snapshot()
+	if err != nil {
+		return err
+	}
+
+	data, err := pm.raftSnapshotData(origSnap, appliedIndex)
+	if err != nil {
+		return err
+	}
+
+	cs := pm.GetRaftConfState(false)
+	snap, err := pm.rs.CreateSnapshot(appliedIndex, &cs, data)
+	log.Debug("TriggerRaftSnapshot: after CreateSnapshot", "index", appliedIndex, "e", err)
+	if err != nil {
+		return err
+	}
+
+	err = pm.SetRaftSnapshotIndex(snap.Metadata.Index, false)
+	if err != nil {
+		return err
+	}
+
+	if appliedIndex <= RaftSnapshotCatchUpEntries {
+		return nil
+	}
+
+	return pm.rs.Compact(appliedIndex-RaftSnapshotCatchUpEntries, false)
+}
+
+func (pm *ProtocolManager) raftSnapshotData(origSnap pb.Snapshot, appliedIndex uint64) ([]byte, error) {
+	data := &RaftSnapshotData{}
+	if len(origSnap.Data) != 0 {
+		err := json.Unmarshal(origSnap.Data, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ents, err := pm.rs.Entries(origSnap.Metadata.Index+1, appliedIndex+1, math.MaxUint64)
+	if err != nil {
+		return nil, err
+	}
+
+	data.Entries = mergeRaftSnapshotEntries(data.Entries, pm.acceptedRaftEntries(ents))
+
+	return json.Marshal(data)
+}
+
+/*
+acceptedRaftEntries filters out the conf-changes without master-oplogs (rejected by validateRaftConfChange).
+*/
+func (pm *ProtocolManager) acceptedRaftEntries(ents []pb.Entry) []pb.Entry {
+	myID := pm.Entity().GetID()
+
+	newEnts := make([]pb.Entry, 0, len(ents))
+	baseOplog := &pkgservice.BaseOplog{}
+	pm.SetMasterDB(baseOplog)
+	for _, ent := range ents {
+		if ent.Type != pb.EntryConfChange {
+			continue
+		}
+
+		err := baseOplog.Get(raftIdxToMasterOplogID(ent.Index, myID), false)
+		if err != nil {
+			continue
+		}
+
+		newEnts = append(newEnts, ent)
+	}
+
+	return newEnts
+}
+
+/*
+mergeRaftSnapshotEntries keeps the newest conf-change entry of each node, ordered by the index.
+*/
+func mergeRaftSnapshotEntries(origEnts []pb.Entry, ents []pb.Entry) []pb.Entry {
+	entByNodes := make(map[uint64]pb.Entry)
+
+	var cc pb.ConfChange
+	for _, eachEnts := range [][]pb.Entry{origEnts, ents} {
+		for _, ent := range eachEnts {
+			if ent.Type != pb.EntryConfChange {
+				continue
+			}
+
+			cc = pb.ConfChange{}
+			err := cc.Unmarshal(ent.Data)
+			if err != nil {
+				continue
+			}
+
+			origEnt, ok := entByNodes[cc.NodeID]
+			if ok && origEnt.Index > ent.Index {
+				continue
+			}
+			entByNodes[cc.NodeID] = ent
+		}
+	}
+
+	newEnts := make([]pb.Entry, 0, len(entByNodes))
+	for _, ent := range entByNodes {
+		newEnts = append(newEnts, ent)
+	}
+
+	sort.Slice(newEnts, func(i, j int) bool {
+		return newEnts[i].Index < newEnts[j].Index
+	})
+
+	return newEnts
+}
+
+/**********
+ * Send Raft Snapshot
+ **********/
+
+/*
+SendRaftSnapshot sends the snapshot to the lagging node,
+and reports the result to raft, so that the leader can continue replicating the entries or resend the snapshot.
+*/
+func (pm *ProtocolManager) SendRaftSnapshot(msg pb.Message, peer *pkgservice.PttPeer) error {
+	log.Debug("SendRaftSnapshot: start", "to", msg.To, "index", msg.Snapshot.Metadata.Index)
+
+	if peer == nil {
+		pm.raftNode.ReportSnapshot(msg.To, raft.SnapshotFailure)
+		return ErrInvalidNode
+	}
+
+	data := &SendRaftSnapshot{
+		Msg: msg,
+	}
+
+	err := pm.SendDataToPeer(SendRaftSnapshotMsg, data, peer)
+	if err != nil {
+		pm.raftNode.ReportSnapshot(msg.To, raft.SnapshotFailure)
+		return err
+	}
+
+	pm.raftNode.ReportSnapshot(msg.To, raft.SnapshotFinish)
+
 	return nil
 }
+
+func (pm *ProtocolManager) HandleSendRaftSnapshot(dataBytes []byte, peer *pkgservice.PttPeer) error {
+	myInfo := pm.Entity().(*MyInfo)
+	// defensive-programming
+	if myInfo.Status == types.StatusInit || myInfo.Status == types.StatusInternalPending {
+		return nil
+	}
+
+	if pm.raftNode == nil {
+		return nil
+	}
+
+	data := &SendRaftSnapshot{}
+	err := json.Unmarshal(dataBytes, data)
+	if err != nil {
+		return err
+	}
+
+	if data.Msg.Type != pb.MsgSnap {
+		return ErrInvalidEntry
+	}
+
+	log.Debug("HandleSendRaftSnapshot: to Step", "from", data.Msg.From, "index", data.Msg.Snapshot.Metadata.Index)
+
+	return pm.raftNode.Step(context.TODO(), data.Msg)
+}
@@ -0,0 +1,74 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package me
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/ailabstw/go-pttai/raft/raftpb"
+)
+
+func tConfChangeEntry(t *testing.T, idx uint64, ccType pb.ConfChangeType, nodeID uint64) pb.Entry {
+	cc := pb.ConfChange{Type: ccType, NodeID: nodeID}
+	data, err := cc.Marshal()
+	if err != nil {
+		t.Fatalf("unable to marshal conf-change: %v", err)
+	}
+	return pb.Entry{Index: idx, Type: pb.EntryConfChange, Data: data}
+}
+
+func TestMergeRaftSnapshotEntries(t *testing.T) {
+	add1 := tConfChangeEntry(t, 1, pb.ConfChangeAddNode, 1)
+	add2 := tConfChangeEntry(t, 3, pb.ConfChangeAddNode, 2)
+	normal := pb.Entry{Index: 4, Type: pb.EntryNormal}
+	update1 := tConfChangeEntry(t, 5, pb.ConfChangeAddNode, 1)
+	remove2 := tConfChangeEntry(t, 7, pb.ConfChangeRemoveNode, 2)
+	add3 := tConfChangeEntry(t, 8, pb.ConfChangeAddNode, 3)
+
+	tests := []struct {
+		name     string
+		origEnts []pb.Entry
+		ents     []pb.Entry
+		want     []pb.Entry
+	}{
+		{
+			name: "empty",
+			want: []pb.Entry{},
+		},
+		{
+			name: "first snapshot",
+			ents: []pb.Entry{add1, add2, normal},
+			want: []pb.Entry{add1, add2},
+		},
+		{
+			name:     "newest per node",
+			origEnts: []pb.Entry{add1, add2},
+			ents:     []pb.Entry{normal, update1, remove2, add3},
+			want:     []pb.Entry{update1, remove2, add3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeRaftSnapshotEntries(tt.origEnts, tt.ents)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeRaftSnapshotEntries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
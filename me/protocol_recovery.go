@@ -0,0 +1,221 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package me
+
+import (
+	"crypto/ecdsa"
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/crypto"
+	"github.com/ailabstw/go-pttai/crypto/shamir"
+	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+const (
+	sizeRecoveryKey = 32
+)
+
+/*
+SetupRecovery splits my master-key (with the postfix of my id) into the shamir-shares,
+and sends each share to the corresponding friend through the friend-entity.
+Any threshold of the friends are able to help restore my identity.
+
+The shares are sent all or none: the shares are pending at my friends,
+and are committed only after all the shares are sent (aborted otherwise.)
+*/
+func (pm *ProtocolManager) SetupRecovery(friendIDs []*types.PttID, threshold int) error {
+	myInfo := pm.Entity().(*MyInfo)
+	if myInfo.Status != types.StatusAlive {
+		return ErrInvalidMe
+	}
+
+	nShare := len(friendIDs)
+	if threshold < 2 || threshold > nShare {
+		return shamir.ErrInvalidThreshold
+	}
+
+	// friends
+	friendSPM := pm.Entity().Service().(*Backend).friendBackend.SPM().(*friend.ServiceProtocolManager)
+
+	friendPMs := make([]*friend.ProtocolManager, nShare)
+	isSeen := make(map[types.PttID]bool)
+	for i, friendID := range friendIDs {
+		if isSeen[*friendID] {
+			return types.ErrInvalidID
+		}
+		isSeen[*friendID] = true
+
+		f, err := friendSPM.GetFriendEntityByFriendID(friendID)
+		if err != nil {
+			return err
+		}
+		if f.GetStatus() != types.StatusAlive {
+			return friend.ErrInvalidFriend
+		}
+
+		friendPM := f.PM().(*friend.ProtocolManager)
+		if !friendPM.IsFriendOnline() {
+			return pkgservice.ErrNotSent
+		}
+		friendPMs[i] = friendPM
+	}
+
+	// split
+	secret := marshalRecoverySecret(myInfo.GetMasterKey(), myInfo.ID)
+	defer common.Bzero(secret)
+
+	shares, err := shamir.Split(secret, nShare, threshold)
+	if err != nil {
+		return err
+	}
+
+	setID, err := types.NewPttID()
+	if err != nil {
+		return err
+	}
+
+	// send
+	for i, friendPM := range friendPMs {
+		err = friendPM.SendRecoveryShare(setID, shares[i], threshold, nShare)
+		log.Debug("SetupRecovery: after SendRecoveryShare", "friend", friendIDs[i], "e", err)
+		if err != nil {
+			for _, sentPM := range friendPMs[:i] {
+				sentPM.AbortRecoveryShare(setID)
+			}
+			return err
+		}
+	}
+
+	// commit
+	var commitErr error
+	for i, friendPM := range friendPMs {
+		err = friendPM.CommitRecoveryShare(setID)
+		log.Debug("SetupRecovery: after CommitRecoveryShare", "friend", friendIDs[i], "e", err)
+		if err != nil && commitErr == nil {
+			commitErr = err
+		}
+	}
+
+	return commitErr
+}
+
+/*
+RecoverMe restores the identity of the owner from the recovery-shares released by my friends,
+and restarts the node as the owner.
+*/
+func (pm *ProtocolManager) RecoverMe(ownerID *types.PttID) error {
+	myInfo := pm.Entity().(*MyInfo)
+	if reflect.DeepEqual(myInfo.ID, ownerID) {
+		return types.ErrInvalidID
+	}
+
+	friendSPM := pm.Entity().Service().(*Backend).friendBackend.SPM().(*friend.ServiceProtocolManager)
+	recoveredShares, err := friendSPM.GetRecoveredShareList(ownerID)
+	if err != nil {
+		return err
+	}
+
+	key, postfix, err := combineRecoveryShares(recoveredShares, ownerID)
+	if err != nil {
+		return err
+	}
+
+	// renew-me
+	cfg := pm.Entity().Service().(*Backend).Config
+	err = renewMe(cfg, key, postfix)
+	log.Debug("RecoverMe: after renewMe", "e", err)
+	if err != nil {
+		return err
+	}
+
+	// restart
+	pm.myPtt.NotifyNodeRestart().PassChan(struct{}{})
+
+	return nil
+}
+
+/*
+combineRecoveryShares combines the recovered-shares of any set with enough shares into the master-key of the owner.
+*/
+func combineRecoveryShares(recoveredShares []*friend.RecoveredShare, ownerID *types.PttID) (*ecdsa.PrivateKey, []byte, error) {
+	// group by set
+	shareSets := make(map[types.PttID][][]byte)
+	thresholds := make(map[types.PttID]int)
+	for _, recoveredShare := range recoveredShares {
+		setID := *recoveredShare.SetID
+		shareSets[setID] = append(shareSets[setID], recoveredShare.Share)
+		thresholds[setID] = recoveredShare.Threshold
+	}
+
+	for setID, shares := range shareSets {
+		if len(shares) < thresholds[setID] {
+			continue
+		}
+
+		secret, err := shamir.Combine(shares)
+		if err != nil {
+			continue
+		}
+
+		key, postfix, err := unmarshalRecoverySecret(secret, ownerID)
+		common.Bzero(secret)
+		if err == nil {
+			return key, postfix, nil
+		}
+	}
+
+	return nil, nil, ErrNotEnoughRecoveryShares
+}
+
+func marshalRecoverySecret(key *ecdsa.PrivateKey, myID *types.PttID) []byte {
+	keyBytes := crypto.FromECDSA(key)
+
+	secret := make([]byte, 0, len(keyBytes)+len(myID)-common.AddressLength)
+	secret = append(secret, keyBytes...)
+	secret = append(secret, myID[common.AddressLength:]...)
+
+	common.Bzero(keyBytes)
+
+	return secret
+}
+
+func unmarshalRecoverySecret(secret []byte, ownerID *types.PttID) (*ecdsa.PrivateKey, []byte, error) {
+	if len(secret) <= sizeRecoveryKey {
+		return nil, nil, ErrInvalidPrivateKey
+	}
+
+	key, err := crypto.ToECDSA(secret[:sizeRecoveryKey])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	postfix := common.CloneBytes(secret[sizeRecoveryKey:])
+
+	id, err := types.NewPttIDFromKeyPostfix(key, postfix)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !reflect.DeepEqual(id, ownerID) {
+		return nil, nil, ErrInvalidPrivateKey
+	}
+
+	return key, postfix, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package me
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/crypto"
+	"github.com/ailabstw/go-pttai/crypto/shamir"
+	"github.com/ailabstw/go-pttai/friend"
+)
+
+func TestRecoverySplitReleaseRecover(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: e: %v", err)
+	}
+	myID, err := types.NewPttIDFromKey(key)
+	if err != nil {
+		t.Fatalf("NewPttIDFromKey: e: %v", err)
+	}
+
+	// split (SetupRecovery)
+	secret := marshalRecoverySecret(key, myID)
+	shares, err := shamir.Split(secret, 3, 2)
+	if err != nil {
+		t.Fatalf("Split: e: %v", err)
+	}
+
+	// release (ApproveRecoveryRequest / HandleReleaseRecoveryShare)
+	setID, _ := types.NewPttID()
+	otherSetID, _ := types.NewPttID()
+	released := make([]*friend.RecoveredShare, len(shares))
+	for i, share := range shares {
+		released[i] = &friend.RecoveredShare{
+			OwnerID:   myID,
+			HolderID:  &types.PttID{byte(i + 1)},
+			SetID:     setID,
+			Share:     share,
+			Threshold: 2,
+		}
+	}
+	stale := &friend.RecoveredShare{OwnerID: myID, SetID: otherSetID, Share: shares[0], Threshold: 2}
+
+	// recover (RecoverMe)
+	_, _, err = combineRecoveryShares([]*friend.RecoveredShare{released[0], stale}, myID)
+	if err != ErrNotEnoughRecoveryShares {
+		t.Errorf("combineRecoveryShares: below threshold: e: %v", err)
+	}
+
+	recoveredKey, postfix, err := combineRecoveryShares([]*friend.RecoveredShare{stale, released[2], released[0]}, myID)
+	if err != nil {
+		t.Fatalf("combineRecoveryShares: e: %v", err)
+	}
+	if !reflect.DeepEqual(crypto.FromECDSA(recoveredKey), crypto.FromECDSA(key)) {
+		t.Errorf("combineRecoveryShares: key not recovered")
+	}
+
+	recoveredID, err := types.NewPttIDFromKeyPostfix(recoveredKey, postfix)
+	if err != nil || !reflect.DeepEqual(recoveredID, myID) {
+		t.Errorf("combineRecoveryShares: id: %v want: %v e: %v", recoveredID, myID, err)
+	}
+
+	otherID, _ := types.NewPttID()
+	if _, _, err = combineRecoveryShares(released, otherID); err != ErrNotEnoughRecoveryShares {
+		t.Errorf("combineRecoveryShares: other owner: e: %v", err)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/log"
 	"github.com/ailabstw/go-pttai/p2p/discover"
+	pkgservice "github.com/ailabstw/go-pttai/service"
 )
 
 /*
@@ -42,6 +43,10 @@ func (pm *ProtocolManager) RevokeNode(nodeID *discover.NodeID) error {
 		return ErrInvalidNode
 	}
 
+	if raftID != pm.myPtt.MyRaftID() && !pm.IsMyNodePermitted(NodePermManageNodes, true) {
+		return pkgservice.ErrNotPermitted
+	}
+
 	err = pm.EnsureRaftLead()
 	if err != nil {
 		return err
@@ -35,10 +35,16 @@ func (pm *ProtocolManager) SendRaftMsgs(msgs []pb.Message) error {
 	myRaftID := pm.myPtt.MyRaftID()
 	msgByPeers := make(map[uint64][]pb.Message)
 	var origMsgByPeers []pb.Message
+	var snapMsgs []pb.Message
 	for _, msg := range msgs {
 		if msg.To == myRaftID {
 			continue
 		}
+		// snapshot is sent separately to report the status to raft.
+		if msg.Type == pb.MsgSnap {
+			snapMsgs = append(snapMsgs, msg)
+			continue
+		}
 		origMsgByPeers = msgByPeers[msg.To]
 		msgByPeers[msg.To] = append(origMsgByPeers, msg)
 	}
@@ -50,18 +56,7 @@ func (pm *ProtocolManager) SendRaftMsgs(msgs []pb.Message) error {
 	log.Debug("SendRaftMsgs: to for-loop", "peers", peers, "msgByPeers", msgByPeers)
 	var data *SendRaftMsgs
 	for raftID, eachMsgs := range msgByPeers {
-		myNode := pm.MyNodes[raftID]
-		if myNode == nil {
-			log.Warn("SendRaftMsgs: unable to send peer not myNode", "raftID", raftID)
-			continue
-		}
-
-		if myNode.Status == types.StatusInit || myNode.Status == types.StatusInternalPending {
-			log.Warn("SendRaftMsgs: myNode status invalid", "raftID", raftID, "status", myNode.Status)
-			continue
-		}
-
-		peer := peers.Peer(myNode.NodeID, false)
+		peer := pm.raftPeer(raftID, peers)
 		log.Debug("SendRaftMsgs: after get Peer", "peer", peer)
 		if peer == nil {
 			continue
@@ -76,9 +71,32 @@ func (pm *ProtocolManager) SendRaftMsgs(msgs []pb.Message) error {
 		pm.SendDataToPeer(SendRaftMsgsMsg, data, peer)
 	}
 
+	// snapshot
+	for _, msg := range snapMsgs {
+		pm.SendRaftSnapshot(msg, pm.raftPeer(msg.To, peers))
+	}
+
 	return nil
 }
 
+/*
+raftPeer gets the peer of the my-node by the raft-id. Required lockMyNodes.
+*/
+func (pm *ProtocolManager) raftPeer(raftID uint64, peers *pkgservice.PttPeerSet) *pkgservice.PttPeer {
+	myNode := pm.MyNodes[raftID]
+	if myNode == nil {
+		log.Warn("SendRaftMsgs: unable to send peer not myNode", "raftID", raftID)
+		return nil
+	}
+
+	if myNode.Status == types.StatusInit || myNode.Status == types.StatusInternalPending {
+		log.Warn("SendRaftMsgs: myNode status invalid", "raftID", raftID, "status", myNode.Status)
+		return nil
+	}
+
+	return peers.Peer(myNode.NodeID, false)
+}
+
 func (pm *ProtocolManager) HandleSendRaftMsgs(dataBytes []byte, peer *pkgservice.PttPeer) error {
 	myInfo := pm.Entity().(*MyInfo)
 	// defensive-programming
@@ -0,0 +1,247 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package me
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/content"
+	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+SyncSeen syncs the last-seen of the board / article / friend to my other nodes.
+*/
+func (m *MyInfo) SyncSeen(seenType pkgservice.SeenType, entityID *types.PttID, objID *types.PttID, ts types.Timestamp) error {
+	pm := m.PM().(*ProtocolManager)
+
+	logObjID := entityID
+	if objID != nil {
+		logObjID = objID
+	}
+
+	opData := &MeOpSetSeen{
+		SeenType: seenType,
+		EntityID: entityID,
+		ObjID:    objID,
+	}
+
+	return pm.createSyncOplog(logObjID, ts, MeOpTypeSetSeen, opData)
+}
+
+/*
+SyncLocale syncs the locale to my other nodes.
+*/
+func (m *MyInfo) SyncLocale(locale pkgservice.Locale, ts types.Timestamp) error {
+	pm := m.PM().(*ProtocolManager)
+
+	opData := &MeOpSetLocale{
+		Locale: locale,
+	}
+
+	return pm.createSyncOplog(m.ID, ts, MeOpTypeSetLocale, opData)
+}
+
+/*
+createSyncOplog creates the sync-oplog even without other nodes, so that the nodes joining later still get the newest states.
+*/
+func (pm *ProtocolManager) createSyncOplog(objID *types.PttID, ts types.Timestamp, op pkgservice.OpType, opData interface{}) error {
+	oplog, err := pm.CreateMeOplog(objID, ts, op, opData)
+	if err != nil {
+		return err
+	}
+
+	oplog.IsSync = true
+
+	err = oplog.Save(false, pm.meOplogMerkle)
+	if err != nil {
+		return err
+	}
+
+	isNewer, err := pm.applySyncLog(oplog.BaseOplog)
+	if err != nil {
+		return err
+	}
+	if !isNewer {
+		pm.deleteSyncOplog(oplog.ID)
+		return nil
+	}
+
+	pm.BroadcastMeOplog(oplog)
+
+	return nil
+}
+
+/*
+applySyncLog coalesces the sync-oplogs to one per object with last-writer-wins,
+and compacts the oplog superseded by the newer oplog.
+Returns false if the oplog is already superseded.
+*/
+func (pm *ProtocolManager) applySyncLog(oplog *pkgservice.BaseOplog) (bool, error) {
+	myID := pm.Entity().GetID()
+
+	pm.lockSyncLog.Lock()
+	defer pm.lockSyncLog.Unlock()
+
+	info := &SyncLogInfo{}
+	err := info.Get(myID, oplog.ObjID)
+	if err != nil {
+		info = &SyncLogInfo{}
+	}
+
+	if !isNewerSyncLog(oplog, info) {
+		return false, nil
+	}
+
+	origLogID := info.LogID
+
+	info = &SyncLogInfo{
+		V:        types.CurrentVersion,
+		ID:       oplog.ObjID,
+		MyID:     myID,
+		UpdateTS: oplog.UpdateTS,
+		LogID:    oplog.ID,
+	}
+
+	err = info.Save()
+	if err != nil {
+		return false, err
+	}
+
+	if origLogID != nil {
+		pm.deleteSyncOplog(origLogID)
+	}
+
+	return true, nil
+}
+
+func (pm *ProtocolManager) deleteSyncOplog(logID *types.PttID) {
+	oplog := &pkgservice.BaseOplog{}
+	pm.SetMeDB(oplog)
+
+	err := oplog.Get(logID, false)
+	if err != nil {
+		return
+	}
+
+	err = oplog.Delete(false)
+	if err != nil {
+		log.Warn("deleteSyncOplog: unable to delete", "logID", logID, "e", err)
+	}
+}
+
+func (pm *ProtocolManager) setNewestSyncLog(oplog *pkgservice.BaseOplog) (types.Bool, error) {
+	myID := pm.Entity().GetID()
+
+	info := &SyncLogInfo{}
+	err := info.Get(myID, oplog.ObjID)
+	if err != nil {
+		return true, err
+	}
+
+	return !types.Bool(reflect.DeepEqual(oplog.ID, info.LogID)), nil
+}
+
+/*
+handleSyncLog coalesces the sync-oplog from my other nodes.
+The superseded oplog is still saved as synced, and compacted in postprocessMeOplogs.
+*/
+func (pm *ProtocolManager) handleSyncLog(oplog *pkgservice.BaseOplog, info *ProcessMeInfo) (bool, error) {
+	isNewer, err := pm.applySyncLog(oplog)
+	if err != nil {
+		return false, err
+	}
+	if !isNewer {
+		info.SyncLogInfo[*oplog.ID] = oplog
+		return false, pkgservice.ErrNewerOplog
+	}
+
+	return true, nil
+}
+
+/*
+handleSeenLog applies the last-seen from my other nodes. The last-seens are saved with last-writer-wins.
+*/
+func (pm *ProtocolManager) handleSeenLog(oplog *pkgservice.BaseOplog, info *ProcessMeInfo) ([]*pkgservice.BaseOplog, error) {
+	opData := &MeOpSetSeen{}
+	err := oplog.GetData(opData)
+	if err != nil {
+		return nil, err
+	}
+
+	isNewer, err := pm.handleSyncLog(oplog, info)
+	if !isNewer {
+		return nil, err
+	}
+
+	backend := pm.Entity().Service().(*Backend)
+
+	switch opData.SeenType {
+	case pkgservice.SeenTypeBoard:
+		entity := backend.contentBackend.SPM().Entity(opData.EntityID)
+		if entity == nil {
+			return nil, nil
+		}
+		_, err = entity.PM().(*content.ProtocolManager).SaveLastSeen(oplog.UpdateTS)
+	case pkgservice.SeenTypeArticle:
+		entity := backend.contentBackend.SPM().Entity(opData.EntityID)
+		if entity == nil || opData.ObjID == nil {
+			return nil, nil
+		}
+		_, err = entity.PM().(*content.ProtocolManager).SaveArticleLastSeen(opData.ObjID, oplog.UpdateTS)
+	case pkgservice.SeenTypeFriend:
+		entity := backend.friendBackend.SPM().Entity(opData.EntityID)
+		if entity == nil {
+			return nil, nil
+		}
+		_, err = entity.PM().(*friend.ProtocolManager).SaveLastSeen(oplog.UpdateTS)
+	default:
+		return nil, pkgservice.ErrInvalidData
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (pm *ProtocolManager) handleLocaleLog(oplog *pkgservice.BaseOplog, info *ProcessMeInfo) ([]*pkgservice.BaseOplog, error) {
+	opData := &MeOpSetLocale{}
+	err := oplog.GetData(opData)
+	if err != nil {
+		return nil, err
+	}
+
+	if opData.Locale >= pkgservice.NLocale {
+		return nil, pkgservice.ErrInvalidData
+	}
+
+	isNewer, err := pm.handleSyncLog(oplog, info)
+	if !isNewer {
+		return nil, err
+	}
+
+	_, err = pkgservice.SetLocaleWithTS(opData.Locale, oplog.UpdateTS)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
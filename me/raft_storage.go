@@ -224,6 +224,10 @@ func (rs *RaftStorage) Entries(startIdx uint64, endIdx uint64, maxSize uint64) (
 		return []pb.Entry{}, nil
 	}
 
+	if startIdx < rs.firstIdx {
+		return nil, raft.ErrCompacted
+	}
+
 	iter, err := rs.GetIter(startIdx)
 	if err != nil {
 		return nil, err
@@ -264,6 +268,14 @@ func (rs *RaftStorage) Term(idx uint64) (uint64, error) {
 	rs.lock.RLock()
 	defer rs.lock.RUnlock()
 
+	// the valid term range is [index of dummy entry, last index]
+	if idx+1 < rs.firstIdx {
+		return 0, raft.ErrCompacted
+	}
+	if idx > rs.lastIdx {
+		return 0, raft.ErrUnavailable
+	}
+
 	key, err := rs.MarshalKey(idx)
 	if err != nil {
 		return 0, err
@@ -331,10 +343,33 @@ func (rs *RaftStorage) ApplySnapshot(snap pb.Snapshot) error {
 
 	rs.snapshot = snap
 
+	// discard all the entries and keep the dummy entry with the term of the snapshot.
+	err = rs.cleanEntries()
+	if err != nil {
+		return err
+	}
+
+	err = rs.SaveEntry(pb.Entry{Index: snapIndex, Term: snap.Metadata.Term}, true)
+	if err != nil {
+		return err
+	}
+
 	rs.firstIdx = snapIndex + 1
 	rs.lastIdx = snapIndex
 
-	rs.Compact(snapIndex, true)
+	return nil
+}
+
+func (rs *RaftStorage) cleanEntries() error {
+	iter, err := rs.GetIter(0)
+	if err != nil {
+		return err
+	}
+	defer iter.Release()
+
+	for iter.Next() {
+		dbRaft.Delete(iter.Key())
+	}
 
 	return nil
 }
@@ -363,9 +398,13 @@ func (rs *RaftStorage) LoadSnapshot() (pb.Snapshot, error) {
 	return snapshot, nil
 }
 
+/*
+CreateSnapshot creates and saves the snapshot at index i with the conf-state and the data of the state-machine.
+The entries are not compacted. Compact is called separately.
+*/
 func (rs *RaftStorage) CreateSnapshot(i uint64, cs *pb.ConfState, data []byte) (pb.Snapshot, error) {
-	rs.lock.RLock()
-	defer rs.lock.RUnlock()
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
 
 	if i <= rs.snapshot.Metadata.Index {
 		return pb.Snapshot{}, raft.ErrSnapOutOfDate
@@ -375,17 +414,41 @@ func (rs *RaftStorage) CreateSnapshot(i uint64, cs *pb.ConfState, data []byte) (
 		return pb.Snapshot{}, ErrInvalidEntry
 	}
 
-	rs.snapshot.Metadata.Index = i
-	term, err := rs.GetTermByIdx(i)
+	term, err := rs.getTermByIdx(i)
 	if err != nil {
 		return pb.Snapshot{}, err
 	}
-	rs.snapshot.Metadata.Term = term
-	rs.snapshot.Data = data
+
+	snap := pb.Snapshot{Data: data}
+	snap.Metadata.Index = i
+	snap.Metadata.Term = term
+	if cs != nil {
+		snap.Metadata.ConfState = *cs
+	}
+
+	marshaled, err := snap.Marshal()
+	if err != nil {
+		return pb.Snapshot{}, err
+	}
+
+	key, err := rs.MarshalKeyRaftSnapshot()
+	if err != nil {
+		return pb.Snapshot{}, err
+	}
+
+	err = dbRaft.Put(key, marshaled)
+	if err != nil {
+		return pb.Snapshot{}, err
+	}
+
+	rs.snapshot = snap
 
 	return rs.snapshot, nil
 }
 
+/*
+Compact discards the entries before idx. The entry at idx becomes the dummy entry.
+*/
 func (rs *RaftStorage) Compact(idx uint64, isLocked bool) error {
 	if !isLocked {
 		rs.lock.Lock()
@@ -404,10 +467,11 @@ func (rs *RaftStorage) Compact(idx uint64, isLocked bool) error {
 		return nil
 	}
 
-	iter, err := rs.GetPrevIter(idx - 1)
+	iter, err := rs.GetPrevIter(idx)
 	if err != nil {
 		return err
 	}
+	defer iter.Release()
 
 	toRemoveKeys := make([][]byte, 0, idx-rs.firstIdx+1)
 	for iter.Prev() {
@@ -419,6 +483,8 @@ func (rs *RaftStorage) Compact(idx uint64, isLocked bool) error {
 		dbRaft.Delete(key)
 	}
 
+	rs.firstIdx = idx + 1
+
 	return nil
 }
 
@@ -539,6 +605,26 @@ func (rs *RaftStorage) GetTermByIdx(idx uint64) (uint64, error) {
 	return entry.Term, nil
 }
 
+func (rs *RaftStorage) getTermByIdx(idx uint64) (uint64, error) {
+	key, err := rs.MarshalKey(idx)
+	if err != nil {
+		return 0, err
+	}
+
+	data, err := dbRaft.Get(key)
+	if err != nil {
+		return 0, err
+	}
+
+	ent := pb.Entry{}
+	err = ent.Unmarshal(data)
+	if err != nil {
+		return 0, err
+	}
+
+	return ent.Term, nil
+}
+
 func (rs *RaftStorage) GetIter(idx uint64) (iterator.Iterator, error) {
 	startKey, err := rs.MarshalKey(idx)
 	endKey, err := rs.MarshalKey(math.MaxUint64)
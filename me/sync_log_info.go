@@ -0,0 +1,93 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package me
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/*
+SyncLogInfo is the newest sync-oplog (last-seen / locale) of the object.
+The sync-oplogs are coalesced to one per object with last-writer-wins, and the superseded oplogs are compacted.
+*/
+type SyncLogInfo struct {
+	V        types.Version
+	ID       *types.PttID
+	MyID     *types.PttID    `json:"m"`
+	UpdateTS types.Timestamp `json:"UT"`
+	LogID    *types.PttID    `json:"l"`
+}
+
+func (s *SyncLogInfo) Save() error {
+	key, err := s.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	return dbMeCore.Put(key, marshaled)
+}
+
+func (s *SyncLogInfo) Get(myID *types.PttID, id *types.PttID) error {
+	s.MyID = myID
+	s.ID = id
+
+	key, err := s.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	theBytes, err := dbMeCore.Get(key)
+	if err != nil {
+		return err
+	}
+	if len(theBytes) == 0 {
+		return leveldb.ErrNotFound
+	}
+
+	return json.Unmarshal(theBytes, s)
+}
+
+func (s *SyncLogInfo) MarshalKey() ([]byte, error) {
+	return common.Concat([][]byte{DBSyncLogInfoPrefix, s.MyID[:], s.ID[:]})
+}
+
+/*
+isNewerSyncLog checks whether the oplog supersedes the newest sync-oplog of the info.
+The oplog with the same update-ts is ordered by the log-id, so that all my nodes keep the same oplog.
+*/
+func isNewerSyncLog(oplog *pkgservice.BaseOplog, info *SyncLogInfo) bool {
+	if info.LogID == nil {
+		return true
+	}
+
+	if oplog.UpdateTS.IsEqual(info.UpdateTS) {
+		return bytes.Compare(oplog.ID[:], info.LogID[:]) > 0
+	}
+
+	return info.UpdateTS.IsLess(oplog.UpdateTS)
+}
@@ -0,0 +1,59 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package me
+
+import (
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func TestIsNewerSyncLog(t *testing.T) {
+	// prepare test-cases
+	ts := types.Timestamp{Ts: 100}
+	newerTS := types.Timestamp{Ts: 101}
+
+	logID := &types.PttID{2}
+	smallerLogID := &types.PttID{1}
+	largerLogID := &types.PttID{3}
+
+	info := &SyncLogInfo{UpdateTS: ts, LogID: logID}
+
+	tests := []struct {
+		name  string
+		oplog *pkgservice.BaseOplog
+		info  *SyncLogInfo
+		want  bool
+	}{
+		{"no info", &pkgservice.BaseOplog{ID: smallerLogID, UpdateTS: ts}, &SyncLogInfo{}, true},
+		{"newer", &pkgservice.BaseOplog{ID: smallerLogID, UpdateTS: newerTS}, info, true},
+		{"older", &pkgservice.BaseOplog{ID: largerLogID, UpdateTS: types.Timestamp{Ts: 99}}, info, false},
+		{"same ts, larger id", &pkgservice.BaseOplog{ID: largerLogID, UpdateTS: ts}, info, true},
+		{"same ts, smaller id", &pkgservice.BaseOplog{ID: smallerLogID, UpdateTS: ts}, info, false},
+		{"same log", &pkgservice.BaseOplog{ID: logID, UpdateTS: ts}, info, false},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isNewerSyncLog(tt.oplog, tt.info); got != tt.want {
+				t.Errorf("isNewerSyncLog() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
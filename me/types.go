@@ -34,4 +34,15 @@ type RaftStatus struct {
 	ConfState     pb.ConfState `json:"CS"`
 	HardState     pb.HardState `json:"HS"`
 	RSLastIndex   uint64       `json:"li"`
+	RSFirstIndex  uint64       `json:"fi"`
+	SnapshotTerm  uint64       `json:"ST"`
+}
+
+/*
+NodePermission is the bitmask of what a node is allowed to do in the me-cluster.
+*/
+type NodePermission uint8
+
+func (p NodePermission) IsPermitted(perm NodePermission) bool {
+	return p&perm == perm
 }
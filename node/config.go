@@ -43,7 +43,7 @@ import (
 	"time"
 
 	"github.com/ailabstw/go-pttai/common"
-	"github.com/ailabstw/go-pttai/crypto"
+	"github.com/ailabstw/go-pttai/crypto/keystore"
 	"github.com/ailabstw/go-pttai/log"
 	"github.com/ailabstw/go-pttai/p2p"
 	"github.com/ailabstw/go-pttai/p2p/discover"
@@ -76,6 +76,10 @@ type Config struct {
 	// is created by New and destroyed when the node is stopped.
 	KeyStoreDir string `toml:",omitempty"`
 
+	// Passphrase is the passphrase of the encrypted node key-file.
+	// The node key-file is stored in plain if Passphrase is empty.
+	Passphrase string `toml:"-"`
+
 	// IPCPath is the requested location to place the IPC endpoint. If the path is
 	// a simple file name, it is placed inside the data directory (or on the root
 	// pipe path on Windows), whereas if it's a resolvable path name (absolute or
@@ -223,10 +227,14 @@ func (c *Config) NodeKey() *ecdsa.PrivateKey {
 
 	// retrieve key / postfix from file
 	keyfile := c.ResolvePath(DataDirPrivateKey)
-	key, err := discover.LoadECDSA(keyfile)
+	key, err := keystore.LoadECDSA(keyfile, c.Passphrase)
 	if err == nil {
 		return key
 	}
+	if err == keystore.ErrLocked || err == keystore.ErrDecrypt {
+		// never overwrite the encrypted key
+		log.Crit(fmt.Sprintf("Failed to unlock node key: %v", err))
+	}
 
 	log.Warn(fmt.Sprintf("Failed to load key: %v. create a new one.", err))
 	// No persistent key found, generate and store a new one.
@@ -249,11 +257,11 @@ func (c *Config) NodeKey() *ecdsa.PrivateKey {
 }
 
 func (c *Config) SaveKey(filename string, key *ecdsa.PrivateKey) error {
-	return crypto.SaveECDSA(filename, key)
+	return keystore.SaveECDSA(filename, key, c.Passphrase)
 }
 
 func (c *Config) LoadKey(filename string) (*ecdsa.PrivateKey, error) {
-	key, err := crypto.LoadECDSA(filename)
+	key, err := keystore.LoadECDSA(filename, c.Passphrase)
 	if err != nil {
 		return nil, err
 	}
@@ -261,6 +269,58 @@ func (c *Config) LoadKey(filename string) (*ecdsa.PrivateKey, error) {
 	return key, nil
 }
 
+/*
+IsLocked checks whether the node key-file is encrypted and not able to be decrypted with the current passphrase.
+*/
+func (c *Config) IsLocked() bool {
+	if c.P2P.PrivateKey != nil || c.DataDir == "" {
+		return false
+	}
+
+	keyfile := c.ResolvePath(DataDirPrivateKey)
+	if !keystore.IsEncryptedFile(keyfile) {
+		return false
+	}
+
+	_, err := keystore.LoadECDSA(keyfile, c.Passphrase)
+	return err != nil
+}
+
+/*
+Unlock validates the passphrase with the node key-file and sets the passphrase.
+*/
+func (c *Config) Unlock(passphrase string) error {
+	if c.P2P.PrivateKey == nil && c.DataDir != "" {
+		keyfile := c.ResolvePath(DataDirPrivateKey)
+		if keystore.IsEncryptedFile(keyfile) {
+			_, err := keystore.LoadECDSA(keyfile, passphrase)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	c.Passphrase = passphrase
+
+	return nil
+}
+
+/*
+KeyFiles returns the node key-file if exists.
+*/
+func (c *Config) KeyFiles() ([]string, error) {
+	if c.DataDir == "" {
+		return nil, nil
+	}
+
+	keyfile := c.ResolvePath(DataDirPrivateKey)
+	if _, err := os.Stat(keyfile); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	return []string{keyfile}, nil
+}
+
 func (c *Config) RevokeKeyPath() error {
 	instanceDir := filepath.Join(c.DataDir, c.name())
 	keyfile := filepath.Join(instanceDir, DataDirPrivateKey)
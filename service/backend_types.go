@@ -80,15 +80,57 @@ type BackendJoinURL struct {
 	ExpireSecond uint            `json:"e"`
 }
 
+type BackendInvite struct {
+	ID        *types.PttID
+	CreateTS  types.Timestamp `json:"CT"`
+	ExpireTS  types.Timestamp `json:"ET"`
+	MaxUse    uint32          `json:"M"`
+	NUsed     uint32          `json:"U"`
+	InviteeID *types.PttID    `json:"IID"`
+	Status    types.Status    `json:"S"`
+	Hash      []byte          `json:"H"`
+}
+
+func InviteToBackendInvite(invite *Invite) *BackendInvite {
+	return &BackendInvite{
+		ID:        invite.ID,
+		CreateTS:  invite.CreateTS,
+		ExpireTS:  invite.ExpireTS,
+		MaxUse:    invite.MaxUse,
+		NUsed:     invite.NUsed,
+		InviteeID: invite.InviteeID,
+		Status:    invite.Status,
+		Hash:      invite.Hash[:],
+	}
+}
+
 type BackendJoinRequest struct {
 	CreatorID *types.PttID     `json:"C"`
 	NodeID    *discover.NodeID `json:"n"`
 	Hash      []byte           `json:"H"`
 	Name      []byte           `json:"N"`
 	Status    JoinStatus       `json:"S"`
+	SAS       string           `json:"SAS,omitempty"`
 }
 
 func MarshalBackendJoinURL(id *types.PttID, nodeID *discover.NodeID, keyInfo *KeyInfo, name []byte, path string) (*BackendJoinURL, error) {
+	return marshalBackendJoinURL(id, nodeID, keyInfo, name, path, keyInfo.UpdateTS.Ts+IntRenewJoinKeySeconds, IntRenewJoinKeySeconds)
+}
+
+/*
+MarshalBackendInviteURL marshals the join-url with the join-key of the invite.
+*/
+func MarshalBackendInviteURL(id *types.PttID, nodeID *discover.NodeID, invite *Invite, name []byte, path string) (*BackendJoinURL, error) {
+	expireTS := invite.ExpireTS.Ts
+	var expireSecond uint = 0
+	if expireTS != 0 {
+		expireSecond = uint(expireTS - invite.CreateTS.Ts)
+	}
+
+	return marshalBackendJoinURL(id, nodeID, invite.KeyInfo(), name, path, expireTS, expireSecond)
+}
+
+func marshalBackendJoinURL(id *types.PttID, nodeID *discover.NodeID, keyInfo *KeyInfo, name []byte, path string, expireTS int64, expireSecond uint) (*BackendJoinURL, error) {
 	nodeIDBytes, err := nodeID.MarshalText()
 	if err != nil {
 		return nil, err
@@ -114,7 +156,7 @@ func MarshalBackendJoinURL(id *types.PttID, nodeID *discover.NodeID, keyInfo *Ke
 	v.Add("h", keyHashStr)
 	v.Add("k", keyStr)
 	v.Add("n", nameStr)
-	v.Add("t", strconv.FormatInt(keyInfo.UpdateTS.Ts+IntRenewJoinKeySeconds, 10))
+	v.Add("t", strconv.FormatInt(expireTS, 10))
 
 	return &BackendJoinURL{
 		CreatorID:    creatorIDStr,
@@ -123,7 +165,7 @@ func MarshalBackendJoinURL(id *types.PttID, nodeID *discover.NodeID, keyInfo *Ke
 		Pn:           nodeIDStr,
 		URL:          "pnode://" + nodeIDStr + path + "?" + v.Encode(),
 		UpdateTS:     keyInfo.UpdateTS,
-		ExpireSecond: IntRenewJoinKeySeconds,
+		ExpireSecond: expireSecond,
 	}, nil
 }
 
@@ -283,3 +325,29 @@ func MerkleToBackendMerkle(m *Merkle) *BackendMerkle {
 		ExpireGenerateSeconds: m.ExpireGenerateSeconds,
 	}
 }
+
+/*
+BackendKeyReport reports the age and the expiry of the key of the entity.
+ExpireTS is zero if the key does not expire by time (the sign-keys are renewed by the usage count).
+*/
+type BackendKeyReport struct {
+	EntityID    *types.PttID    `json:"EID"`
+	ServiceName string          `json:"SN"`
+	KeyType     string          `json:"T"`
+	KeyID       *types.PttID    `json:"ID"`
+	Hash        *common.Address `json:"H"`
+	CreateTS    types.Timestamp `json:"CT"`
+	AgeSeconds  int64           `json:"A"`
+	ExpireTS    types.Timestamp `json:"ET"`
+	IsExpired   bool            `json:"E"`
+	Count       int             `json:"C"`
+}
+
+type BackendRotateKeys struct {
+	NEntity       int `json:"N"`
+	NOpKey        int `json:"O"`
+	NRevokedOpKey int `json:"R"`
+	NPendingOpKey int `json:"P"`
+	NJoinKey      int `json:"J"`
+	NFailed       int `json:"F"`
+}
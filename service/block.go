@@ -17,6 +17,7 @@
 package service
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/json"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/log"
 	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 )
 
@@ -47,6 +49,9 @@ type Block struct {
 
 	Buf []byte `json:"B,omitempty"`
 
+	// BufHash refers to the content-addressed BlockBlob when the block is stored in the db.
+	BufHash []byte `json:"BH,omitempty"`
+
 	ObjID *types.PttID `json:"o,omitempty"`
 
 	Hash     []byte        `json:"H,omitempty"`
@@ -103,17 +108,94 @@ func (b *Block) Save() error {
 	if err != nil {
 		return err
 	}
-	marshaled, err := b.Marshal()
+
+	db := b.db.DB()
+
+	// the buf is stored as the content-addressed blob.
+	origHash, err := b.getStoredBufHash(key)
+	if err != nil {
+		return err
+	}
+
+	bufHash := origHash
+	if len(b.Buf) != 0 && !bytes.Equal(origHash, BlockBlobHash(b.Buf)) {
+		bufHash, err = RefBlockBlob(db, b.Buf)
+		if err != nil {
+			return err
+		}
+		if origHash != nil {
+			UnrefBlockBlob(db, origHash)
+		}
+	}
+
+	marshaled, err := b.marshalStored(bufHash)
 	if err != nil {
 		return err
 	}
 
 	log.Debug("Block.Save: to put", "key", key)
-	err = b.db.DB().Put(key, marshaled)
+	err = db.Put(key, marshaled)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (b *Block) getStoredBufHash(key []byte) ([]byte, error) {
+	val, err := b.db.DB().Get(key)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	stored := NewEmptyBlock()
+	err = stored.Unmarshal(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return stored.BufHash, nil
+}
+
+/*
+marshalStored marshals the block with the buf replaced by the hash of the blob.
+*/
+func (b *Block) marshalStored(bufHash []byte) ([]byte, error) {
+	if bufHash == nil {
+		return b.Marshal()
+	}
+
+	stored := *b
+	stored.Buf = nil
+	stored.BufHash = bufHash
+
+	return stored.Marshal()
+}
+
+/*
+UnmarshalStored unmarshals the block from the db, and loads the buf from the blob if needed.
+*/
+func (b *Block) UnmarshalStored(theBytes []byte) error {
+	err := b.Unmarshal(theBytes)
+	if err != nil {
+		return err
+	}
+
+	if b.BufHash == nil {
+		return nil
+	}
+
+	buf, err := GetBlockBlobBuf(b.db.DB(), b.BufHash)
 	if err != nil {
 		return err
 	}
 
+	b.Buf = buf
+	b.BufHash = nil
+
 	return nil
 }
 
@@ -124,8 +206,17 @@ func (b *Block) RemoveAll() error {
 	}
 	defer iter.Release()
 
+	db := b.db.DB()
+	var stored *Block
 	for iter.Next() {
 		key := iter.Key()
+
+		stored = NewEmptyBlock()
+		err = stored.Unmarshal(iter.Value())
+		if err == nil && stored.BufHash != nil {
+			UnrefBlockBlob(db, stored.BufHash)
+		}
+
 		b.db.DBDelete(key)
 	}
 
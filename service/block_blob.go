@@ -0,0 +1,174 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/crypto"
+	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/*
+BlockBlob is the content-addressed storage of the buf of a block.
+
+Blocks with identical (scrambled) bufs share the same blob, whichever
+entity / object they belong to. The per-entity block keeps its own
+signature and only refers to the blob by BufHash.
+The blob is removed once RefCount drops to 0.
+*/
+type BlockBlob struct {
+	V        types.Version
+	Hash     []byte `json:"H"`
+	RefCount uint32 `json:"R"`
+	Buf      []byte `json:"B,omitempty"`
+}
+
+var blockBlobLock sync.Mutex
+
+func BlockBlobHash(buf []byte) []byte {
+	return crypto.Keccak256(buf)
+}
+
+func blockBlobKey(hash []byte) ([]byte, error) {
+	return common.Concat([][]byte{DBBlockBlobPrefix, hash})
+}
+
+func getBlockBlob(db *pttdb.LDBDatabase, hash []byte) (*BlockBlob, error) {
+	key, err := blockBlobKey(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	blob := &BlockBlob{}
+	err = json.Unmarshal(val, blob)
+	if err != nil {
+		return nil, err
+	}
+
+	return blob, nil
+}
+
+func saveBlockBlob(db *pttdb.LDBDatabase, blob *BlockBlob) error {
+	key, err := blockBlobKey(blob.Hash)
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := json.Marshal(blob)
+	if err != nil {
+		return err
+	}
+
+	return db.Put(key, marshaled)
+}
+
+/*
+RefBlockBlob stores buf in the content-addressed blob (or increases the ref-count if the blob already exists) and returns the hash of the blob.
+*/
+func RefBlockBlob(db *pttdb.LDBDatabase, buf []byte) ([]byte, error) {
+	hash := BlockBlobHash(buf)
+
+	blockBlobLock.Lock()
+	defer blockBlobLock.Unlock()
+
+	blob, err := getBlockBlob(db, hash)
+	if err == leveldb.ErrNotFound {
+		blob = &BlockBlob{
+			V:    types.CurrentVersion,
+			Hash: hash,
+			Buf:  buf,
+		}
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	blob.RefCount++
+
+	err = saveBlockBlob(db, blob)
+	if err != nil {
+		return nil, err
+	}
+
+	return hash, nil
+}
+
+/*
+UnrefBlockBlob decreases the ref-count of the blob, and removes the blob if no block refers to it.
+*/
+func UnrefBlockBlob(db *pttdb.LDBDatabase, hash []byte) error {
+	blockBlobLock.Lock()
+	defer blockBlobLock.Unlock()
+
+	blob, err := getBlockBlob(db, hash)
+	if err == leveldb.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if blob.RefCount > 1 {
+		blob.RefCount--
+		return saveBlockBlob(db, blob)
+	}
+
+	key, err := blockBlobKey(hash)
+	if err != nil {
+		return err
+	}
+
+	return db.Delete(key)
+}
+
+/*
+GetBlockBlobBuf gets the buf of the blob.
+*/
+func GetBlockBlobBuf(db *pttdb.LDBDatabase, hash []byte) ([]byte, error) {
+	blob, err := getBlockBlob(db, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return blob.Buf, nil
+}
+
+/*
+GetBlockBlobRefCount gets the ref-count of the blob (0 if the blob does not exist).
+*/
+func GetBlockBlobRefCount(db *pttdb.LDBDatabase, hash []byte) (uint32, error) {
+	blob, err := getBlockBlob(db, hash)
+	if err == leveldb.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return blob.RefCount, nil
+}
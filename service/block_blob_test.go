@@ -0,0 +1,138 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+func TestBlock_SaveDedup(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	prefix := []byte(".tbdb")
+	buf := tDefaultScrambleBuf[0]
+	hash := BlockBlobHash(buf)
+
+	objIDs := make([]*types.PttID, 2)
+	blocks := make([]*Block, 2)
+	for i := 0; i < len(blocks); i++ {
+		objIDs[i], _ = types.NewPttID()
+		blockID, _ := types.NewPttID()
+		blocks[i], _ = NewBlock(0, 0, buf)
+		blocks[i].SetDB(tDBOplog, prefix, objIDs[i], blockID)
+	}
+
+	// save
+	for _, block := range blocks {
+		err := block.Save()
+		if err != nil {
+			t.Errorf("Block.Save() error = %v", err)
+			return
+		}
+	}
+
+	// save again should not increase the ref-count
+	err := blocks[0].Save()
+	if err != nil {
+		t.Errorf("Block.Save() error = %v", err)
+		return
+	}
+
+	refCount, _ := GetBlockBlobRefCount(tDBOplogCore, hash)
+	if refCount != 2 {
+		t.Errorf("GetBlockBlobRefCount() = %v, want %v", refCount, 2)
+	}
+
+	// load
+	key, _ := blocks[1].MarshalKey()
+	val, _ := tDBOplogCore.Get(key)
+	got := NewEmptyBlock()
+	got.SetDB(tDBOplog, prefix, nil, nil)
+	err = got.UnmarshalStored(val)
+	if err != nil {
+		t.Errorf("Block.UnmarshalStored() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(got.Buf, buf) {
+		t.Errorf("Block.UnmarshalStored() Buf = %v, want %v", got.Buf, buf)
+	}
+	if got.BufHash != nil {
+		t.Errorf("Block.UnmarshalStored() BufHash = %v, want nil", got.BufHash)
+	}
+
+	// remove
+	block := NewEmptyBlock()
+	block.SetDB(tDBOplog, prefix, objIDs[0], nil)
+	block.RemoveAll()
+
+	refCount, _ = GetBlockBlobRefCount(tDBOplogCore, hash)
+	if refCount != 1 {
+		t.Errorf("GetBlockBlobRefCount() = %v, want %v", refCount, 1)
+	}
+
+	block.SetDB(tDBOplog, prefix, objIDs[1], nil)
+	block.RemoveAll()
+
+	refCount, _ = GetBlockBlobRefCount(tDBOplogCore, hash)
+	if refCount != 0 {
+		t.Errorf("GetBlockBlobRefCount() = %v, want %v", refCount, 0)
+	}
+
+	// teardown test
+}
+
+func TestBlock_SaveCorrupted(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	prefix := []byte(".tbdb")
+	buf := tDefaultScrambleBuf[0]
+	hash := BlockBlobHash(buf)
+
+	objID, _ := types.NewPttID()
+	blockID, _ := types.NewPttID()
+	block, _ := NewBlock(0, 0, buf)
+	block.SetDB(tDBOplog, prefix, objID, blockID)
+
+	err := block.Save()
+	if err != nil {
+		t.Errorf("Block.Save() error = %v", err)
+		return
+	}
+
+	// corrupt the stored block
+	key, _ := block.MarshalKey()
+	tDBOplogCore.Put(key, []byte("corrupted"))
+
+	err = block.Save()
+	if err == nil {
+		t.Errorf("Block.Save() error = nil, want error")
+	}
+
+	refCount, _ := GetBlockBlobRefCount(tDBOplogCore, hash)
+	if refCount != 1 {
+		t.Errorf("GetBlockBlobRefCount() = %v, want %v", refCount, 1)
+	}
+
+	// teardown test
+}
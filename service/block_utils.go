@@ -17,6 +17,7 @@
 package service
 
 import (
+	"github.com/ailabstw/go-pttai/crypto"
 	"github.com/ailabstw/go-pttai/log"
 	"github.com/ailabstw/go-pttai/pttdb"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
@@ -55,7 +56,8 @@ func GetBlockList(blockInfo *BlockInfo, limit uint32, isLocked bool) ([]*Block,
 	for iter.Next() {
 		v = iter.Value()
 		each = NewEmptyBlock()
-		err = each.Unmarshal(v)
+		blockInfo.SetBlockDB(each)
+		err = each.UnmarshalStored(v)
 		if err != nil {
 			continue
 		}
@@ -70,6 +72,29 @@ func GetBlockList(blockInfo *BlockInfo, limit uint32, isLocked bool) ([]*Block,
 	return blocks, nil
 }
 
+/*
+GetBlockSigHash gets the hash of the signatures of all the blocks based on the information of block-info.
+
+The signatures are signed by the updater of the block-info, so the hash refers to the signed content.
+*/
+func GetBlockSigHash(blockInfo *BlockInfo, isLocked bool) ([]byte, error) {
+	blocks, err := GetBlockList(blockInfo, 0, isLocked)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(blocks) != blockInfo.NBlock*NSubBlock {
+		return nil, ErrInvalidBlock
+	}
+
+	sigs := make([][]byte, len(blocks))
+	for i, block := range blocks {
+		sigs[i] = block.Sig
+	}
+
+	return crypto.Keccak256(sigs...), nil
+}
+
 /*
 GetContentBlockList gets the block list based on the information of block-info.
 
@@ -109,7 +134,7 @@ func GetContentBlockList(blockInfo *BlockInfo, limit uint32, isLocked bool) ([]*
 		v = iter.Value()
 		each = NewEmptyBlock()
 		blockInfo.SetBlockDB(each)
-		err = each.Unmarshal(v)
+		err = each.UnmarshalStored(v)
 		if err != nil {
 			continue
 		}
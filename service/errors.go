@@ -69,6 +69,17 @@ var (
 
 	ErrInvalidKeyInfo = errors.New("invalid key info")
 
+	ErrInvalidInvite = errors.New("invalid invite")
+
+	ErrInvalidMemberRole = errors.New("invalid member role")
+
+	ErrInvalidQuorum = errors.New("invalid quorum")
+
+	ErrBlocked = errors.New("blocked")
+
+	ErrBanned = errors.New("banned")
+	ErrMuted  = errors.New("muted")
+
 	ErrNotFound = errors.New("not found")
 
 	ErrNoPeer = errors.New("no peer")
@@ -91,6 +102,8 @@ var (
 
 	ErrNotAlive = errors.New("not alive")
 
+	ErrNotPermitted = errors.New("not permitted")
+
 	ErrInvalidFunc = errors.New("invalid function")
 )
 
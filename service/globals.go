@@ -75,6 +75,35 @@ const (
 	RenewJoinKeySeconds    = time.Duration(IntRenewJoinKeySeconds) * time.Second
 )
 
+// rotate-keys
+const (
+	RotateOpKeyGraceSeconds = 300 * time.Second // revoke the old op-keys after the grace period if the new op-key is not valid yet.
+)
+
+var (
+	PublicJoinKeySalt = []byte("ptt-public-join")
+)
+
+// qrcode
+const (
+	QRCodeDefaultSize = 256
+	QRCodeMinSize     = 64
+	QRCodeMaxSize     = 1024
+)
+
+// public entities
+const (
+	PublicEntityTopicPrefix = "ptt-public-entity-"
+
+	PublicEntityTickTime     = 60 * time.Second
+	PublicEntitySearchPeriod = 10 * time.Second
+	PublicEntityExpireTime   = 30 * time.Minute
+	PublicEntityFindTimeout  = 30 * time.Second
+
+	MaxPublicEntitiesPerPeer = 100
+	MaxPublicEntities        = 5000
+)
+
 // msg
 const (
 	_ OpType = iota
@@ -174,6 +203,27 @@ var (
 	DBMemberMerkleOplogPrefix = []byte(".mbmk")
 )
 
+// reader
+var (
+	DBReaderPrefix = []byte(".rddb")
+)
+
+// master-quorum
+var (
+	DBMasterQuorumPrefix = []byte(".mqdb")
+)
+
+// invite
+var (
+	DBInvitePrefix = []byte(".ivdb")
+)
+
+// member-role
+var (
+	DBMemberRolePrefix = []byte(".mrdb")
+	DBMemberBanPrefix  = []byte(".mndb")
+)
+
 // op-key
 const (
 	MaxIterDeriveKeyBIP32 = 10
@@ -190,6 +240,14 @@ var (
 	DBOpKeyIdxOplogPrefix = []byte(".okig")
 )
 
+// key-report
+const (
+	KeyReportTypeJoin     = "join"
+	KeyReportTypeOp       = "op"
+	KeyReportTypeSign     = "sign"
+	KeyReportTypeNodeSign = "nodeSign"
+)
+
 // oplog
 const (
 	MaxSyncOplogAck = 200
@@ -214,6 +272,8 @@ var (
 	DBBlockInfoIdxPrefix = []byte(".biix")
 
 	DBContentBlockPrefix = []byte(".bkdb")
+
+	DBBlockBlobPrefix = []byte(".bbdb")
 )
 
 // media
@@ -258,6 +318,15 @@ var (
 
 	DBLocalePrefix     = []byte(".locl")
 	DBPttLogSeenPrefix = []byte(".ptsn")
+
+	DBBlockedUserPrefix = []byte(".blus")
+
+	DBPresencePrefix     = []byte(".prsn")
+	DBHidePresencePrefix = []byte(".hdps")
+
+	DBNotificationPrefix    = []byte(".ntdb")
+	DBNotificationIdxPrefix = []byte(".ntix")
+	DBNotifySettingPrefix   = []byte(".ntst")
 )
 
 // oplog
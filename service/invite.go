@@ -0,0 +1,155 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/crypto"
+	"github.com/ailabstw/go-pttai/pttdb"
+)
+
+/*
+Invite is a join-key with the max-use count, the expire-ts and the optional bound invitee.
+*/
+type Invite struct {
+	V         types.Version
+	ID        *types.PttID    `json:"ID"`
+	EntityID  *types.PttID    `json:"EID"`
+	CreatorID *types.PttID    `json:"CID"`
+	CreateTS  types.Timestamp `json:"CT"`
+	ExpireTS  types.Timestamp `json:"ET"`
+	MaxUse    uint32          `json:"M"`
+	NUsed     uint32          `json:"U"`
+	InviteeID *types.PttID    `json:"IID,omitempty"`
+	Status    types.Status    `json:"S"`
+
+	Hash     *common.Address `json:"H"`
+	KeyBytes []byte          `json:"K"`
+
+	keyInfo *KeyInfo
+}
+
+func NewInvite(entityID *types.PttID, creatorID *types.PttID, expireTS types.Timestamp, maxUse uint32, inviteeID *types.PttID) (*Invite, error) {
+	keyInfo, err := NewJoinKeyInfo(entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Invite{
+		V:         types.CurrentVersion,
+		ID:        keyInfo.ID,
+		EntityID:  entityID,
+		CreatorID: creatorID,
+		CreateTS:  keyInfo.UpdateTS,
+		ExpireTS:  expireTS,
+		MaxUse:    maxUse,
+		InviteeID: inviteeID,
+		Status:    types.StatusAlive,
+
+		Hash:     keyInfo.Hash,
+		KeyBytes: keyInfo.KeyBytes,
+
+		keyInfo: keyInfo,
+	}, nil
+}
+
+func (i *Invite) MarshalKey() ([]byte, error) {
+	return common.Concat([][]byte{DBInvitePrefix, i.EntityID[:], i.ID[:]})
+}
+
+func (i *Invite) Save(db *pttdb.LDBBatch) error {
+	key, err := i.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := json.Marshal(i)
+	if err != nil {
+		return err
+	}
+
+	return db.DB().Put(key, marshaled)
+}
+
+func (i *Invite) Unmarshal(theBytes []byte) error {
+	err := json.Unmarshal(theBytes, i)
+	if err != nil {
+		return err
+	}
+
+	key, err := crypto.ToECDSA(i.KeyBytes)
+	if err != nil {
+		return err
+	}
+
+	i.keyInfo = joinKeyToKeyInfo(key)
+	i.keyInfo.Hash = i.Hash
+	i.keyInfo.UpdateTS = i.CreateTS
+
+	return nil
+}
+
+func (i *Invite) KeyInfo() *KeyInfo {
+	return i.keyInfo
+}
+
+/*
+IsValid returns whether the invite is still able to be used at ts.
+*/
+func (i *Invite) IsValid(ts types.Timestamp) bool {
+	if i.Status != types.StatusAlive {
+		return false
+	}
+
+	if i.ExpireTS.Ts != 0 && i.ExpireTS.IsLess(ts) {
+		return false
+	}
+
+	if i.MaxUse != 0 && i.NUsed >= i.MaxUse {
+		return false
+	}
+
+	return true
+}
+
+func getInviteList(db *pttdb.LDBBatch, entityID *types.PttID) ([]*Invite, error) {
+	prefix, err := common.Concat([][]byte{DBInvitePrefix, entityID[:]})
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := db.DB().NewIteratorWithPrefix(nil, prefix, pttdb.ListOrderNext)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	invites := make([]*Invite, 0)
+	for iter.Next() {
+		invite := &Invite{}
+		err = invite.Unmarshal(iter.Value())
+		if err != nil {
+			continue
+		}
+		invites = append(invites, invite)
+	}
+
+	return invites, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+func TestInvite_IsValid(t *testing.T) {
+	ts := types.Timestamp{Ts: 1000}
+
+	// prepare test-cases
+	tests := []struct {
+		name   string
+		invite *Invite
+		want   bool
+	}{
+		{
+			name:   "unlimited",
+			invite: &Invite{Status: types.StatusAlive},
+			want:   true,
+		},
+		{
+			name:   "revoked",
+			invite: &Invite{Status: types.StatusDeleted},
+			want:   false,
+		},
+		{
+			name:   "expired",
+			invite: &Invite{Status: types.StatusAlive, ExpireTS: types.Timestamp{Ts: 999}},
+			want:   false,
+		},
+		{
+			name:   "not expired",
+			invite: &Invite{Status: types.StatusAlive, ExpireTS: types.Timestamp{Ts: 1001}},
+			want:   true,
+		},
+		{
+			name:   "used up",
+			invite: &Invite{Status: types.StatusAlive, MaxUse: 1, NUsed: 1},
+			want:   false,
+		},
+		{
+			name:   "not used up",
+			invite: &Invite{Status: types.StatusAlive, MaxUse: 2, NUsed: 1},
+			want:   true,
+		},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.invite.IsValid(ts); got != tt.want {
+				t.Errorf("Invite.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
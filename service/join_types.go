@@ -45,6 +45,7 @@ const (
 	JoinStatusRequested
 	JoinStatusWaitAccepted
 	JoinStatusAccepted
+	JoinStatusWaitConfirmed
 )
 
 // JoinRequest
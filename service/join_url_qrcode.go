@@ -0,0 +1,120 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+type QRCodeFormat string
+
+const (
+	QRCodeFormatPNG QRCodeFormat = "png"
+	QRCodeFormatSVG QRCodeFormat = "svg"
+)
+
+/*
+BackendJoinURLQRCode is the QR-code of the join-url.
+The join-url is valid until UpdateTS + ExpireSecond, the QR-code needs to be refreshed afterwards.
+*/
+type BackendJoinURLQRCode struct {
+	URL          string
+	ContentType  string          `json:"T"`
+	Buf          []byte          `json:"B"`
+	UpdateTS     types.Timestamp `json:"UT"`
+	ExpireSecond uint            `json:"e"`
+}
+
+func ParseQRCodeFormat(format string) (QRCodeFormat, error) {
+	switch QRCodeFormat(format) {
+	case "", QRCodeFormatPNG:
+		return QRCodeFormatPNG, nil
+	case QRCodeFormatSVG:
+		return QRCodeFormatSVG, nil
+	}
+
+	return "", ErrInvalidData
+}
+
+/*
+JoinURLToQRCode renders the join-url as a png or svg QR-code.
+size is the width in pixels, 0 as QRCodeDefaultSize.
+*/
+func JoinURLToQRCode(joinURL *BackendJoinURL, formatStr string, size int) (*BackendJoinURLQRCode, error) {
+	format, err := ParseQRCodeFormat(formatStr)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case size == 0:
+		size = QRCodeDefaultSize
+	case size < QRCodeMinSize:
+		size = QRCodeMinSize
+	case size > QRCodeMaxSize:
+		size = QRCodeMaxSize
+	}
+
+	q, err := qrcode.New(joinURL.URL, qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf []byte
+	var contentType string
+	switch format {
+	case QRCodeFormatPNG:
+		buf, err = q.PNG(size)
+		contentType = "image/png"
+	case QRCodeFormatSVG:
+		buf = qrCodeToSVG(q.Bitmap(), size)
+		contentType = "image/svg+xml"
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &BackendJoinURLQRCode{
+		URL:          joinURL.URL,
+		ContentType:  contentType,
+		Buf:          buf,
+		UpdateTS:     joinURL.UpdateTS,
+		ExpireSecond: joinURL.ExpireSecond,
+	}, nil
+}
+
+func qrCodeToSVG(bitmap [][]bool, size int) []byte {
+	n := len(bitmap)
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, n, n)
+	fmt.Fprintf(buf, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, n, n)
+	for y, row := range bitmap {
+		for x, isBlack := range row {
+			if isBlack {
+				fmt.Fprintf(buf, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	buf.WriteString(`"/></svg>`)
+
+	return buf.Bytes()
+}
@@ -17,7 +17,10 @@
 package service
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
 	"crypto/ecdsa"
+	"crypto/rand"
 
 	"github.com/ailabstw/go-pttai/common"
 	"github.com/ailabstw/go-pttai/common/types"
@@ -33,6 +36,7 @@ type KeyInfo struct {
 
 	Key         *ecdsa.PrivateKey `json:"-"`
 	KeyBytes    []byte            `json:"K"`
+	EncKeyBytes []byte            `json:"eK,omitempty"`
 	PubKeyBytes []byte            `json:"-"`
 
 	UpdateTS types.Timestamp `json:"UT"`
@@ -59,6 +63,23 @@ func NewJoinKeyInfo(entityID *types.PttID) (*KeyInfo, error) {
 	return newKeyInfo(extendedKey, nil, entityID, nil)
 }
 
+/*
+NewPublicJoinKeyInfo derives the join-key of a public entity.
+The key is deterministic from the entity-id, so anyone knowing the entity-id is able to join as a reader.
+*/
+func NewPublicJoinKeyInfo(entityID *types.PttID) (*KeyInfo, error) {
+	key, err := derivePublicJoinKey(entityID)
+	if err != nil {
+		return nil, err
+	}
+	extendedKey, err := bip32.PrivKeyToExtKey(key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return newKeyInfo(extendedKey, nil, entityID, nil)
+}
+
 func NewOpKeyInfo(entityID *types.PttID, doerID *types.PttID, masterKey *ecdsa.PrivateKey) (*KeyInfo, error) {
 	key, extra, err := deriveOpKey(masterKey)
 	if err != nil {
@@ -127,6 +148,10 @@ func deriveJoinKey() (*ecdsa.PrivateKey, error) {
 	return crypto.GenerateKey()
 }
 
+func derivePublicJoinKey(entityID *types.PttID) (*ecdsa.PrivateKey, error) {
+	return crypto.ToECDSA(crypto.Keccak256(PublicJoinKeySalt, entityID[:]))
+}
+
 func deriveOpKey(masterKey *ecdsa.PrivateKey) (*bip32.ExtendedKey, *KeyExtraInfo, error) {
 	return deriveKeyBIP32(masterKey)
 }
@@ -166,3 +191,56 @@ func deriveKeyBIP32(masterKey *ecdsa.PrivateKey) (*bip32.ExtendedKey, *KeyExtraI
 
 	return extendedKey, extra, nil
 }
+
+/**********
+ * Encrypt at rest
+ **********/
+
+var keyInfoAEAD cipher.AEAD
+
+/*
+SetKeyInfoDBKey sets the key to encrypt the KeyBytes of the key-infos stored in the db (as EncKeyBytes).
+The KeyBytes are stored in plain if the key is not set.
+*/
+func SetKeyInfoDBKey(dbKey []byte) error {
+	block, err := aes.NewCipher(dbKey)
+	if err != nil {
+		return err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+
+	keyInfoAEAD = aead
+
+	return nil
+}
+
+func (k *KeyInfo) encryptKeyBytes() ([]byte, error) {
+	if k.ID == nil {
+		return nil, ErrInvalidKeyInfo
+	}
+
+	nonce := make([]byte, keyInfoAEAD.NonceSize())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return keyInfoAEAD.Seal(nonce, nonce, k.KeyBytes, k.ID[:]), nil
+}
+
+func (k *KeyInfo) decryptKeyBytes() ([]byte, error) {
+	if keyInfoAEAD == nil || k.ID == nil {
+		return nil, ErrInvalidKeyInfo
+	}
+
+	nonceSize := keyInfoAEAD.NonceSize()
+	if len(k.EncKeyBytes) < nonceSize {
+		return nil, ErrInvalidKeyInfo
+	}
+
+	return keyInfoAEAD.Open(nil, k.EncKeyBytes[:nonceSize], k.EncKeyBytes[nonceSize:], k.ID[:])
+}
@@ -0,0 +1,66 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/crypto"
+)
+
+func TestKeyInfo_MarshalEncrypted(t *testing.T) {
+	origAEAD := keyInfoAEAD
+	defer func() { keyInfoAEAD = origAEAD }()
+
+	err := SetKeyInfoDBKey(crypto.Keccak256([]byte("db-key")))
+	if err != nil {
+		t.Fatalf("SetKeyInfoDBKey: e: %v", err)
+	}
+
+	key, _ := crypto.GenerateKey()
+	k := &KeyInfo{
+		BaseObject: &BaseObject{ID: &types.PttID{1}},
+		KeyBytes:   crypto.FromECDSA(key),
+	}
+
+	marshaled, err := k.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: e: %v", err)
+	}
+	if k.EncKeyBytes != nil {
+		t.Errorf("Marshal: EncKeyBytes is set in the key-info")
+	}
+
+	k2 := &KeyInfo{}
+	err = k2.Unmarshal(marshaled)
+	if err != nil {
+		t.Fatalf("Unmarshal: e: %v", err)
+	}
+	if !reflect.DeepEqual(k2.KeyBytes, k.KeyBytes) {
+		t.Errorf("Unmarshal: KeyBytes: %v want: %v", k2.KeyBytes, k.KeyBytes)
+	}
+
+	// not stored in plain.
+	k3 := &KeyInfo{}
+	keyInfoAEAD = nil
+	err = k3.Unmarshal(marshaled)
+	if err == nil || k3.KeyBytes != nil {
+		t.Errorf("Unmarshal: without db-key: KeyBytes: %v e: %v", k3.KeyBytes, err)
+	}
+}
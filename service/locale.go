@@ -16,6 +16,8 @@
 
 package service
 
+import "github.com/ailabstw/go-pttai/common/types"
+
 type Locale uint8
 
 const (
@@ -41,8 +43,52 @@ func LoadLocale() Locale {
 	return Locale(value[0])
 }
 
+/*
+LoadLocaleTS loads the timestamp of the locale, the locale is stored as the locale followed by the timestamp.
+*/
+func LoadLocaleTS() types.Timestamp {
+	value, err := dbMeta.Get(DBLocalePrefix)
+	if err != nil || len(value) <= 1 {
+		return types.ZeroTimestamp
+	}
+
+	ts, err := types.UnmarshalTimestamp(value[1:])
+	if err != nil {
+		return types.ZeroTimestamp
+	}
+
+	return ts
+}
+
 func SetLocale(locale Locale) error {
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return err
+	}
+
+	_, err = SetLocaleWithTS(locale, ts)
+	return err
+}
+
+/*
+SetLocaleWithTS sets the locale with last-writer-wins, returning false if the stored locale is newer.
+*/
+func SetLocaleWithTS(locale Locale, ts types.Timestamp) (bool, error) {
+	if ts.IsLess(LoadLocaleTS()) {
+		return false, nil
+	}
+
+	tsBytes, err := ts.Marshal()
+	if err != nil {
+		return false, err
+	}
+
 	CurrentLocale = locale
-	value := []byte{uint8(locale)}
-	return dbMeta.Put(DBLocalePrefix, value)
+	value := append([]byte{uint8(locale)}, tsBytes...)
+	err = dbMeta.Put(DBLocalePrefix, value)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
@@ -16,12 +16,36 @@
 
 package service
 
+import "github.com/ailabstw/go-pttai/common/types"
+
 const (
 	_ OpType = iota
 	MasterOpTypeAddMaster
 	MasterOpTypeMigrateMaster
 	MasterOpTypeTransferMaster
+
+	MasterOpTypeSetMemberRole
+	MasterOpTypeBanMember
+	MasterOpTypeMuteMember
+
+	MasterOpTypeSetMasterQuorum
 )
 
 type MasterOpCreateMaster struct {
 }
+
+type MasterOpSetMemberRole struct {
+	Role MemberRole `json:"R"`
+}
+
+type MasterOpBanMember struct {
+	IsBanned bool `json:"B"`
+}
+
+type MasterOpMuteMember struct {
+	ExpireTS types.Timestamp `json:"E"`
+}
+
+type MasterOpSetMasterQuorum struct {
+	Quorum uint32 `json:"Q"`
+}
@@ -33,6 +33,12 @@ type Member struct {
 	TransferToID *types.PttID `json:"t,omitempty"`
 
 	SyncInfo *SyncPersonInfo `json:"s,omitempty"`
+
+	Role MemberRole `json:"R,omitempty"`
+
+	// presence, set in GetMemberList.
+	Online         bool             `json:"o,omitempty"`
+	LastSeenOnline *types.Timestamp `json:"lo,omitempty"`
 }
 
 func NewMember(
@@ -0,0 +1,148 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/pttdb"
+)
+
+/*
+MemberBanInfo is the ban / mute status of the user in the entity, set by the masters.
+The banned users are not able to join the entity again,
+and the oplogs creating the articles / comments from the muted users are rejected from MuteUpdateTS until MuteExpireTS.
+*/
+type MemberBanInfo struct {
+	V        types.Version
+	ID       *types.PttID `json:"ID"`
+	EntityID *types.PttID `json:"EID"`
+
+	IsBanned    bool            `json:"B"`
+	BanUpdateTS types.Timestamp `json:"BT"`
+	BanLogID    *types.PttID    `json:"Bl,omitempty"`
+
+	MuteExpireTS types.Timestamp `json:"ME"`
+	MuteUpdateTS types.Timestamp `json:"MT"`
+	MuteLogID    *types.PttID    `json:"Ml,omitempty"`
+}
+
+func NewMemberBanInfo(entityID *types.PttID, id *types.PttID) *MemberBanInfo {
+	return &MemberBanInfo{
+		V:        types.CurrentVersion,
+		ID:       id,
+		EntityID: entityID,
+	}
+}
+
+/*
+IsBannedAt returns whether the user is banned at ts.
+The ban set after ts is not applied retroactively.
+*/
+func (b *MemberBanInfo) IsBannedAt(ts types.Timestamp) bool {
+	return b.IsBanned && !ts.IsLess(b.BanUpdateTS)
+}
+
+/*
+IsMuted returns whether the user is muted at ts,
+that is, ts is within [MuteUpdateTS, MuteExpireTS).
+*/
+func (b *MemberBanInfo) IsMuted(ts types.Timestamp) bool {
+	return !ts.IsLess(b.MuteUpdateTS) && ts.IsLess(b.MuteExpireTS)
+}
+
+func marshalMemberBanKey(entityID *types.PttID, id *types.PttID) ([]byte, error) {
+	return common.Concat([][]byte{DBMemberBanPrefix, entityID[:], id[:]})
+}
+
+func (b *MemberBanInfo) Save(db *pttdb.LDBBatch) error {
+	key, err := marshalMemberBanKey(b.EntityID, b.ID)
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+
+	return db.DB().Put(key, marshaled)
+}
+
+func getMemberBanInfo(db *pttdb.LDBBatch, entityID *types.PttID, id *types.PttID) (*MemberBanInfo, error) {
+	key, err := marshalMemberBanKey(entityID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := db.DBGet(key)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &MemberBanInfo{}
+	err = json.Unmarshal(val, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func getMemberBanInfoList(db *pttdb.LDBBatch, entityID *types.PttID) ([]*MemberBanInfo, error) {
+	prefix, err := common.Concat([][]byte{DBMemberBanPrefix, entityID[:]})
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := db.DB().NewIteratorWithPrefix(nil, prefix, pttdb.ListOrderNext)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	bans := make([]*MemberBanInfo, 0)
+	for iter.Next() {
+		b := &MemberBanInfo{}
+		err = json.Unmarshal(iter.Value(), b)
+		if err != nil {
+			continue
+		}
+		bans = append(bans, b)
+	}
+
+	return bans, nil
+}
+
+func cleanMemberBanInfo(db *pttdb.LDBBatch, entityID *types.PttID) error {
+	bans, err := getMemberBanInfoList(db, entityID)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range bans {
+		key, err := marshalMemberBanKey(entityID, b.ID)
+		if err != nil {
+			continue
+		}
+		db.DB().Delete(key)
+	}
+
+	return nil
+}
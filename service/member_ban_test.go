@@ -0,0 +1,77 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+func TestMemberBanInfo_IsMuted(t *testing.T) {
+	b := &MemberBanInfo{
+		MuteUpdateTS: types.Timestamp{Ts: 1000},
+		MuteExpireTS: types.Timestamp{Ts: 2000},
+	}
+
+	// prepare test-cases
+	tests := []struct {
+		name string
+		ts   types.Timestamp
+		want bool
+	}{
+		{
+			name: "before mute",
+			ts:   types.Timestamp{Ts: 999},
+			want: false,
+		},
+		{
+			name: "muted",
+			ts:   types.Timestamp{Ts: 1000},
+			want: true,
+		},
+		{
+			name: "expired",
+			ts:   types.Timestamp{Ts: 2000},
+			want: false,
+		},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.IsMuted(tt.ts); got != tt.want {
+				t.Errorf("MemberBanInfo.IsMuted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemberBanInfo_IsBannedAt(t *testing.T) {
+	b := &MemberBanInfo{
+		IsBanned:    true,
+		BanUpdateTS: types.Timestamp{Ts: 1000},
+	}
+
+	if b.IsBannedAt(types.Timestamp{Ts: 999}) {
+		t.Errorf("MemberBanInfo.IsBannedAt() before ban = true, want false")
+	}
+
+	if !b.IsBannedAt(types.Timestamp{Ts: 1000}) {
+		t.Errorf("MemberBanInfo.IsBannedAt() after ban = false, want true")
+	}
+}
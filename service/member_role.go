@@ -0,0 +1,156 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/pttdb"
+)
+
+/*
+MemberRole is the role of the member in the entity, assigned by the masters.
+
+    Member: the default role, able to create and to delete the own objects.
+    Moderator: able to delete the objects created by the others as well.
+    Poster: able to create and to delete the own objects, but not the entity-level settings.
+    ReadOnly: able to read and to sync only.
+*/
+type MemberRole uint8
+
+const (
+	MemberRoleMember MemberRole = iota
+	MemberRoleModerator
+	MemberRolePoster
+	MemberRoleReadOnly
+
+	NMemberRole
+)
+
+var memberRoleStr = map[MemberRole]string{
+	MemberRoleMember:    "member",
+	MemberRoleModerator: "moderator",
+	MemberRolePoster:    "poster",
+	MemberRoleReadOnly:  "read-only",
+}
+
+func (r MemberRole) String() string {
+	str, ok := memberRoleStr[r]
+	if !ok {
+		return "unknown"
+	}
+	return str
+}
+
+func (r MemberRole) IsValid() bool {
+	return r < NMemberRole
+}
+
+/*
+MemberRoleInfo is the role of the member, stored separately from the member-object
+because the master-oplogs are possibly synced before the member-oplogs.
+*/
+type MemberRoleInfo struct {
+	V        types.Version
+	ID       *types.PttID    `json:"ID"`
+	EntityID *types.PttID    `json:"EID"`
+	Role     MemberRole      `json:"R"`
+	UpdateTS types.Timestamp `json:"UT"`
+	LogID    *types.PttID    `json:"l"`
+}
+
+func marshalMemberRoleKey(entityID *types.PttID, id *types.PttID) ([]byte, error) {
+	return common.Concat([][]byte{DBMemberRolePrefix, entityID[:], id[:]})
+}
+
+func (r *MemberRoleInfo) Save(db *pttdb.LDBBatch) error {
+	key, err := marshalMemberRoleKey(r.EntityID, r.ID)
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	return db.DB().Put(key, marshaled)
+}
+
+func getMemberRoleInfo(db *pttdb.LDBBatch, entityID *types.PttID, id *types.PttID) (*MemberRoleInfo, error) {
+	key, err := marshalMemberRoleKey(entityID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := db.DBGet(key)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &MemberRoleInfo{}
+	err = json.Unmarshal(val, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func getMemberRoleInfoList(db *pttdb.LDBBatch, entityID *types.PttID) ([]*MemberRoleInfo, error) {
+	prefix, err := common.Concat([][]byte{DBMemberRolePrefix, entityID[:]})
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := db.DB().NewIteratorWithPrefix(nil, prefix, pttdb.ListOrderNext)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	roles := make([]*MemberRoleInfo, 0)
+	for iter.Next() {
+		r := &MemberRoleInfo{}
+		err = json.Unmarshal(iter.Value(), r)
+		if err != nil {
+			continue
+		}
+		roles = append(roles, r)
+	}
+
+	return roles, nil
+}
+
+func cleanMemberRoleInfo(db *pttdb.LDBBatch, entityID *types.PttID) error {
+	roles, err := getMemberRoleInfoList(db, entityID)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range roles {
+		key, err := marshalMemberRoleKey(entityID, r.ID)
+		if err != nil {
+			continue
+		}
+		db.DB().Delete(key)
+	}
+
+	return nil
+}
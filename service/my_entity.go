@@ -45,10 +45,15 @@ type MyEntity interface {
 	SignBlock(block *Block) error
 
 	IsValidInternalOplog(signInfos []*SignInfo) (*types.PttID, uint32, bool)
+	IsPostableInternalOplog(signInfos []*SignInfo) bool
+	IsPostable() bool
 
 	CreateEntityOplog(entity Entity) error
 	CreateJoinEntityOplog(entity Entity) error
 
+	SyncSeen(seenType SeenType, entityID *types.PttID, objID *types.PttID, ts types.Timestamp) error
+	SyncLocale(locale Locale, ts types.Timestamp) error
+
 	GetValidateKey() *types.PttID
 }
 
@@ -58,6 +63,8 @@ type PttMyEntity interface {
 	MyPM() MyProtocolManager
 
 	SignKey() *KeyInfo
+	NodeSignKey() *KeyInfo
+	RenewSignKeys() error
 
 	// join
 	GetJoinRequest(hash *common.Address) (*JoinRequest, error)
@@ -0,0 +1,209 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/crypto"
+)
+
+type NotificationType uint8
+
+const (
+	_ NotificationType = iota
+
+	// I am mentioned (@userID) in the article / comment.
+	NotificationTypeMention
+
+	// the comment on my article.
+	NotificationTypeReply
+
+	// the push-comment on my article.
+	NotificationTypePush
+
+	// the user joins with my join-friend-key.
+	NotificationTypeFriendRequest
+
+	// the user joins my board with my invite.
+	NotificationTypeBoardInvite
+)
+
+/*
+Notification is the local notification record with read / unread state.
+
+The id is derived from the type and the related ids,
+so the same event (ex: re-synced objects, retried joins) creates only one notification.
+*/
+type Notification struct {
+	V        types.Version
+	ID       *types.PttID     `json:"ID"`
+	CreateTS types.Timestamp  `json:"CT"`
+	Type     NotificationType `json:"T"`
+
+	FromID   *types.PttID `json:"f"`
+	EntityID *types.PttID `json:"e,omitempty"`
+	ObjID    *types.PttID `json:"o,omitempty"`
+	SubObjID *types.PttID `json:"so,omitempty"`
+
+	IsRead bool `json:"R,omitempty"`
+}
+
+func NewNotification(theType NotificationType, fromID *types.PttID, entityID *types.PttID, objID *types.PttID, subObjID *types.PttID) (*Notification, error) {
+	if fromID == nil {
+		return nil, types.ErrInvalidID
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notification{
+		V:        types.CurrentVersion,
+		ID:       notificationID(theType, fromID, entityID, objID, subObjID),
+		CreateTS: ts,
+		Type:     theType,
+		FromID:   fromID,
+		EntityID: entityID,
+		ObjID:    objID,
+		SubObjID: subObjID,
+	}, nil
+}
+
+/*
+notificationID is the hash of the type and the ids, with the address of the from-id as the postfix.
+*/
+func notificationID(theType NotificationType, fromID *types.PttID, entityID *types.PttID, objID *types.PttID, subObjID *types.PttID) *types.PttID {
+	bufs := [][]byte{{uint8(theType)}, fromID[:]}
+	for _, eachID := range []*types.PttID{entityID, objID, subObjID} {
+		if eachID == nil {
+			bufs = append(bufs, nil)
+			continue
+		}
+		bufs = append(bufs, eachID[:])
+	}
+
+	hash := crypto.Keccak256(bufs...)
+
+	id := &types.PttID{}
+	copy(id[:common.AddressLength], hash)
+	copy(id[common.AddressLength:], fromID[:common.AddressLength])
+
+	return id
+}
+
+func (n *Notification) MarshalKey() ([]byte, error) {
+	return common.Concat([][]byte{DBNotificationPrefix, n.ID[:]})
+}
+
+func (n *Notification) MarshalIdxKey() ([]byte, error) {
+	tsBytes, err := n.CreateTS.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return common.Concat([][]byte{DBNotificationIdxPrefix, tsBytes, n.ID[:]})
+}
+
+func (n *Notification) Save() error {
+	key, err := n.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	return dbMeta.Put(key, marshaled)
+}
+
+func (n *Notification) Get(id *types.PttID) error {
+	n.ID = id
+	key, err := n.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := dbMeta.Get(key)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(marshaled, n)
+}
+
+/*
+ParseMentions parses the @userID mentions in the content-block bufs.
+*/
+func ParseMentions(bufs [][]byte) []*types.PttID {
+	ids := make([]*types.PttID, 0)
+	for _, buf := range bufs {
+		for {
+			idx := bytes.IndexByte(buf, '@')
+			if idx < 0 {
+				break
+			}
+			buf = buf[idx+1:]
+
+			end := 0
+			for end < len(buf) && isMentionChar(buf[end]) {
+				end++
+			}
+
+			id, err := types.UnmarshalTextPttID(buf[:end], false)
+			buf = buf[end:]
+			if err != nil || isInIDs(id, ids) {
+				continue
+			}
+
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+func isMentionChar(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isInIDs(id *types.PttID, ids []*types.PttID) bool {
+	for _, eachID := range ids {
+		if *eachID == *id {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+IsMentioned checks whether the id is mentioned in the content-block bufs.
+*/
+func IsMentioned(bufs [][]byte, id *types.PttID) bool {
+	if id == nil {
+		return false
+	}
+
+	return isInIDs(id, ParseMentions(bufs))
+}
@@ -0,0 +1,69 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+func TestParseMentions(t *testing.T) {
+	id0 := &types.PttID{1, 2, 3}
+	id1 := &types.PttID{4, 5, 6}
+
+	text0, _ := id0.MarshalText()
+	text1, _ := id1.MarshalText()
+
+	bufs := [][]byte{
+		[]byte("hi @" + string(text0) + ", how are you?"),
+		[]byte("@" + string(text1) + " @" + string(text0) + " @invalid"),
+		[]byte("no mention@"),
+	}
+
+	got := ParseMentions(bufs)
+	want := []*types.PttID{id0, id1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseMentions() = %v, want %v", got, want)
+	}
+
+	if !IsMentioned(bufs, id1) {
+		t.Errorf("IsMentioned() = false, want true")
+	}
+	if IsMentioned(bufs[2:], id0) {
+		t.Errorf("IsMentioned() = true, want false")
+	}
+}
+
+func TestNotifySetting_IsNotify(t *testing.T) {
+	tests := []struct {
+		setting   NotifySetting
+		isMention bool
+		want      bool
+	}{
+		{NotifySettingAll, false, true},
+		{NotifySettingMention, false, false},
+		{NotifySettingMention, true, true},
+		{NotifySettingMute, true, false},
+	}
+	for _, tt := range tests {
+		if got := tt.setting.IsNotify(tt.isMention); got != tt.want {
+			t.Errorf("NotifySetting(%v).IsNotify(%v) = %v, want %v", tt.setting, tt.isMention, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+/*
+NotifySetting is the local-only notification preference of the entity. The entities without the setting notify everything.
+*/
+type NotifySetting uint8
+
+const (
+	NotifySettingAll NotifySetting = iota
+	NotifySettingMention
+	NotifySettingMute
+
+	NNotifySetting
+)
+
+/*
+IsNotify checks whether the event is notified with the setting.
+*/
+func (s NotifySetting) IsNotify(isMention bool) bool {
+	switch s {
+	case NotifySettingAll:
+		return true
+	case NotifySettingMention:
+		return isMention
+	}
+
+	return false
+}
+
+func marshalNotifySettingKey(entityID *types.PttID) ([]byte, error) {
+	return common.Concat([][]byte{DBNotifySettingPrefix, entityID[:]})
+}
+
+func LoadNotifySetting(entityID *types.PttID) NotifySetting {
+	if dbMeta == nil || entityID == nil {
+		return NotifySettingAll
+	}
+
+	key, err := marshalNotifySettingKey(entityID)
+	if err != nil {
+		return NotifySettingAll
+	}
+
+	value, err := dbMeta.Get(key)
+	if err != nil || len(value) == 0 {
+		return NotifySettingAll
+	}
+
+	return NotifySetting(value[0])
+}
+
+func SetNotifySetting(entityID *types.PttID, setting NotifySetting) error {
+	if setting >= NNotifySetting {
+		return ErrInvalidData
+	}
+
+	key, err := marshalNotifySettingKey(entityID)
+	if err != nil {
+		return err
+	}
+
+	if setting == NotifySettingAll {
+		return dbMeta.Delete(key)
+	}
+
+	return dbMeta.Put(key, []byte{uint8(setting)})
+}
@@ -158,8 +158,24 @@ func (k *KeyInfo) MarshalKey() ([]byte, error) {
 	return common.Concat([][]byte{k.fullDBPrefix, marshalTimestamp, k.ID[:]})
 }
 
+/*
+Marshal marshals the key-info to be stored in the db, with KeyBytes encrypted if the db-key is set.
+*/
 func (k *KeyInfo) Marshal() ([]byte, error) {
-	return json.Marshal(k)
+	if keyInfoAEAD == nil || k.KeyBytes == nil {
+		return json.Marshal(k)
+	}
+
+	encKeyBytes, err := k.encryptKeyBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	theK := *k
+	theK.KeyBytes = nil
+	theK.EncKeyBytes = encKeyBytes
+
+	return json.Marshal(&theK)
 }
 
 func (k *KeyInfo) Unmarshal(data []byte) error {
@@ -168,6 +184,15 @@ func (k *KeyInfo) Unmarshal(data []byte) error {
 		return err
 	}
 
+	// the KeyBytes stored in plain before the db-key is set are still accepted.
+	if k.EncKeyBytes != nil {
+		k.KeyBytes, err = k.decryptKeyBytes()
+		if err != nil {
+			return err
+		}
+		k.EncKeyBytes = nil
+	}
+
 	// it's possible that k.KeyBytes is nil because of the init-key.
 	if k.KeyBytes != nil {
 		k.Key, err = crypto.ToECDSA(k.KeyBytes)
@@ -53,6 +53,9 @@ const (
 	CodeTypeOpCheckMember
 	CodeTypeOpCheckMemberAck
 
+	CodeTypeRequestPublicEntities
+	CodeTypePublicEntities
+
 	NCodeType
 )
 
@@ -82,6 +85,9 @@ var codeTypeStr = map[CodeType]string{
 
 	CodeTypeOpCheckMember:    "op-check-member",
 	CodeTypeOpCheckMemberAck: "op-check-member-ack",
+
+	CodeTypeRequestPublicEntities: "request-public-entities",
+	CodeTypePublicEntities:        "public-entities",
 }
 
 func (c CodeType) String() string {
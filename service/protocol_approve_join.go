@@ -50,9 +50,21 @@ func (p *BasePtt) ApproveJoin(confirmKey []byte) error {
 	entity, joinEntity, keyInfo, peer := confirmJoin.Entity, confirmJoin.JoinEntity, confirmJoin.KeyInfo, confirmJoin.Peer
 
 	pm := entity.PM()
+	if pm.IsBanned(joinEntity.ID) {
+		delete(p.confirmJoins, confirmKeyStr)
+		return ErrBanned
+	}
+
+	err := pm.UseInvite(keyInfo.Hash, joinEntity.ID)
+	if err != nil {
+		delete(p.confirmJoins, confirmKeyStr)
+		return err
+	}
+
 	opKeyInfo, approvedData, err := pm.ApproveJoin(joinEntity, keyInfo, peer)
 	log.Debug("ApproveJoin: after pm.ApproveJoin", "e", err)
 	if err != nil {
+		pm.UnuseInvite(keyInfo.Hash)
 		return err
 	}
 
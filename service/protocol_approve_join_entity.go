@@ -46,7 +46,10 @@ func (pm *BaseProtocolManager) ApproveJoin(
 
 	myID := pm.Ptt().GetMyEntity().GetID()
 
-	if !pm.IsMaster(myID, false) && peer.PeerType != PeerTypeMe {
+	// joining with the public join-key as a reader
+	isReader := keyInfo != nil && pm.IsPublicJoinKeyHash(keyInfo.Hash) && !pm.IsMember(joinEntity.ID, false)
+
+	if !isReader && !pm.IsMaster(myID, false) && peer.PeerType != PeerTypeMe {
 		return nil, nil, types.ErrInvalidID
 	}
 
@@ -87,7 +90,13 @@ func (pm *BaseProtocolManager) ApproveJoin(
 	// XXX (force adding member. We are single-master for now.)
 	var memberLog *MemberOplog
 	memberLogs := make([]*BaseOplog, 0, 2)
-	if !reflect.DeepEqual(myID, joinEntity.ID) {
+	if isReader {
+		err = pm.AddReader(joinEntity.ID)
+		log.Debug("ApproveJoin: after AddReader", "e", err)
+		if err != nil && err != types.ErrAlreadyExists {
+			return nil, nil, err
+		}
+	} else if !reflect.DeepEqual(myID, joinEntity.ID) {
 		log.Debug("ApproveJoin: peer not me", "joinEntity", joinEntity.ID, "myID", entity.GetCreatorID())
 		_, memberLog, err = pm.AddMember(joinEntity.ID, true)
 		log.Debug("ApproveJoin: after AddMember", "e", err)
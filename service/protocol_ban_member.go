@@ -0,0 +1,208 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/*
+BanMember bans / unbans the user with the master-oplog.
+The banned member is deleted from the entity as well.
+*/
+func (pm *BaseProtocolManager) BanMember(id *types.PttID, isBanned bool) error {
+
+	opData := &MasterOpBanMember{IsBanned: isBanned}
+	err := pm.createBanMemberOplog(id, MasterOpTypeBanMember, opData, func(oplog *BaseOplog) error {
+		return pm.saveBanMemberWithOplog(oplog, isBanned)
+	})
+	if err != nil {
+		return err
+	}
+
+	if !isBanned || !pm.IsMember(id, false) {
+		return nil
+	}
+
+	_, err = pm.DeleteMember(id)
+	log.Debug("BanMember: after DeleteMember", "e", err)
+
+	return err
+}
+
+/*
+MuteMember mutes the user until expireTS with the master-oplog. Unmute if expireTS is 0.
+*/
+func (pm *BaseProtocolManager) MuteMember(id *types.PttID, expireTS types.Timestamp) error {
+
+	opData := &MasterOpMuteMember{ExpireTS: expireTS}
+	return pm.createBanMemberOplog(id, MasterOpTypeMuteMember, opData, func(oplog *BaseOplog) error {
+		return pm.saveMuteMemberWithOplog(oplog, expireTS)
+	})
+}
+
+func (pm *BaseProtocolManager) createBanMemberOplog(id *types.PttID, op OpType, opData OpData, saveWithOplog func(oplog *BaseOplog) error) error {
+	ptt := pm.Ptt()
+	myID := ptt.GetMyEntity().GetID()
+
+	// 1. validate
+	if pm.Entity().GetStatus() != types.StatusAlive {
+		return types.ErrInvalidStatus
+	}
+
+	if !pm.IsMaster(myID, false) {
+		return types.ErrInvalidID
+	}
+
+	if pm.IsMaster(id, false) {
+		return types.ErrInvalidID
+	}
+
+	// 2. oplog
+	theOplog, err := pm.NewMasterOplog(id, op, opData)
+	if err != nil {
+		return err
+	}
+	oplog := theOplog.GetBaseOplog()
+
+	err = pm.SignOplog(oplog)
+	if err != nil {
+		return err
+	}
+
+	err = oplog.Lock()
+	if err != nil {
+		return err
+	}
+	defer oplog.Unlock()
+
+	// 3. set ban
+	if oplog.MasterLogID != nil {
+		err = saveWithOplog(oplog)
+		if err != nil {
+			return err
+		}
+		oplog.IsSync = true
+	}
+
+	// 4. save oplog
+	err = oplog.Save(true, pm.MasterMerkle())
+	if err != nil {
+		return err
+	}
+
+	pm.broadcastMasterOplogCore(oplog)
+
+	return nil
+}
+
+func (pm *BaseProtocolManager) getOrNewMemberBanInfo(id *types.PttID) (*MemberBanInfo, error) {
+	entityID := pm.Entity().GetID()
+
+	b, err := getMemberBanInfo(pm.db, entityID, id)
+	if err == leveldb.ErrNotFound {
+		return NewMemberBanInfo(entityID, id), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func (pm *BaseProtocolManager) saveBanMemberWithOplog(oplog *BaseOplog, isBanned bool) error {
+	pm.lockMemberBan.Lock()
+	defer pm.lockMemberBan.Unlock()
+
+	b, err := pm.getOrNewMemberBanInfo(oplog.ObjID)
+	if err != nil {
+		return err
+	}
+
+	if oplog.UpdateTS.IsLess(b.BanUpdateTS) {
+		return ErrNewerOplog
+	}
+
+	b.IsBanned = isBanned
+	b.BanUpdateTS = oplog.UpdateTS
+	b.BanLogID = oplog.ID
+
+	return b.Save(pm.db)
+}
+
+func (pm *BaseProtocolManager) saveMuteMemberWithOplog(oplog *BaseOplog, expireTS types.Timestamp) error {
+	pm.lockMemberBan.Lock()
+	defer pm.lockMemberBan.Unlock()
+
+	b, err := pm.getOrNewMemberBanInfo(oplog.ObjID)
+	if err != nil {
+		return err
+	}
+
+	if oplog.UpdateTS.IsLess(b.MuteUpdateTS) {
+		return ErrNewerOplog
+	}
+
+	b.MuteExpireTS = expireTS
+	b.MuteUpdateTS = oplog.UpdateTS
+	b.MuteLogID = oplog.ID
+
+	return b.Save(pm.db)
+}
+
+func (pm *BaseProtocolManager) IsBanned(id *types.PttID) bool {
+	b, err := getMemberBanInfo(pm.db, pm.Entity().GetID(), id)
+	if err != nil {
+		return false
+	}
+
+	return b.IsBanned
+}
+
+/*
+IsBannedAt returns whether the user is banned at ts.
+*/
+func (pm *BaseProtocolManager) IsBannedAt(id *types.PttID, ts types.Timestamp) bool {
+	b, err := getMemberBanInfo(pm.db, pm.Entity().GetID(), id)
+	if err != nil {
+		return false
+	}
+
+	return b.IsBannedAt(ts)
+}
+
+func (pm *BaseProtocolManager) IsMuted(id *types.PttID, ts types.Timestamp) bool {
+	b, err := getMemberBanInfo(pm.db, pm.Entity().GetID(), id)
+	if err != nil {
+		return false
+	}
+
+	return b.IsMuted(ts)
+}
+
+/*
+GetBanList returns the users banned or muted (possibly expired) in the entity.
+*/
+func (pm *BaseProtocolManager) GetBanList() ([]*MemberBanInfo, error) {
+	return getMemberBanInfoList(pm.db, pm.Entity().GetID())
+}
+
+func (pm *BaseProtocolManager) CleanMemberBan() error {
+	return cleanMemberBanInfo(pm.db, pm.Entity().GetID())
+}
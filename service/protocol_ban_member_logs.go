@@ -0,0 +1,134 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/**********
+ * Ban
+ **********/
+
+func (pm *BaseProtocolManager) handleBanMemberLog(oplog *BaseOplog, info *ProcessPersonInfo) ([]*BaseOplog, error) {
+
+	if !pm.IsMaster(oplog.CreatorID, false) {
+		return nil, ErrSkipOplog
+	}
+
+	opData := &MasterOpBanMember{}
+	err := oplog.GetData(opData)
+	if err != nil {
+		return nil, err
+	}
+
+	err = pm.saveBanMemberWithOplog(oplog, opData.IsBanned)
+	if err != nil {
+		return nil, err
+	}
+
+	oplog.IsSync = true
+
+	return nil, nil
+}
+
+func (pm *BaseProtocolManager) handlePendingBanMemberLog(oplog *BaseOplog, info *ProcessPersonInfo) (types.Bool, []*BaseOplog, error) {
+
+	if !pm.IsMaster(oplog.CreatorID, false) {
+		return false, nil, ErrSkipOplog
+	}
+
+	return true, nil, nil
+}
+
+func (pm *BaseProtocolManager) setNewestBanMemberLog(oplog *BaseOplog) (types.Bool, error) {
+	b, err := getMemberBanInfo(pm.db, pm.Entity().GetID(), oplog.ObjID)
+	if err == leveldb.ErrNotFound {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return !types.Bool(reflect.DeepEqual(oplog.ID, b.BanLogID)), nil
+}
+
+func (pm *BaseProtocolManager) handleFailedBanMemberLog(oplog *BaseOplog) error {
+	return nil
+}
+
+func (pm *BaseProtocolManager) handleFailedValidBanMemberLog(oplog *BaseOplog) error {
+	return nil
+}
+
+/**********
+ * Mute
+ **********/
+
+func (pm *BaseProtocolManager) handleMuteMemberLog(oplog *BaseOplog, info *ProcessPersonInfo) ([]*BaseOplog, error) {
+
+	if !pm.IsMaster(oplog.CreatorID, false) {
+		return nil, ErrSkipOplog
+	}
+
+	opData := &MasterOpMuteMember{}
+	err := oplog.GetData(opData)
+	if err != nil {
+		return nil, err
+	}
+
+	err = pm.saveMuteMemberWithOplog(oplog, opData.ExpireTS)
+	if err != nil {
+		return nil, err
+	}
+
+	oplog.IsSync = true
+
+	return nil, nil
+}
+
+func (pm *BaseProtocolManager) handlePendingMuteMemberLog(oplog *BaseOplog, info *ProcessPersonInfo) (types.Bool, []*BaseOplog, error) {
+
+	if !pm.IsMaster(oplog.CreatorID, false) {
+		return false, nil, ErrSkipOplog
+	}
+
+	return true, nil, nil
+}
+
+func (pm *BaseProtocolManager) setNewestMuteMemberLog(oplog *BaseOplog) (types.Bool, error) {
+	b, err := getMemberBanInfo(pm.db, pm.Entity().GetID(), oplog.ObjID)
+	if err == leveldb.ErrNotFound {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return !types.Bool(reflect.DeepEqual(oplog.ID, b.MuteLogID)), nil
+}
+
+func (pm *BaseProtocolManager) handleFailedMuteMemberLog(oplog *BaseOplog) error {
+	return nil
+}
+
+func (pm *BaseProtocolManager) handleFailedValidMuteMemberLog(oplog *BaseOplog) error {
+	return nil
+}
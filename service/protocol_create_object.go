@@ -96,7 +96,7 @@ func (pm *BaseProtocolManager) CreateObject(
 /*
 createObjectCore is the core for ForceCreateObject and CreateObject.
 
-	1. validate the entity status.
+	1. validate the entity status and the write-permission.
 	2. new-object.
 	3. new-oplog.
 
@@ -150,6 +150,15 @@ func (pm *BaseProtocolManager) createObjectCore(
 		return nil, types.ErrInvalidStatus
 	}
 
+	myEntity := pm.Ptt().GetMyEntity()
+	myID := myEntity.GetID()
+	if !pm.IsWritable(myID) {
+		return nil, types.ErrInvalidID
+	}
+	if !myEntity.IsPostable() {
+		return nil, ErrNotPermitted
+	}
+
 	// 2. new-obj
 	obj, opData, err := newObj(data)
 	if err != nil {
@@ -52,6 +52,9 @@ func (pm *BaseProtocolManager) DeleteObject(
 	if myEntity.GetStatus() != types.StatusAlive {
 		return types.ErrInvalidStatus
 	}
+	if !myEntity.IsPostable() {
+		return ErrNotPermitted
+	}
 
 	// 1. lock object
 	origObj.SetID(id)
@@ -75,7 +78,7 @@ func (pm *BaseProtocolManager) DeleteObject(
 	}
 
 	creatorID := origObj.GetCreatorID()
-	if !reflect.DeepEqual(myID, creatorID) && !pm.IsMaster(myID, false) {
+	if !reflect.DeepEqual(myID, creatorID) && !pm.IsModerator(myID) {
 		return types.ErrInvalidID
 	}
 
@@ -30,6 +30,16 @@ func (pm *BaseProtocolManager) GetMemberList(startID *types.PttID, limit int, li
 		return nil, err
 	}
 	typedObjs := ObjsToMembers(objs)
+	ptt := pm.Ptt()
+	for _, each := range typedObjs {
+		each.Role = pm.GetMemberRole(each.ID)
+
+		isOnline, lastOnlineTS := ptt.GetPresence(each.ID)
+		each.Online = isOnline
+		if lastOnlineTS.Ts != 0 {
+			each.LastSeenOnline = &lastOnlineTS
+		}
+	}
 
 	return typedObjs, nil
 }
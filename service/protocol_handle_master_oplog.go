@@ -36,6 +36,14 @@ func (pm *BaseProtocolManager) processMasterLog(oplog *BaseOplog, processInfo Pr
 		origLogs, err = pm.handleMigrateMasterLog(oplog, info)
 	case MasterOpTypeTransferMaster:
 		origLogs, err = pm.handleTransferMasterLog(oplog, info)
+	case MasterOpTypeSetMemberRole:
+		origLogs, err = pm.handleSetMemberRoleLog(oplog, info)
+	case MasterOpTypeBanMember:
+		origLogs, err = pm.handleBanMemberLog(oplog, info)
+	case MasterOpTypeMuteMember:
+		origLogs, err = pm.handleMuteMemberLog(oplog, info)
+	case MasterOpTypeSetMasterQuorum:
+		origLogs, err = pm.handleSetMasterQuorumLog(oplog, info)
 	}
 	return
 }
@@ -61,6 +69,14 @@ func (pm *BaseProtocolManager) processPendingMasterLog(oplog *BaseOplog, process
 		isToSign, origLogs, err = pm.handlePendingMigrateMasterLog(oplog, info)
 	case MasterOpTypeTransferMaster:
 		isToSign, origLogs, err = pm.handlePendingTransferMasterLog(oplog, info)
+	case MasterOpTypeSetMemberRole:
+		isToSign, origLogs, err = pm.handlePendingSetMemberRoleLog(oplog, info)
+	case MasterOpTypeBanMember:
+		isToSign, origLogs, err = pm.handlePendingBanMemberLog(oplog, info)
+	case MasterOpTypeMuteMember:
+		isToSign, origLogs, err = pm.handlePendingMuteMemberLog(oplog, info)
+	case MasterOpTypeSetMasterQuorum:
+		isToSign, origLogs, err = pm.handlePendingSetMasterQuorumLog(oplog, info)
 	}
 	return isToSign, origLogs, err
 }
@@ -91,6 +107,14 @@ func (pm *BaseProtocolManager) SetNewestMasterOplog(oplog *BaseOplog) error {
 		isNewer, err = pm.setNewestMigrateMasterLog(oplog)
 	case MasterOpTypeTransferMaster:
 		isNewer, err = pm.setNewestTransferMasterLog(oplog)
+	case MasterOpTypeSetMemberRole:
+		isNewer, err = pm.setNewestSetMemberRoleLog(oplog)
+	case MasterOpTypeBanMember:
+		isNewer, err = pm.setNewestBanMemberLog(oplog)
+	case MasterOpTypeMuteMember:
+		isNewer, err = pm.setNewestMuteMemberLog(oplog)
+	case MasterOpTypeSetMasterQuorum:
+		isNewer, err = pm.setNewestSetMasterQuorumLog(oplog)
 	}
 
 	if err != nil {
@@ -116,6 +140,14 @@ func (pm *BaseProtocolManager) HandleFailedMasterOplog(oplog *BaseOplog) error {
 		err = pm.handleFailedMigrateMasterLog(oplog)
 	case MasterOpTypeTransferMaster:
 		err = pm.handleFailedTransferMasterLog(oplog)
+	case MasterOpTypeSetMemberRole:
+		err = pm.handleFailedSetMemberRoleLog(oplog)
+	case MasterOpTypeBanMember:
+		err = pm.handleFailedBanMemberLog(oplog)
+	case MasterOpTypeMuteMember:
+		err = pm.handleFailedMuteMemberLog(oplog)
+	case MasterOpTypeSetMasterQuorum:
+		err = pm.handleFailedSetMasterQuorumLog(oplog)
 	}
 
 	return err
@@ -131,6 +163,14 @@ func (pm *BaseProtocolManager) HandleFailedValidMasterOplog(oplog *BaseOplog, pr
 		err = pm.handleFailedValidMigrateMasterLog(oplog)
 	case MasterOpTypeTransferMaster:
 		err = pm.handleFailedValidTransferMasterLog(oplog)
+	case MasterOpTypeSetMemberRole:
+		err = pm.handleFailedValidSetMemberRoleLog(oplog)
+	case MasterOpTypeBanMember:
+		err = pm.handleFailedValidBanMemberLog(oplog)
+	case MasterOpTypeMuteMember:
+		err = pm.handleFailedValidMuteMemberLog(oplog)
+	case MasterOpTypeSetMasterQuorum:
+		err = pm.handleFailedValidSetMasterQuorumLog(oplog)
 	}
 
 	return err
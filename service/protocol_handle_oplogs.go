@@ -548,6 +548,18 @@ func preprocessOplogs(
 		}
 	}
 
+	// master-quorum
+	// skip the critical oplogs claimed as valid without enough master-signs.
+	validLogs := oplogs[:0]
+	for _, oplog := range oplogs {
+		if !pm.IsValidQuorumOplog(oplog) {
+			log.Warn("preprocessOplogs: invalid quorum", "op", oplog.Op, "logID", oplog.ID, "entity", pm.Entity().GetID())
+			continue
+		}
+		validLogs = append(validLogs, oplog)
+	}
+	oplogs = validLogs
+
 	// check pre-log-id
 	// XXX prelog as shared tmp-variable.
 	prelog := &BaseOplog{}
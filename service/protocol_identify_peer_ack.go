@@ -31,6 +31,7 @@ type IdentifyPeerAck struct {
 	PubBytes     []byte        `json:"P,omitempty"`
 	Extra        *KeyExtraInfo `json:"E,omitempty"`
 	MyID         *types.PttID  `json:"M,omitempty"`
+	HidePresence bool          `json:"hp,omitempty"`
 }
 
 /*
@@ -111,6 +112,7 @@ func (p *BasePtt) IdentifyPeerAck(challenge *types.Salt, peer *PttPeer) (*Identi
 		PubBytes:     pubBytes,
 		MyID:         myID,
 		Extra:        signKey.Extra,
+		HidePresence: LoadHidePresence(),
 	}
 
 	return ackData, nil
@@ -139,6 +141,7 @@ func (p *BasePtt) HandleIdentifyPeerAck(entityID *types.PttID, data *IdentifyPee
 	}
 
 	peer.UserID = data.MyID
+	peer.IsHidePresence = data.HidePresence
 
 	peer.FinishID(entityID)
 
@@ -0,0 +1,228 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+)
+
+/*
+CreateInvite creates an invite with its own join-key.
+
+expireTS: zero as no expiration.
+maxUse: 0 as unlimited.
+inviteeID: nil as anyone with the invite-url.
+*/
+func (pm *BaseProtocolManager) CreateInvite(expireTS types.Timestamp, maxUse uint32, inviteeID *types.PttID) (*Invite, error) {
+	entity := pm.Entity()
+	if entity.GetStatus() != types.StatusAlive {
+		return nil, types.ErrInvalidStatus
+	}
+
+	myID := pm.Ptt().GetMyEntity().GetID()
+	if !pm.IsMaster(myID, false) {
+		return nil, types.ErrInvalidID
+	}
+
+	invite, err := NewInvite(entity.GetID(), myID, expireTS, maxUse, inviteeID)
+	if err != nil {
+		return nil, err
+	}
+
+	pm.lockJoinKeyInfo.Lock()
+	defer pm.lockJoinKeyInfo.Unlock()
+
+	err = invite.Save(pm.db)
+	if err != nil {
+		return nil, err
+	}
+
+	pm.invites[*invite.Hash] = invite
+	pm.ptt.AddJoinKey(invite.Hash, entity.GetID(), false)
+
+	return invite, nil
+}
+
+func (pm *BaseProtocolManager) RevokeInvite(inviteID *types.PttID) error {
+	myID := pm.Ptt().GetMyEntity().GetID()
+	if !pm.IsMaster(myID, false) {
+		return types.ErrInvalidID
+	}
+
+	pm.lockJoinKeyInfo.Lock()
+	defer pm.lockJoinKeyInfo.Unlock()
+
+	var invite *Invite
+	for _, eachInvite := range pm.invites {
+		if reflect.DeepEqual(eachInvite.ID, inviteID) {
+			invite = eachInvite
+			break
+		}
+	}
+	if invite == nil {
+		return types.ErrInvalidID
+	}
+
+	if invite.Status != types.StatusAlive {
+		return nil
+	}
+
+	invite.Status = types.StatusDeleted
+	err := invite.Save(pm.db)
+	if err != nil {
+		return err
+	}
+
+	pm.ptt.RemoveJoinKey(invite.Hash, pm.Entity().GetID(), false)
+
+	return nil
+}
+
+func (pm *BaseProtocolManager) GetInvites() ([]*Invite, error) {
+	return getInviteList(pm.db, pm.Entity().GetID())
+}
+
+/*
+ValidateInvite validates the join with the hash if the hash is from an invite.
+inviteeID is nil if the joiner is not revealed yet.
+*/
+func (pm *BaseProtocolManager) ValidateInvite(hash *common.Address, inviteeID *types.PttID) error {
+	pm.lockJoinKeyInfo.RLock()
+	defer pm.lockJoinKeyInfo.RUnlock()
+
+	invite, ok := pm.invites[*hash]
+	if !ok {
+		return nil
+	}
+
+	return validateInvite(invite, inviteeID)
+}
+
+func validateInvite(invite *Invite, inviteeID *types.PttID) error {
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return err
+	}
+
+	if !invite.IsValid(ts) {
+		return ErrInvalidInvite
+	}
+
+	if inviteeID != nil && invite.InviteeID != nil && !reflect.DeepEqual(inviteeID, invite.InviteeID) {
+		return ErrInvalidInvite
+	}
+
+	return nil
+}
+
+/*
+UseInvite validates and counts the join with the hash if the hash is from an invite,
+atomically so that the concurrent joins are not able to exceed the max-use.
+The join-key is removed once the invite is used up.
+
+UnuseInvite is required if the join is not approved afterwards.
+*/
+func (pm *BaseProtocolManager) UseInvite(hash *common.Address, inviteeID *types.PttID) error {
+	pm.lockJoinKeyInfo.Lock()
+	defer pm.lockJoinKeyInfo.Unlock()
+
+	invite, ok := pm.invites[*hash]
+	if !ok {
+		return nil
+	}
+
+	err := validateInvite(invite, inviteeID)
+	if err != nil {
+		return err
+	}
+
+	invite.NUsed++
+	err = invite.Save(pm.db)
+	if err != nil {
+		invite.NUsed--
+		return err
+	}
+
+	if invite.MaxUse != 0 && invite.NUsed >= invite.MaxUse {
+		pm.ptt.RemoveJoinKey(invite.Hash, pm.Entity().GetID(), false)
+	}
+
+	return nil
+}
+
+/*
+UnuseInvite rolls back the count of UseInvite.
+*/
+func (pm *BaseProtocolManager) UnuseInvite(hash *common.Address) error {
+	pm.lockJoinKeyInfo.Lock()
+	defer pm.lockJoinKeyInfo.Unlock()
+
+	invite, ok := pm.invites[*hash]
+	if !ok || invite.NUsed == 0 {
+		return nil
+	}
+
+	isUsedUp := invite.MaxUse != 0 && invite.NUsed >= invite.MaxUse
+
+	invite.NUsed--
+	err := invite.Save(pm.db)
+	if err != nil {
+		return err
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return err
+	}
+
+	if isUsedUp && invite.IsValid(ts) {
+		pm.ptt.AddJoinKey(invite.Hash, pm.Entity().GetID(), false)
+	}
+
+	return nil
+}
+
+func (pm *BaseProtocolManager) loadInvites() error {
+	invites, err := pm.GetInvites()
+	if err != nil {
+		return err
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return err
+	}
+
+	pm.lockJoinKeyInfo.Lock()
+	defer pm.lockJoinKeyInfo.Unlock()
+
+	entityID := pm.Entity().GetID()
+	for _, invite := range invites {
+		pm.invites[*invite.Hash] = invite
+		if !invite.IsValid(ts) {
+			continue
+		}
+		log.Debug("loadInvites: to AddJoinKey", "e", entityID, "invite", invite.ID)
+		pm.ptt.AddJoinKey(invite.Hash, entityID, false)
+	}
+
+	return nil
+}
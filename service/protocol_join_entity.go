@@ -77,6 +77,8 @@ Recevied "join-entity" with revealed ID and Name. (invitor)
     2. if the entity auto-approves the entity-id and node-id
         => do approve.
     3. put to confirm-queue.
+
+join-me is never auto-approved, and is confirmed with the SAS code on the existing node.
 */
 func (p *BasePtt) HandleJoinEntity(dataBytes []byte, hash *common.Address, entity Entity, pm ProtocolManager, keyInfo *KeyInfo, peer *PttPeer) error {
 	log.Debug("HandleJoinEntity: start")
@@ -93,11 +95,20 @@ func (p *BasePtt) HandleJoinEntity(dataBytes []byte, hash *common.Address, entit
 
 	id := joinEntity.ID
 	nodeID := peer.GetID()
+
+	if entity.PM().IsBanned(id) {
+		return ErrBanned
+	}
+
+	err = entity.PM().ValidateInvite(hash, id)
+	if err != nil {
+		return err
+	}
 	if entity.PM().IsSuspiciousID(id, nodeID) {
 		return ErrInvalidData
 	}
 
-	confirmKey := getConfirmKey(id, entity.GetID())
+	confirmKey := GetConfirmKey(id, entity.GetID())
 	joinType, err := entity.PM().GetJoinType(hash)
 	log.Debug("HandleJoinEntity: after get join type", "e", err, "joinType", joinType, "entity", entity.Service().Name())
 	if err != nil {
@@ -110,14 +121,36 @@ func (p *BasePtt) HandleJoinEntity(dataBytes []byte, hash *common.Address, entit
 		return err
 	}
 
-	if entity.PM().IsGoodID(id, nodeID) {
+	p.notifyJoinEntity(joinType, id, entity.GetID())
+
+	if joinType != JoinTypeMe && entity.PM().IsGoodID(id, nodeID) {
 		return p.ApproveJoin(confirmKey)
 	}
 
 	return nil
 }
 
-func getConfirmKey(id *types.PttID, entityID *types.PttID) []byte {
+/*
+notifyJoinEntity notifies me that the user requested to be my friend or joined my board with my invite.
+*/
+func (p *BasePtt) notifyJoinEntity(joinType JoinType, id *types.PttID, entityID *types.PttID) {
+	var theType NotificationType
+	switch joinType {
+	case JoinTypeFriend:
+		theType = NotificationTypeFriendRequest
+	case JoinTypeBoard:
+		theType = NotificationTypeBoardInvite
+	default:
+		return
+	}
+
+	err := p.CreateNotification(theType, id, entityID, nil, nil)
+	if err != nil {
+		log.Warn("notifyJoinEntity: unable to create notification", "joinType", joinType, "e", err)
+	}
+}
+
+func GetConfirmKey(id *types.PttID, entityID *types.PttID) []byte {
 	confirmKey := make([]byte, types.SizePttID+types.SizePttID)
 	copy(confirmKey[:types.SizePttID], id[:])
 	copy(confirmKey[types.SizePttID:], entityID[:])
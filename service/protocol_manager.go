@@ -70,6 +70,7 @@ type ProtocolManager interface {
 	// member
 
 	AddMember(id *types.PttID, isForce bool) (*Member, *MemberOplog, error)
+	IsMember(id *types.PttID, isLocked bool) bool
 	MigrateMember(fromID *types.PttID, toID *types.PttID) error
 	DeleteMember(id *types.PttID) (bool, error)
 
@@ -142,6 +143,31 @@ type ProtocolManager interface {
 
 	GetMemberList(startID *types.PttID, limit int, listOrder pttdb.ListOrder, isLocked bool) ([]*Member, error)
 
+	// member-role
+	SetMemberRole(id *types.PttID, role MemberRole) error
+	GetMemberRole(id *types.PttID) MemberRole
+	GetMemberRoleAt(id *types.PttID, ts types.Timestamp) (MemberRole, bool)
+	GetMemberRoles() ([]*MemberRoleInfo, error)
+	IsModerator(id *types.PttID) bool
+
+	// member-ban
+	BanMember(id *types.PttID, isBanned bool) error
+	MuteMember(id *types.PttID, expireTS types.Timestamp) error
+	IsBanned(id *types.PttID) bool
+	IsBannedAt(id *types.PttID, ts types.Timestamp) bool
+	IsMuted(id *types.PttID, ts types.Timestamp) bool
+	GetBanList() ([]*MemberBanInfo, error)
+
+	// master-quorum
+	RegisterCriticalOp(dbPrefix []byte, op OpType)
+	IsCriticalOplog(oplog *BaseOplog) bool
+	IsValidQuorumOplog(oplog *BaseOplog) bool
+	MasterQuorum() uint32
+	SetMasterQuorum(quorum uint32) error
+	GetPendingQuorumOplogs(setDB func(oplog *BaseOplog)) ([]*BaseOplog, error)
+	CoSignOplog(logID *types.PttID, setDB func(oplog *BaseOplog), handlePendingOplogs func(oplogs []*BaseOplog, peer *PttPeer) error) error
+	CoSignMasterOplog(logID *types.PttID) error
+
 	// member-oplog
 	BroadcastMemberOplog(log *MemberOplog) error
 
@@ -185,6 +211,24 @@ type ProtocolManager interface {
 	CreateJoinKeyLoop() error
 
 	JoinKeyList() []*KeyInfo
+	RenewJoinKey() error
+
+	// public
+	IsPublic() bool
+	GetPublicJoinKey() (*KeyInfo, error)
+	IsPublicJoinKeyHash(hash *common.Address) bool
+
+	IsReader(id *types.PttID) bool
+	AddReader(id *types.PttID) error
+	IsWritable(id *types.PttID) bool
+
+	// invite
+	CreateInvite(expireTS types.Timestamp, maxUse uint32, inviteeID *types.PttID) (*Invite, error)
+	RevokeInvite(inviteID *types.PttID) error
+	GetInvites() ([]*Invite, error)
+	ValidateInvite(hash *common.Address, inviteeID *types.PttID) error
+	UseInvite(hash *common.Address, inviteeID *types.PttID) error
+	UnuseInvite(hash *common.Address) error
 
 	// op
 
@@ -348,6 +392,18 @@ type BaseProtocolManager struct {
 	memberMerkle *Merkle
 	myMemberLog  *MemberOplog
 
+	lockMemberRole sync.Mutex
+	lockMemberBan  sync.Mutex
+
+	// master-quorum
+	lockMasterQuorum sync.RWMutex
+	masterQuorums    []*MasterQuorum
+	masterLogTSs     map[types.PttID]types.Timestamp
+	masterIDSets     map[types.PttID]map[types.PttID]bool
+
+	lockCriticalOps sync.RWMutex
+	criticalOps     map[string]map[OpType]bool
+
 	// peer
 	getPeerType func(peer *PttPeer) PeerType
 
@@ -365,6 +421,10 @@ type BaseProtocolManager struct {
 
 	joinKeyInfos []*KeyInfo
 
+	publicJoinKeyInfo *KeyInfo
+
+	invites map[common.Address]*Invite
+
 	// op
 	lockOpKeyInfo sync.RWMutex
 
@@ -484,6 +544,9 @@ func NewBaseProtocolManager(
 	if err != nil {
 		return nil, err
 	}
+	if ptt != nil {
+		peers.SetIsBlockedUser(ptt.IsBlockedUser)
+	}
 
 	// db-lock
 	dbLock, err := types.NewLockMap(SleepTimeLock)
@@ -535,6 +598,12 @@ func NewBaseProtocolManager(
 
 		// join
 		joinKeyInfos: make([]*KeyInfo, 0),
+		invites:      make(map[common.Address]*Invite),
+
+		// master-quorum
+		criticalOps:  make(map[string]map[OpType]bool),
+		masterLogTSs: make(map[types.PttID]types.Timestamp),
+		masterIDSets: make(map[types.PttID]map[types.PttID]bool),
 
 		// master
 		isMaster:          isMaster,
@@ -659,6 +728,11 @@ func NewBaseProtocolManager(
 		pm.postdelete = pm.DefaultPostdeleteEntity
 	}
 
+	// master-quorum
+	pm.RegisterCriticalOp(DBMasterOplogPrefix, MasterOpTypeAddMaster)
+	pm.RegisterCriticalOp(DBMasterOplogPrefix, MasterOpTypeTransferMaster)
+	pm.RegisterCriticalOp(DBMasterOplogPrefix, MasterOpTypeSetMasterQuorum)
+
 	return pm, nil
 
 }
@@ -703,6 +777,12 @@ func (pm *BaseProtocolManager) Prestart() error {
 
 	pm.newestMasterLogID = newestMasterLogID
 
+	// master-quorum
+	err = pm.loadMasterQuorums()
+	if err != nil {
+		return err
+	}
+
 	// master0hash
 	masterLog0hash, err := pm.loadMasterLog0Hash()
 	if err == nil {
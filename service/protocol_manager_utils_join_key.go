@@ -40,6 +40,14 @@ func (pm *BaseProtocolManager) GetJoinKeyFromHash(hash *common.Address) (*KeyInf
 		}
 	}
 
+	if invite, ok := pm.invites[*hash]; keyInfo == nil && ok {
+		keyInfo = invite.KeyInfo()
+	}
+
+	if keyInfo == nil && pm.publicJoinKeyInfo != nil && reflect.DeepEqual(hash, pm.publicJoinKeyInfo.Hash) {
+		keyInfo = pm.publicJoinKeyInfo
+	}
+
 	if keyInfo == nil {
 		return nil, ErrInvalidKeyInfo
 	}
@@ -65,6 +73,8 @@ func (pm *BaseProtocolManager) CreateJoinKeyLoop() error {
 	defer ticker.Stop()
 
 	pm.createJoinKey()
+	pm.createPublicJoinKey()
+	pm.loadInvites()
 
 loop:
 	for {
@@ -120,6 +130,27 @@ func (pm *BaseProtocolManager) createJoinKey() error {
 	return nil
 }
 
+/*
+RenewJoinKey removes all the join-keys and creates a new one.
+The join-urls given out are no longer valid, except the invites and the public join-url.
+*/
+func (pm *BaseProtocolManager) RenewJoinKey() error {
+	myID := pm.Ptt().GetMyEntity().GetID()
+	if !pm.IsMaster(myID, false) {
+		return nil
+	}
+
+	pm.lockJoinKeyInfo.Lock()
+	entityID := pm.Entity().GetID()
+	for _, keyInfo := range pm.joinKeyInfos {
+		pm.ptt.RemoveJoinKey(keyInfo.Hash, entityID, false)
+	}
+	pm.joinKeyInfos = nil
+	pm.lockJoinKeyInfo.Unlock()
+
+	return pm.createJoinKey()
+}
+
 func (pm *BaseProtocolManager) JoinKeyList() []*KeyInfo {
 	return pm.joinKeyInfos
 }
@@ -150,4 +181,13 @@ func (pm *BaseProtocolManager) CleanJoinKey() {
 	for _, keyInfo := range pm.joinKeyInfos {
 		pm.ptt.RemoveJoinKey(keyInfo.Hash, entityID, false)
 	}
+
+	for _, invite := range pm.invites {
+		pm.ptt.RemoveJoinKey(invite.Hash, entityID, false)
+	}
+
+	if pm.publicJoinKeyInfo != nil {
+		pm.ptt.RemoveJoinKey(pm.publicJoinKeyInfo.Hash, entityID, false)
+		pm.publicJoinKeyInfo = nil
+	}
 }
@@ -28,6 +28,7 @@ import (
 
 func (pm *BaseProtocolManager) SetNewestMasterLogID(id *types.PttID) error {
 	pm.newestMasterLogID = id
+	pm.resetMasterIDsAt()
 	return pm.saveNewestMasterLogID()
 }
 
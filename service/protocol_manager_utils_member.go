@@ -129,5 +129,7 @@ func (pm *BaseProtocolManager) CleanMember() error {
 		member.Delete(false)
 	}
 
-	return nil
+	pm.CleanMemberBan()
+
+	return pm.CleanMemberRole()
 }
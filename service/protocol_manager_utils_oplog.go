@@ -302,6 +302,11 @@ func (pm *BaseProtocolManager) defaultInternalSign(oplog *BaseOplog) (bool, erro
 		return false, nil
 	}
 
+	// not co-signing the oplogs from the nodes not permitted to post
+	if len(oplog.InternalSigns) != 0 && !myEntity.IsPostableInternalOplog(oplog.InternalSigns) {
+		return false, nil
+	}
+
 	// internal-sign
 	log.Debug("defaultInternalSign: to InternalSign")
 	err := myEntity.InternalSign(oplog)
@@ -315,7 +320,11 @@ func (pm *BaseProtocolManager) defaultInternalSign(oplog *BaseOplog) (bool, erro
 		return true, nil
 	}
 
-	// master-sign
+	// master-sign (the critical oplogs from the others are co-signed explicitly)
+	if !pm.isToMasterSign(oplog) {
+		return true, nil
+	}
+
 	log.Debug("defaultInternalSign: to MasterSign")
 	err = myEntity.MasterSign(oplog)
 	if err != nil {
@@ -323,7 +332,7 @@ func (pm *BaseProtocolManager) defaultInternalSign(oplog *BaseOplog) (bool, erro
 	}
 
 	log.Debug("defaultInternalSign: to isValidOplog")
-	masterLogID, weight, isValid := pm.isValidOplog(oplog.MasterSigns)
+	masterLogID, weight, isValid := pm.validateMasterSigns(oplog)
 	if !isValid {
 		return true, nil
 	}
@@ -441,14 +450,14 @@ func (pm *BaseProtocolManager) defaultValidateIntegrateSign(oplog *BaseOplog, is
 	myEntity := ptt.GetMyEntity()
 
 	_, weight, isValid := myEntity.IsValidInternalOplog(oplog.InternalSigns)
-	if isValid {
+	if isValid && pm.isToMasterSign(oplog) {
 		err = myEntity.MasterSign(oplog)
 		if err != nil {
 			return
 		}
 	}
 
-	masterLogID, weight, isValid := pm.isValidOplog(oplog.MasterSigns)
+	masterLogID, weight, isValid := pm.validateMasterSigns(oplog)
 	if isValid {
 		err = oplog.SetMasterLogID(masterLogID, weight)
 		if err != nil {
@@ -174,7 +174,7 @@ func (pm *BaseProtocolManager) defaultIsMemberPeer(peer *PttPeer) bool {
 		return false
 	}
 
-	return pm.IsMember(peer.UserID, false)
+	return pm.IsMember(peer.UserID, false) || pm.IsReader(peer.UserID)
 }
 
 func (pm *BaseProtocolManager) IsPendingPeer(peer *PttPeer) bool {
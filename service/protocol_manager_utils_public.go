@@ -0,0 +1,154 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/*
+IsPublic returns whether the entity is readable by anyone knowing the entity-id.
+*/
+func (pm *BaseProtocolManager) IsPublic() bool {
+	return pm.Entity().GetEntityType() == EntityTypePublic
+}
+
+func (pm *BaseProtocolManager) createPublicJoinKey() error {
+	if !pm.IsPublic() {
+		return nil
+	}
+
+	status := pm.Entity().GetStatus()
+	statusClass := types.StatusToStatusClass(status)
+	if statusClass >= types.StatusClassDeleted {
+		return nil
+	}
+
+	pm.lockJoinKeyInfo.Lock()
+	defer pm.lockJoinKeyInfo.Unlock()
+
+	if pm.publicJoinKeyInfo != nil {
+		return nil
+	}
+
+	entityID := pm.Entity().GetID()
+	keyInfo, err := NewPublicJoinKeyInfo(entityID)
+	if err != nil {
+		return err
+	}
+
+	pm.publicJoinKeyInfo = keyInfo
+	log.Debug("createPublicJoinKey: to AddJoinKey", "e", entityID, "hash", keyInfo.Hash)
+	pm.ptt.AddJoinKey(keyInfo.Hash, entityID, false)
+
+	return nil
+}
+
+func (pm *BaseProtocolManager) GetPublicJoinKey() (*KeyInfo, error) {
+	pm.lockJoinKeyInfo.RLock()
+	defer pm.lockJoinKeyInfo.RUnlock()
+
+	if pm.publicJoinKeyInfo == nil {
+		return nil, ErrInvalidKeyInfo
+	}
+
+	return pm.publicJoinKeyInfo, nil
+}
+
+func (pm *BaseProtocolManager) IsPublicJoinKeyHash(hash *common.Address) bool {
+	pm.lockJoinKeyInfo.RLock()
+	defer pm.lockJoinKeyInfo.RUnlock()
+
+	if pm.publicJoinKeyInfo == nil {
+		return false
+	}
+
+	return reflect.DeepEqual(pm.publicJoinKeyInfo.Hash, hash)
+}
+
+/**********
+ * Reader
+ **********/
+
+func (pm *BaseProtocolManager) marshalReaderKey(id *types.PttID) ([]byte, error) {
+	return common.Concat([][]byte{DBReaderPrefix, pm.Entity().GetID()[:], id[:]})
+}
+
+/*
+IsReader returns whether the id joined the public entity with the public join-key.
+*/
+func (pm *BaseProtocolManager) IsReader(id *types.PttID) bool {
+	if !pm.IsPublic() {
+		return false
+	}
+
+	key, err := pm.marshalReaderKey(id)
+	if err != nil {
+		return false
+	}
+
+	_, err = pm.db.DBGet(key)
+	return err == nil
+}
+
+func (pm *BaseProtocolManager) AddReader(id *types.PttID) error {
+	key, err := pm.marshalReaderKey(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = pm.db.DBGet(key)
+	if err == nil {
+		return types.ErrAlreadyExists
+	}
+	if err != leveldb.ErrNotFound {
+		return err
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return err
+	}
+
+	val, err := ts.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return pm.db.DB().Put(key, val)
+}
+
+/*
+IsWritable returns whether the id is able to write to the entity.
+Readers of the public entity and the read-only members are able to read and sync only.
+*/
+func (pm *BaseProtocolManager) IsWritable(id *types.PttID) bool {
+	if pm.IsPublic() && !pm.IsMember(id, false) {
+		return false
+	}
+
+	if pm.IsMaster(id, false) {
+		return true
+	}
+
+	return pm.GetMemberRole(id) != MemberRoleReadOnly
+}
@@ -0,0 +1,647 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/pttdb"
+)
+
+/*
+MasterQuorum is the M in the M-of-N master quorum of the entity, set since UpdateTS.
+
+When Quorum is 0 (the default), all the masters are required to sign the oplogs.
+Otherwise the critical oplogs (registered with RegisterCriticalOp) require Quorum master-signs,
+and the other oplogs are validated with isValidOplog as usual.
+The masters other than the creator are required to co-sign the critical oplogs explicitly (CoSignOplog).
+
+All the quorums are kept, so the critical oplogs are validated with the quorum and the masters as of their master-log.
+
+The critical master-ops are AddMaster, TransferMaster and SetMasterQuorum.
+There is no revoke-master op (the masters are revoked by TransferMaster),
+and MigrateMaster is excluded as it is the master moving to the new id of the same user, force-signed by the master.
+*/
+type MasterQuorum struct {
+	V        types.Version
+	EntityID *types.PttID    `json:"EID"`
+	Quorum   uint32          `json:"Q"`
+	UpdateTS types.Timestamp `json:"UT"`
+	LogID    *types.PttID    `json:"l"`
+}
+
+func marshalMasterQuorumKey(entityID *types.PttID, ts types.Timestamp, logID *types.PttID) ([]byte, error) {
+	tsBytes, err := ts.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return common.Concat([][]byte{DBMasterQuorumPrefix, entityID[:], tsBytes, logID[:]})
+}
+
+func (q *MasterQuorum) Save(db *pttdb.LDBBatch) error {
+	key, err := marshalMasterQuorumKey(q.EntityID, q.UpdateTS, q.LogID)
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := json.Marshal(q)
+	if err != nil {
+		return err
+	}
+
+	return db.DB().Put(key, marshaled)
+}
+
+/*
+getMasterQuorumList returns the quorums of the entity in the order of UpdateTS.
+*/
+func getMasterQuorumList(db *pttdb.LDBBatch, entityID *types.PttID) ([]*MasterQuorum, error) {
+	prefix, err := common.Concat([][]byte{DBMasterQuorumPrefix, entityID[:]})
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := db.DB().NewIteratorWithPrefix(nil, prefix, pttdb.ListOrderNext)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	quorums := make([]*MasterQuorum, 0)
+	for iter.Next() {
+		q := &MasterQuorum{}
+		err = json.Unmarshal(iter.Value(), q)
+		if err != nil {
+			continue
+		}
+		quorums = append(quorums, q)
+	}
+
+	return quorums, nil
+}
+
+/**********
+ * Critical Op
+ **********/
+
+/*
+RegisterCriticalOp registers the op of the oplogs with dbPrefix as requiring the master quorum.
+*/
+func (pm *BaseProtocolManager) RegisterCriticalOp(dbPrefix []byte, op OpType) {
+	pm.lockCriticalOps.Lock()
+	defer pm.lockCriticalOps.Unlock()
+
+	ops, ok := pm.criticalOps[string(dbPrefix)]
+	if !ok {
+		ops = make(map[OpType]bool)
+		pm.criticalOps[string(dbPrefix)] = ops
+	}
+	ops[op] = true
+}
+
+func (pm *BaseProtocolManager) IsCriticalOplog(oplog *BaseOplog) bool {
+	pm.lockCriticalOps.RLock()
+	defer pm.lockCriticalOps.RUnlock()
+
+	ops, ok := pm.criticalOps[string(oplog.dbPrefix)]
+	if !ok {
+		return false
+	}
+
+	return ops[oplog.Op]
+}
+
+/**********
+ * Quorum
+ **********/
+
+func (pm *BaseProtocolManager) loadMasterQuorums() error {
+	quorums, err := getMasterQuorumList(pm.db, pm.Entity().GetID())
+	if err != nil {
+		return err
+	}
+
+	pm.lockMasterQuorum.Lock()
+	defer pm.lockMasterQuorum.Unlock()
+
+	pm.masterQuorums = quorums
+
+	return nil
+}
+
+/*
+MasterQuorum returns the current quorum.
+*/
+func (pm *BaseProtocolManager) MasterQuorum() uint32 {
+	pm.lockMasterQuorum.RLock()
+	defer pm.lockMasterQuorum.RUnlock()
+
+	lenQuorums := len(pm.masterQuorums)
+	if lenQuorums == 0 {
+		return 0
+	}
+
+	return pm.masterQuorums[lenQuorums-1].Quorum
+}
+
+/*
+masterQuorumAt returns the quorum as of the master-log, that is, the newest quorum set not after the master-log.
+The current quorum is returned if the master-log is not available yet.
+*/
+func (pm *BaseProtocolManager) masterQuorumAt(masterLogID *types.PttID) uint32 {
+	if masterLogID == nil {
+		return pm.MasterQuorum()
+	}
+
+	ts, err := pm.masterLogTS(masterLogID)
+	if err != nil {
+		return pm.MasterQuorum()
+	}
+
+	pm.lockMasterQuorum.RLock()
+	defer pm.lockMasterQuorum.RUnlock()
+
+	quorum := uint32(0)
+	for _, q := range pm.masterQuorums {
+		if ts.IsLess(q.UpdateTS) {
+			break
+		}
+		quorum = q.Quorum
+	}
+
+	return quorum
+}
+
+/*
+masterLogTS returns the UpdateTS of the valid master-log.
+The valid master-logs are not changed, and are cached in memory.
+*/
+func (pm *BaseProtocolManager) masterLogTS(masterLogID *types.PttID) (types.Timestamp, error) {
+	pm.lockMasterQuorum.RLock()
+	ts, ok := pm.masterLogTSs[*masterLogID]
+	pm.lockMasterQuorum.RUnlock()
+	if ok {
+		return ts, nil
+	}
+
+	oplog := &BaseOplog{}
+	pm.SetMasterDB(oplog)
+	err := oplog.Get(masterLogID, true)
+	if err != nil {
+		return types.ZeroTimestamp, err
+	}
+	if oplog.MasterLogID == nil {
+		return types.ZeroTimestamp, ErrInvalidOplog
+	}
+
+	pm.lockMasterQuorum.Lock()
+	defer pm.lockMasterQuorum.Unlock()
+
+	pm.masterLogTSs[*masterLogID] = oplog.UpdateTS
+
+	return oplog.UpdateTS, nil
+}
+
+/*
+masterIDsAt returns the masters as of the master-log,
+replayed from the valid master-oplogs not after the master-log.
+The valid master-logs are not changed, and the masters are cached in memory.
+The current masters are returned if the master-log is not available yet.
+*/
+func (pm *BaseProtocolManager) masterIDsAt(masterLogID *types.PttID) map[types.PttID]bool {
+	if masterLogID == nil {
+		return pm.currentMasterIDs()
+	}
+
+	ts, err := pm.masterLogTS(masterLogID)
+	if err != nil {
+		return pm.currentMasterIDs()
+	}
+
+	pm.lockMasterQuorum.RLock()
+	masterIDs, ok := pm.masterIDSets[*masterLogID]
+	pm.lockMasterQuorum.RUnlock()
+	if ok {
+		return masterIDs
+	}
+
+	oplog := &BaseOplog{}
+	pm.SetMasterDB(oplog)
+	oplogs, err := GetOplogList(oplog, nil, 0, pttdb.ListOrderNext, types.StatusAlive, false)
+	if err != nil {
+		return pm.currentMasterIDs()
+	}
+
+	masterIDs = replayMasterOplogs(oplogs, ts)
+
+	pm.lockMasterQuorum.Lock()
+	defer pm.lockMasterQuorum.Unlock()
+
+	pm.masterIDSets[*masterLogID] = masterIDs
+
+	return masterIDs
+}
+
+/*
+resetMasterIDsAt resets the cached masters, as the master-oplogs may be synced not in the order of UpdateTS.
+*/
+func (pm *BaseProtocolManager) resetMasterIDsAt() {
+	pm.lockMasterQuorum.Lock()
+	defer pm.lockMasterQuorum.Unlock()
+
+	pm.masterIDSets = make(map[types.PttID]map[types.PttID]bool)
+}
+
+func (pm *BaseProtocolManager) currentMasterIDs() map[types.PttID]bool {
+	pm.lockMaster.RLock()
+	defer pm.lockMaster.RUnlock()
+
+	masterIDs := make(map[types.PttID]bool)
+	for id := range pm.masters {
+		masterIDs[id] = true
+	}
+
+	return masterIDs
+}
+
+/*
+replayMasterOplogs replays the add / transfer / migrate of the valid master-oplogs not after ts.
+*/
+func replayMasterOplogs(oplogs []*BaseOplog, ts types.Timestamp) map[types.PttID]bool {
+	sorted := make([]*BaseOplog, len(oplogs))
+	copy(sorted, oplogs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].UpdateTS.IsLess(sorted[j].UpdateTS)
+	})
+
+	masterIDs := make(map[types.PttID]bool)
+	for _, oplog := range sorted {
+		if ts.IsLess(oplog.UpdateTS) {
+			break
+		}
+		if oplog.ObjID == nil {
+			continue
+		}
+
+		switch oplog.Op {
+		case MasterOpTypeAddMaster:
+			masterIDs[*oplog.ObjID] = true
+		case MasterOpTypeTransferMaster, MasterOpTypeMigrateMaster:
+			opData := &PersonOpTransferPerson{}
+			err := oplog.GetData(opData)
+			if err != nil || opData.ToID == nil {
+				continue
+			}
+			delete(masterIDs, *oplog.ObjID)
+			masterIDs[*opData.ToID] = true
+		}
+	}
+
+	return masterIDs
+}
+
+func (pm *BaseProtocolManager) isQuorumOplog(oplog *BaseOplog) bool {
+	return pm.IsCriticalOplog(oplog) && pm.MasterQuorum() > 0
+}
+
+/*
+isQuorumReached checks whether the distinct masters in signInfos reach the quorum.
+The quorum is bounded by the number of the masters.
+*/
+func isQuorumReached(signInfos []*SignInfo, masterIDs map[types.PttID]bool, quorum uint32) (uint32, bool) {
+	signed := make(map[types.PttID]bool)
+	for _, signInfo := range signInfos {
+		if masterIDs[*signInfo.ID] {
+			signed[*signInfo.ID] = true
+		}
+	}
+	count := uint32(len(signed))
+
+	required := quorum
+	if required > uint32(len(masterIDs)) {
+		required = uint32(len(masterIDs))
+	}
+
+	if count == 0 || count < required {
+		return 0, false
+	}
+
+	return count, true
+}
+
+/*
+validateMasterSigns validates the master-signs of the oplog.
+
+The critical oplogs require the quorum of the masters as of the master-log of the oplog (the newest master-log if the oplog is not valid yet.)
+The other oplogs, and all the oplogs when the quorum is not set, are validated with isValidOplog.
+*/
+func (pm *BaseProtocolManager) validateMasterSigns(oplog *BaseOplog) (*types.PttID, uint32, bool) {
+	if !pm.IsCriticalOplog(oplog) {
+		return pm.isValidOplog(oplog.MasterSigns)
+	}
+
+	masterLogID := oplog.MasterLogID
+	if masterLogID == nil {
+		masterLogID = pm.GetNewestMasterLogID()
+	}
+
+	quorum := pm.masterQuorumAt(masterLogID)
+	if quorum == 0 {
+		return pm.isValidOplog(oplog.MasterSigns)
+	}
+
+	count, isValid := isQuorumReached(oplog.MasterSigns, pm.masterIDsAt(masterLogID), quorum)
+	if !isValid {
+		return nil, 0, false
+	}
+
+	return masterLogID, count, true
+}
+
+/*
+IsValidQuorumOplog checks whether the critical oplog claimed as valid is with enough master-signs
+as of the master-log of the oplog.
+*/
+func (pm *BaseProtocolManager) IsValidQuorumOplog(oplog *BaseOplog) bool {
+	if oplog.MasterLogID == nil || !pm.IsCriticalOplog(oplog) {
+		return true
+	}
+
+	quorum := pm.masterQuorumAt(oplog.MasterLogID)
+	if quorum == 0 {
+		return true
+	}
+
+	_, isValid := isQuorumReached(oplog.MasterSigns, pm.masterIDsAt(oplog.MasterLogID), quorum)
+	return isValid
+}
+
+/*
+isToMasterSign checks whether we automatically master-sign the oplog.
+The masters do not automatically co-sign the critical oplogs created by the others.
+*/
+func (pm *BaseProtocolManager) isToMasterSign(oplog *BaseOplog) bool {
+	if !pm.isQuorumOplog(oplog) {
+		return true
+	}
+
+	myID := pm.Ptt().GetMyEntity().GetID()
+	if reflect.DeepEqual(myID, oplog.CreatorID) {
+		return true
+	}
+
+	if !pm.IsMaster(myID, false) {
+		return true
+	}
+
+	return IDInOplogSigns(myID, oplog.MasterSigns)
+}
+
+/*
+SetMasterQuorum sets the M in the M-of-N master quorum with the master-oplog. 0 as requiring all the masters.
+*/
+func (pm *BaseProtocolManager) SetMasterQuorum(quorum uint32) error {
+	myID := pm.Ptt().GetMyEntity().GetID()
+
+	// 1. validate
+	if pm.Entity().GetStatus() != types.StatusAlive {
+		return types.ErrInvalidStatus
+	}
+
+	if !pm.IsMaster(myID, false) {
+		return types.ErrInvalidID
+	}
+
+	if quorum > uint32(pm.maxMasters) {
+		return ErrInvalidQuorum
+	}
+
+	// 2. oplog
+	opData := &MasterOpSetMasterQuorum{Quorum: quorum}
+	theOplog, err := pm.NewMasterOplog(pm.Entity().GetID(), MasterOpTypeSetMasterQuorum, opData)
+	if err != nil {
+		return err
+	}
+	oplog := theOplog.GetBaseOplog()
+
+	err = pm.SignOplog(oplog)
+	if err != nil {
+		return err
+	}
+
+	err = oplog.Lock()
+	if err != nil {
+		return err
+	}
+	defer oplog.Unlock()
+
+	// 3. set quorum
+	if oplog.MasterLogID != nil {
+		err = pm.saveMasterQuorumWithOplog(oplog, quorum)
+		if err != nil {
+			return err
+		}
+		oplog.IsSync = true
+	}
+
+	// 4. save oplog
+	err = oplog.Save(true, pm.MasterMerkle())
+	if err != nil {
+		return err
+	}
+
+	pm.broadcastMasterOplogCore(oplog)
+
+	return nil
+}
+
+/*
+saveMasterQuorumWithOplog saves the quorum into the quorums in the order of UpdateTS.
+The quorums set by the older oplogs are kept as well, to validate the oplogs as of the older master-logs.
+*/
+func (pm *BaseProtocolManager) saveMasterQuorumWithOplog(oplog *BaseOplog, quorum uint32) error {
+	pm.lockMasterQuorum.Lock()
+	defer pm.lockMasterQuorum.Unlock()
+
+	for _, each := range pm.masterQuorums {
+		if reflect.DeepEqual(each.LogID, oplog.ID) {
+			return nil
+		}
+	}
+
+	q := &MasterQuorum{
+		V:        types.CurrentVersion,
+		EntityID: pm.Entity().GetID(),
+		Quorum:   quorum,
+		UpdateTS: oplog.UpdateTS,
+		LogID:    oplog.ID,
+	}
+
+	err := q.Save(pm.db)
+	if err != nil {
+		return err
+	}
+
+	idx := sort.Search(len(pm.masterQuorums), func(i int) bool {
+		return q.UpdateTS.IsLess(pm.masterQuorums[i].UpdateTS)
+	})
+
+	pm.masterQuorums = append(pm.masterQuorums, nil)
+	copy(pm.masterQuorums[(idx+1):], pm.masterQuorums[idx:])
+	pm.masterQuorums[idx] = q
+
+	return nil
+}
+
+/**********
+ * Co-Sign
+ **********/
+
+/*
+GetPendingQuorumOplogs returns the pending critical oplogs waiting for the co-sign of the masters.
+*/
+func (pm *BaseProtocolManager) GetPendingQuorumOplogs(setDB func(oplog *BaseOplog)) ([]*BaseOplog, error) {
+	oplog := &BaseOplog{}
+	setDB(oplog)
+
+	pendingLogs, err := GetOplogList(oplog, nil, 0, pttdb.ListOrderNext, types.StatusPending, false)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]*BaseOplog, 0, len(pendingLogs))
+	for _, eachLog := range pendingLogs {
+		setDB(eachLog)
+		if !pm.isQuorumOplog(eachLog) {
+			continue
+		}
+		logs = append(logs, eachLog)
+	}
+
+	return logs, nil
+}
+
+/*
+CoSignOplog master-signs the pending critical oplog,
+and handles the oplog as the received pending oplog to be valid if the quorum is reached.
+
+The pending oplogs are expired in ExpireOplogSeconds, the masters are required to co-sign within the period.
+*/
+func (pm *BaseProtocolManager) CoSignOplog(
+	logID *types.PttID,
+
+	setDB func(oplog *BaseOplog),
+	handlePendingOplogs func(oplogs []*BaseOplog, peer *PttPeer) error,
+) error {
+
+	myEntity := pm.Ptt().GetMyEntity()
+	myID := myEntity.GetID()
+
+	if !pm.IsMaster(myID, false) {
+		return types.ErrInvalidID
+	}
+
+	oplog := &BaseOplog{}
+	setDB(oplog)
+
+	err := oplog.Get(logID, false)
+	if err != nil {
+		return err
+	}
+	setDB(oplog)
+
+	if oplog.MasterLogID != nil || !pm.isQuorumOplog(oplog) {
+		return ErrInvalidOplog
+	}
+
+	if IDInOplogSigns(myID, oplog.MasterSigns) {
+		return nil
+	}
+
+	err = myEntity.MasterSign(oplog)
+	log.Debug("CoSignOplog: after MasterSign", "e", err, "logID", logID)
+	if err != nil {
+		return err
+	}
+
+	return handlePendingOplogs([]*BaseOplog{oplog}, nil)
+}
+
+func (pm *BaseProtocolManager) CoSignMasterOplog(logID *types.PttID) error {
+	return pm.CoSignOplog(logID, pm.SetMasterDB, pm.HandlePendingMasterOplogs)
+}
+
+/**********
+ * Handle Oplog
+ **********/
+
+func (pm *BaseProtocolManager) handleSetMasterQuorumLog(oplog *BaseOplog, info *ProcessPersonInfo) ([]*BaseOplog, error) {
+
+	if !pm.IsMaster(oplog.CreatorID, false) {
+		return nil, ErrSkipOplog
+	}
+
+	opData := &MasterOpSetMasterQuorum{}
+	err := oplog.GetData(opData)
+	if err != nil {
+		return nil, err
+	}
+
+	err = pm.saveMasterQuorumWithOplog(oplog, opData.Quorum)
+	if err != nil {
+		return nil, err
+	}
+
+	oplog.IsSync = true
+
+	return nil, nil
+}
+
+func (pm *BaseProtocolManager) handlePendingSetMasterQuorumLog(oplog *BaseOplog, info *ProcessPersonInfo) (types.Bool, []*BaseOplog, error) {
+
+	if !pm.IsMaster(oplog.CreatorID, false) {
+		return false, nil, ErrSkipOplog
+	}
+
+	return true, nil, nil
+}
+
+func (pm *BaseProtocolManager) setNewestSetMasterQuorumLog(oplog *BaseOplog) (types.Bool, error) {
+	pm.lockMasterQuorum.RLock()
+	defer pm.lockMasterQuorum.RUnlock()
+
+	lenQuorums := len(pm.masterQuorums)
+	if lenQuorums == 0 {
+		return true, nil
+	}
+
+	return !types.Bool(reflect.DeepEqual(oplog.ID, pm.masterQuorums[lenQuorums-1].LogID)), nil
+}
+
+func (pm *BaseProtocolManager) handleFailedSetMasterQuorumLog(oplog *BaseOplog) error {
+	return nil
+}
+
+func (pm *BaseProtocolManager) handleFailedValidSetMasterQuorumLog(oplog *BaseOplog) error {
+	return nil
+}
@@ -0,0 +1,261 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+func tQuorumManager(quorums []*MasterQuorum, masterIDs ...*types.PttID) *BaseProtocolManager {
+	pm := &BaseProtocolManager{
+		masters:       make(map[types.PttID]*Master),
+		criticalOps:   make(map[string]map[OpType]bool),
+		masterQuorums: quorums,
+		masterLogTSs:  make(map[types.PttID]types.Timestamp),
+		masterIDSets:  make(map[types.PttID]map[types.PttID]bool),
+	}
+	pm.isValidOplog = pm.defaultIsValidOplog
+
+	for _, id := range masterIDs {
+		pm.masters[*id] = NewEmptyMaster()
+	}
+
+	pm.RegisterCriticalOp(DBMasterOplogPrefix, MasterOpTypeTransferMaster)
+
+	return pm
+}
+
+func tQuorumOplog(op OpType, signerIDs ...*types.PttID) *BaseOplog {
+	oplog := &BaseOplog{Op: op, dbPrefix: DBMasterOplogPrefix}
+	for _, id := range signerIDs {
+		oplog.MasterSigns = append(oplog.MasterSigns, &SignInfo{ID: id})
+	}
+	return oplog
+}
+
+func TestIsQuorumReached(t *testing.T) {
+	id1, id2, id3, idOther := &types.PttID{1}, &types.PttID{2}, &types.PttID{3}, &types.PttID{4}
+	masterIDs := map[types.PttID]bool{*id1: true, *id2: true, *id3: true}
+
+	// prepare test-cases
+	tests := []struct {
+		name      string
+		signIDs   []*types.PttID
+		quorum    uint32
+		wantCount uint32
+		want      bool
+	}{
+		{
+			name:    "pending",
+			signIDs: []*types.PttID{id1},
+			quorum:  2,
+			want:    false,
+		},
+		{
+			name:      "co-signed",
+			signIDs:   []*types.PttID{id1, id2},
+			quorum:    2,
+			wantCount: 2,
+			want:      true,
+		},
+		{
+			name:    "non-master",
+			signIDs: []*types.PttID{id1, idOther},
+			quorum:  2,
+			want:    false,
+		},
+		{
+			name:    "duplicated",
+			signIDs: []*types.PttID{id1, id1},
+			quorum:  2,
+			want:    false,
+		},
+		{
+			name:      "quorum more than masters",
+			signIDs:   []*types.PttID{id1, id2, id3},
+			quorum:    5,
+			wantCount: 3,
+			want:      true,
+		},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signInfos := make([]*SignInfo, len(tt.signIDs))
+			for i, id := range tt.signIDs {
+				signInfos[i] = &SignInfo{ID: id}
+			}
+
+			gotCount, got := isQuorumReached(signInfos, masterIDs, tt.quorum)
+			if got != tt.want || gotCount != tt.wantCount {
+				t.Errorf("isQuorumReached() = (%v, %v), want (%v, %v)", gotCount, got, tt.wantCount, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseProtocolManager_validateMasterSigns(t *testing.T) {
+	id1, id2, id3 := &types.PttID{1}, &types.PttID{2}, &types.PttID{3}
+	quorums := []*MasterQuorum{{Quorum: 2, UpdateTS: types.Timestamp{Ts: 1000}}}
+	pm := tQuorumManager(quorums, id1, id2, id3)
+
+	// pending: the critical oplog signed by the creator only.
+	oplog := tQuorumOplog(MasterOpTypeTransferMaster, id1)
+	if _, _, isValid := pm.validateMasterSigns(oplog); isValid {
+		t.Errorf("validateMasterSigns() pending = true, want false")
+	}
+
+	// co-sign: reaching the quorum.
+	oplog.MasterSigns = append(oplog.MasterSigns, &SignInfo{ID: id2})
+	_, count, isValid := pm.validateMasterSigns(oplog)
+	if !isValid || count != 2 {
+		t.Errorf("validateMasterSigns() co-signed = (%v, %v), want (2, true)", count, isValid)
+	}
+
+	// the non-critical oplogs are validated with isValidOplog (all the masters.)
+	oplog = tQuorumOplog(MasterOpTypeAddMaster, id1, id2)
+	if _, _, isValid := pm.validateMasterSigns(oplog); isValid {
+		t.Errorf("validateMasterSigns() non-critical = true, want false")
+	}
+}
+
+func TestBaseProtocolManager_IsValidQuorumOplog(t *testing.T) {
+	id1, id2, id3 := &types.PttID{1}, &types.PttID{2}, &types.PttID{3}
+	quorums := []*MasterQuorum{
+		{Quorum: 1, UpdateTS: types.Timestamp{Ts: 1000}},
+		{Quorum: 3, UpdateTS: types.Timestamp{Ts: 2000}},
+	}
+	pm := tQuorumManager(quorums, id1, id2, id3)
+
+	masterLogID1, masterLogID2 := &types.PttID{11}, &types.PttID{12}
+	pm.masterLogTSs[*masterLogID1] = types.Timestamp{Ts: 1500}
+	pm.masterLogTSs[*masterLogID2] = types.Timestamp{Ts: 2500}
+	pm.masterIDSets[*masterLogID1] = pm.currentMasterIDs()
+	pm.masterIDSets[*masterLogID2] = pm.currentMasterIDs()
+
+	// valid as of the master-log with quorum 1, not invalidated by the newer quorum.
+	oplog := tQuorumOplog(MasterOpTypeTransferMaster, id1)
+	oplog.MasterLogID = masterLogID1
+	if !pm.IsValidQuorumOplog(oplog) {
+		t.Errorf("IsValidQuorumOplog() as of quorum 1 = false, want true")
+	}
+
+	// invalid as of the master-log with quorum 3.
+	oplog.MasterLogID = masterLogID2
+	if pm.IsValidQuorumOplog(oplog) {
+		t.Errorf("IsValidQuorumOplog() as of quorum 3 = true, want false")
+	}
+}
+
+func TestBaseProtocolManager_validateMasterSigns_MastersChanged(t *testing.T) {
+	id1, id2, id3, id4 := &types.PttID{1}, &types.PttID{2}, &types.PttID{3}, &types.PttID{4}
+	quorums := []*MasterQuorum{{Quorum: 2, UpdateTS: types.Timestamp{Ts: 1000}}}
+
+	// the masters were 1, 2, 4 as of the master-log, 4 transferred to 3 after signing.
+	pm := tQuorumManager(quorums, id1, id2, id3)
+
+	masterLogID := &types.PttID{11}
+	pm.masterLogTSs[*masterLogID] = types.Timestamp{Ts: 1500}
+	pm.masterIDSets[*masterLogID] = map[types.PttID]bool{*id1: true, *id2: true, *id4: true}
+
+	// prepare test-cases
+	tests := []struct {
+		name    string
+		signIDs []*types.PttID
+		want    bool
+	}{
+		{
+			name:    "signed by the masters as of the master-log",
+			signIDs: []*types.PttID{id1, id4},
+			want:    true,
+		},
+		{
+			name:    "signed by the master added afterwards",
+			signIDs: []*types.PttID{id1, id3},
+			want:    false,
+		},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oplog := tQuorumOplog(MasterOpTypeTransferMaster, tt.signIDs...)
+			oplog.MasterLogID = masterLogID
+
+			if _, _, got := pm.validateMasterSigns(oplog); got != tt.want {
+				t.Errorf("validateMasterSigns() = %v, want %v", got, tt.want)
+			}
+			if got := pm.IsValidQuorumOplog(oplog); got != tt.want {
+				t.Errorf("IsValidQuorumOplog() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayMasterOplogs(t *testing.T) {
+	id1, id2, id3 := &types.PttID{1}, &types.PttID{2}, &types.PttID{3}
+
+	transferLog := &BaseOplog{
+		Op:       MasterOpTypeTransferMaster,
+		ObjID:    id2,
+		UpdateTS: types.Timestamp{Ts: 3000},
+		Data:     &PersonOpTransferPerson{ToID: id3},
+	}
+
+	oplogs := []*BaseOplog{
+		transferLog,
+		{Op: MasterOpTypeAddMaster, ObjID: id1, UpdateTS: types.Timestamp{Ts: 1000}},
+		{Op: MasterOpTypeSetMasterQuorum, ObjID: id1, UpdateTS: types.Timestamp{Ts: 1500}},
+		{Op: MasterOpTypeAddMaster, ObjID: id2, UpdateTS: types.Timestamp{Ts: 2000}},
+	}
+
+	// prepare test-cases
+	tests := []struct {
+		name string
+		ts   types.Timestamp
+		want map[types.PttID]bool
+	}{
+		{
+			name: "before add",
+			ts:   types.Timestamp{Ts: 1500},
+			want: map[types.PttID]bool{*id1: true},
+		},
+		{
+			name: "after add",
+			ts:   types.Timestamp{Ts: 2500},
+			want: map[types.PttID]bool{*id1: true, *id2: true},
+		},
+		{
+			name: "after transfer",
+			ts:   types.Timestamp{Ts: 3000},
+			want: map[types.PttID]bool{*id1: true, *id3: true},
+		},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replayMasterOplogs(oplogs, tt.ts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("replayMasterOplogs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
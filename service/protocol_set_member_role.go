@@ -0,0 +1,177 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/*
+SetMemberRole sets the role of the member with the master-oplog.
+Only the masters are able to set the roles, and the masters are not assigned with any role.
+*/
+func (pm *BaseProtocolManager) SetMemberRole(id *types.PttID, role MemberRole) error {
+	ptt := pm.Ptt()
+	myID := ptt.GetMyEntity().GetID()
+
+	// 1. validate
+	if pm.Entity().GetStatus() != types.StatusAlive {
+		return types.ErrInvalidStatus
+	}
+
+	if !role.IsValid() {
+		return ErrInvalidMemberRole
+	}
+
+	if !pm.IsMaster(myID, false) {
+		return types.ErrInvalidID
+	}
+
+	if pm.IsMaster(id, false) {
+		return types.ErrInvalidID
+	}
+
+	if !pm.IsMember(id, false) {
+		return types.ErrInvalidID
+	}
+
+	// 2. oplog
+	opData := &MasterOpSetMemberRole{Role: role}
+	theOplog, err := pm.NewMasterOplog(id, MasterOpTypeSetMemberRole, opData)
+	if err != nil {
+		return err
+	}
+	oplog := theOplog.GetBaseOplog()
+
+	err = pm.SignOplog(oplog)
+	if err != nil {
+		return err
+	}
+
+	err = oplog.Lock()
+	if err != nil {
+		return err
+	}
+	defer oplog.Unlock()
+
+	// 3. set role
+	if oplog.MasterLogID != nil {
+		err = pm.saveMemberRoleWithOplog(oplog, role)
+		log.Debug("SetMemberRole: after saveMemberRoleWithOplog", "e", err)
+		if err != nil {
+			return err
+		}
+		oplog.IsSync = true
+	}
+
+	// 4. save oplog
+	err = oplog.Save(true, pm.MasterMerkle())
+	if err != nil {
+		return err
+	}
+
+	pm.broadcastMasterOplogCore(oplog)
+
+	return nil
+}
+
+func (pm *BaseProtocolManager) saveMemberRoleWithOplog(oplog *BaseOplog, role MemberRole) error {
+	entityID := pm.Entity().GetID()
+
+	pm.lockMemberRole.Lock()
+	defer pm.lockMemberRole.Unlock()
+
+	orig, err := getMemberRoleInfo(pm.db, entityID, oplog.ObjID)
+	if err != nil && err != leveldb.ErrNotFound {
+		return err
+	}
+	if orig != nil {
+		if reflect.DeepEqual(orig.LogID, oplog.ID) {
+			return nil
+		}
+		if oplog.UpdateTS.IsLess(orig.UpdateTS) {
+			return ErrNewerOplog
+		}
+	}
+
+	r := &MemberRoleInfo{
+		V:        types.CurrentVersion,
+		ID:       oplog.ObjID,
+		EntityID: entityID,
+		Role:     role,
+		UpdateTS: oplog.UpdateTS,
+		LogID:    oplog.ID,
+	}
+
+	return r.Save(pm.db)
+}
+
+/*
+GetMemberRole returns the role of the member. MemberRoleMember is returned if the role is never set.
+*/
+func (pm *BaseProtocolManager) GetMemberRole(id *types.PttID) MemberRole {
+	r, err := getMemberRoleInfo(pm.db, pm.Entity().GetID(), id)
+	if err != nil {
+		return MemberRoleMember
+	}
+
+	return r.Role
+}
+
+/*
+GetMemberRoleAt returns the role of the member at ts.
+isKnown is false if the role is set after ts,
+because only the latest role is kept and the earlier role is not known.
+*/
+func (pm *BaseProtocolManager) GetMemberRoleAt(id *types.PttID, ts types.Timestamp) (role MemberRole, isKnown bool) {
+	r, err := getMemberRoleInfo(pm.db, pm.Entity().GetID(), id)
+	if err != nil {
+		return MemberRoleMember, true
+	}
+
+	if ts.IsLess(r.UpdateTS) {
+		return MemberRoleMember, false
+	}
+
+	return r.Role, true
+}
+
+/*
+GetMemberRoles returns the members with the role explicitly set.
+*/
+func (pm *BaseProtocolManager) GetMemberRoles() ([]*MemberRoleInfo, error) {
+	return getMemberRoleInfoList(pm.db, pm.Entity().GetID())
+}
+
+/*
+IsModerator returns whether the id is able to delete the objects created by the others.
+*/
+func (pm *BaseProtocolManager) IsModerator(id *types.PttID) bool {
+	if pm.IsMaster(id, false) {
+		return true
+	}
+
+	return pm.IsMember(id, false) && pm.GetMemberRole(id) == MemberRoleModerator
+}
+
+func (pm *BaseProtocolManager) CleanMemberRole() error {
+	return cleanMemberRoleInfo(pm.db, pm.Entity().GetID())
+}
@@ -0,0 +1,79 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func (pm *BaseProtocolManager) handleSetMemberRoleLog(oplog *BaseOplog, info *ProcessPersonInfo) ([]*BaseOplog, error) {
+
+	if !pm.IsMaster(oplog.CreatorID, false) {
+		return nil, ErrSkipOplog
+	}
+
+	opData := &MasterOpSetMemberRole{}
+	err := oplog.GetData(opData)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opData.Role.IsValid() {
+		return nil, ErrSkipOplog
+	}
+
+	err = pm.saveMemberRoleWithOplog(oplog, opData.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	oplog.IsSync = true
+
+	return nil, nil
+}
+
+func (pm *BaseProtocolManager) handlePendingSetMemberRoleLog(oplog *BaseOplog, info *ProcessPersonInfo) (types.Bool, []*BaseOplog, error) {
+
+	if !pm.IsMaster(oplog.CreatorID, false) {
+		return false, nil, ErrSkipOplog
+	}
+
+	return true, nil, nil
+}
+
+func (pm *BaseProtocolManager) setNewestSetMemberRoleLog(oplog *BaseOplog) (types.Bool, error) {
+	r, err := getMemberRoleInfo(pm.db, pm.Entity().GetID(), oplog.ObjID)
+	if err == leveldb.ErrNotFound {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return !types.Bool(reflect.DeepEqual(oplog.ID, r.LogID)), nil
+}
+
+func (pm *BaseProtocolManager) handleFailedSetMemberRoleLog(oplog *BaseOplog) error {
+	return nil
+}
+
+func (pm *BaseProtocolManager) handleFailedValidSetMemberRoleLog(oplog *BaseOplog) error {
+	return nil
+}
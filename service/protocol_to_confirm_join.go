@@ -42,8 +42,8 @@ func (p *BasePtt) ToConfirmJoin(confirmKey []byte, entity Entity, joinEntity *Jo
 	p.lockConfirmJoin.Lock()
 	defer p.lockConfirmJoin.Unlock()
 
-	_, ok := p.confirmJoins[confirmKeyStr]
-	if ok {
+	origConfirmJoin, ok := p.confirmJoins[confirmKeyStr]
+	if ok && origConfirmJoin.UpdateTS.Ts >= ts.Ts-IntRenewJoinKeySeconds {
 		return types.ErrAlreadyExists
 	}
 
@@ -51,3 +51,30 @@ func (p *BasePtt) ToConfirmJoin(confirmKey []byte, entity Entity, joinEntity *Jo
 
 	return nil
 }
+
+func (p *BasePtt) GetConfirmJoin(confirmKey []byte) (*ConfirmJoin, error) {
+	p.lockConfirmJoin.RLock()
+	defer p.lockConfirmJoin.RUnlock()
+
+	confirmJoin, ok := p.confirmJoins[string(confirmKey)]
+	if !ok {
+		return nil, types.ErrInvalidID
+	}
+
+	return confirmJoin, nil
+}
+
+func (p *BasePtt) RemoveConfirmJoin(confirmKey []byte) error {
+	p.lockConfirmJoin.Lock()
+	defer p.lockConfirmJoin.Unlock()
+
+	confirmKeyStr := string(confirmKey)
+	_, ok := p.confirmJoins[confirmKeyStr]
+	if !ok {
+		return types.ErrAlreadyDeleted
+	}
+
+	delete(p.confirmJoins, confirmKeyStr)
+
+	return nil
+}
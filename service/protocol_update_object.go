@@ -65,6 +65,9 @@ func (pm *BaseProtocolManager) UpdateObject(
 	if myEntity.GetStatus() != types.StatusAlive {
 		return types.ErrInvalidStatus
 	}
+	if !myEntity.IsPostable() {
+		return ErrNotPermitted
+	}
 
 	// 1. lock object
 	origObj.SetID(id)
@@ -56,6 +56,8 @@ type Ptt interface {
 
 	RegisterEntityPeerWithOtherUserID(e Entity, id *types.PttID, peerType PeerType, isLocked bool) error
 
+	GetEntity(id *types.PttID) Entity
+
 	// join
 
 	AddJoinKey(hash *common.Address, entityID *types.PttID, isLocked bool) error
@@ -63,12 +65,36 @@ type Ptt interface {
 
 	TryJoin(challenge []byte, hash *common.Address, key *ecdsa.PrivateKey, request *JoinRequest) error
 
+	GetConfirmJoin(confirmKey []byte) (*ConfirmJoin, error)
+	ApproveJoin(confirmKey []byte) error
+	RemoveConfirmJoin(confirmKey []byte) error
+
 	// op
 
 	AddOpKey(hash *common.Address, entityID *types.PttID, isLocked bool) error
 	RemoveOpKey(hash *common.Address, entityID *types.PttID, isLocked bool) error
 	RequestOpKeyByEntity(entity Entity, peer *PttPeer) error
 
+	// public
+
+	GetPublicEntities(serviceName string) []*PublicEntity
+	GetPublicEntityNodeID(entityID *types.PttID) (*discover.NodeID, error)
+
+	// blocklist
+
+	BlockUser(id *types.PttID) error
+	UnblockUser(id *types.PttID) error
+	IsBlockedUser(id *types.PttID) bool
+	GetBlockedUserList() ([]*BlockedUser, error)
+
+	// presence
+
+	GetPresence(id *types.PttID) (bool, types.Timestamp)
+
+	// notification
+
+	CreateNotification(theType NotificationType, fromID *types.PttID, entityID *types.PttID, objID *types.PttID, subObjID *types.PttID) error
+
 	// sync
 
 	SyncWG() *sync.WaitGroup
@@ -151,10 +177,18 @@ type BasePtt struct {
 	lockConfirmJoin sync.RWMutex
 	confirmJoins    map[string]*ConfirmJoin
 
+	// notifications
+	lockNotification sync.Mutex
+
 	// ops
 	lockOps sync.RWMutex
 	ops     map[common.Address]*types.PttID
 
+	// public entities
+	lockPublicEntities sync.RWMutex
+	publicEntities     map[types.PttID]*PublicEntity
+	publicTopics       map[types.PttID]chan struct{}
+
 	// sync
 	quitSync chan struct{}
 	syncWG   sync.WaitGroup
@@ -230,6 +264,10 @@ func NewPtt(ctx *ServiceContext, cfg *Config, myNodeID *discover.NodeID, myNodeK
 		// ops
 		ops: make(map[common.Address]*types.PttID),
 
+		// public entities
+		publicEntities: make(map[types.PttID]*PublicEntity),
+		publicTopics:   make(map[types.PttID]chan struct{}),
+
 		// sync
 		quitSync: make(chan struct{}),
 
@@ -321,6 +359,12 @@ func (p *BasePtt) Start(server *p2p.Server) error {
 		return errMapToErr(errMap)
 	}
 
+	p.syncWG.Add(1)
+	go func() {
+		defer p.syncWG.Done()
+		p.PublicEntityLoop()
+	}()
+
 	return nil
 }
 
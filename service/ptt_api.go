@@ -87,6 +87,24 @@ func (api *PrivateAPI) GetOps() (map[common.Address]*types.PttID, error) {
 	return api.p.GetOps(), nil
 }
 
+/**********
+ * Key
+ **********/
+
+/*
+GetKeyReport lists every join / op / sign key with the age and the expiry per entity.
+*/
+func (api *PrivateAPI) GetKeyReport() ([]*BackendKeyReport, error) {
+	return api.p.GetKeyReport()
+}
+
+/*
+RotateAllKeys renews the sign-keys, and the op-keys (revoking the old ones) / join-keys of all the entities that I am the master.
+*/
+func (api *PrivateAPI) RotateAllKeys() (*BackendRotateKeys, error) {
+	return api.p.RotateAllKeys()
+}
+
 /**********
  * PttOplog
  **********/
@@ -108,7 +126,21 @@ func (api *PrivateAPI) GetPttOplogSeen() (types.Timestamp, error) {
  **********/
 
 func (api *PrivateAPI) SetLocale(locale Locale) (Locale, error) {
-	err := SetLocale(locale)
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return CurrentLocale, err
+	}
+
+	_, err = SetLocaleWithTS(locale, ts)
+	if err != nil {
+		return CurrentLocale, err
+	}
+
+	myEntity := api.p.GetMyEntity()
+	if myEntity != nil {
+		err = myEntity.SyncLocale(locale, ts)
+	}
+
 	return CurrentLocale, err
 }
 
@@ -116,6 +148,31 @@ func (api *PrivateAPI) GetLocale() (Locale, error) {
 	return CurrentLocale, nil
 }
 
+/**********
+ * Notification
+ **********/
+
+func (api *PrivateAPI) GetNotifications(startID string, limit int, listOrder pttdb.ListOrder) ([]*Notification, error) {
+	return api.p.GetNotifications([]byte(startID), limit, listOrder)
+}
+
+func (api *PrivateAPI) MarkNotificationRead(id string) (bool, error) {
+	return api.p.MarkNotificationRead([]byte(id))
+}
+
+/**********
+ * Presence
+ **********/
+
+func (api *PrivateAPI) SetHidePresence(isHide bool) (bool, error) {
+	err := SetHidePresence(isHide)
+	return LoadHidePresence(), err
+}
+
+func (api *PrivateAPI) GetHidePresence() (bool, error) {
+	return LoadHidePresence(), nil
+}
+
 /**********
  * P2P
  **********/
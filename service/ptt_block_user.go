@@ -0,0 +1,114 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/pttdb"
+)
+
+/*
+BlockedUser is the user in the local blocklist.
+The articles / comments from the blocked users are hidden,
+the friend-requests from the blocked users are rejected,
+and the peers of the blocked users are refused except the members of the shared entities.
+*/
+type BlockedUser struct {
+	V        types.Version
+	ID       *types.PttID    `json:"ID"`
+	CreateTS types.Timestamp `json:"CT"`
+}
+
+func marshalBlockedUserKey(id *types.PttID) ([]byte, error) {
+	return common.Concat([][]byte{DBBlockedUserPrefix, id[:]})
+}
+
+func (p *BasePtt) BlockUser(id *types.PttID) error {
+	myID := p.GetMyEntity().GetID()
+	if reflect.DeepEqual(myID, id) {
+		return types.ErrInvalidID
+	}
+
+	key, err := marshalBlockedUserKey(id)
+	if err != nil {
+		return err
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return err
+	}
+
+	u := &BlockedUser{
+		V:        types.CurrentVersion,
+		ID:       id,
+		CreateTS: ts,
+	}
+	marshaled, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+
+	return dbMeta.Put(key, marshaled)
+}
+
+func (p *BasePtt) UnblockUser(id *types.PttID) error {
+	key, err := marshalBlockedUserKey(id)
+	if err != nil {
+		return err
+	}
+
+	return dbMeta.Delete(key)
+}
+
+func (p *BasePtt) IsBlockedUser(id *types.PttID) bool {
+	if id == nil || dbMeta == nil {
+		return false
+	}
+
+	key, err := marshalBlockedUserKey(id)
+	if err != nil {
+		return false
+	}
+
+	_, err = dbMeta.Get(key)
+	return err == nil
+}
+
+func (p *BasePtt) GetBlockedUserList() ([]*BlockedUser, error) {
+	iter, err := dbMeta.NewIteratorWithPrefix(nil, DBBlockedUserPrefix, pttdb.ListOrderNext)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	users := make([]*BlockedUser, 0)
+	for iter.Next() {
+		u := &BlockedUser{}
+		err = json.Unmarshal(iter.Value(), u)
+		if err != nil {
+			continue
+		}
+		users = append(users, u)
+	}
+
+	return users, nil
+}
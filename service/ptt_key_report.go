@@ -0,0 +1,220 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+)
+
+/*
+GetKeyReport lists the join-keys / op-keys of all the entities and my sign-keys, with the age and the expiry.
+*/
+func (p *BasePtt) GetKeyReport() ([]*BackendKeyReport, error) {
+	now, err := types.GetTimestamp()
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]*BackendKeyReport, 0)
+
+	// sign-keys
+	myEntity := p.myEntity
+	if myEntity != nil {
+		reports = append(reports, keyInfoToBackendKeyReport(myEntity.SignKey(), myEntity.GetID(), "", KeyReportTypeSign, 0, now))
+		reports = append(reports, keyInfoToBackendKeyReport(myEntity.NodeSignKey(), myEntity.GetID(), "", KeyReportTypeNodeSign, 0, now))
+	}
+
+	// entities
+	for _, entity := range p.entityList() {
+		if entity.GetStatus() != types.StatusAlive {
+			continue
+		}
+
+		pm := entity.PM()
+		entityID := entity.GetID()
+		serviceName := entity.Service().Name()
+
+		for _, keyInfo := range pm.JoinKeyList() {
+			reports = append(reports, keyInfoToBackendKeyReport(keyInfo, entityID, serviceName, KeyReportTypeJoin, IntRenewJoinKeySeconds, now))
+		}
+
+		for _, keyInfo := range pm.OpKeyList() {
+			reports = append(reports, keyInfoToBackendKeyReport(keyInfo, entityID, serviceName, KeyReportTypeOp, pm.ExpireOpKeySeconds(), now))
+		}
+	}
+
+	return reports, nil
+}
+
+/*
+RotateAllKeys renews my sign-keys, and for all the entities that I am the master:
+creates the new op-key and revokes the old op-keys, and renews the join-keys.
+
+The old op-keys are revoked only after the new op-key is valid (signed by the masters).
+If the new op-key is still pending, the revocation is retried after RotateOpKeyGraceSeconds,
+and the old op-keys are kept (and expire naturally) if the new op-key is still not valid.
+
+Used after a suspected compromise.
+*/
+func (p *BasePtt) RotateAllKeys() (*BackendRotateKeys, error) {
+	result := &BackendRotateKeys{}
+
+	// sign-keys
+	myEntity := p.myEntity
+	if myEntity == nil {
+		return nil, ErrInvalidEntity
+	}
+	err := myEntity.RenewSignKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	myID := myEntity.GetID()
+	for _, entity := range p.entityList() {
+		if entity.GetStatus() != types.StatusAlive {
+			continue
+		}
+
+		pm := entity.PM()
+		if !pm.IsMaster(myID, false) {
+			continue
+		}
+		result.NEntity++
+
+		// join-key
+		err = pm.RenewJoinKey()
+		if err != nil {
+			log.Warn("RotateAllKeys: unable to RenewJoinKey", "entity", entity.GetID(), "e", err)
+			result.NFailed++
+		} else {
+			result.NJoinKey++
+		}
+
+		// op-key
+		oldOpKeys := pm.OpKeyList()
+
+		err = pm.ForceCreateOpKey()
+		if err != nil {
+			log.Warn("RotateAllKeys: unable to ForceCreateOpKey", "entity", entity.GetID(), "e", err)
+			result.NFailed++
+			continue
+		}
+		result.NOpKey++
+
+		toRevokeOpKeys := newOpKeyRevokeList(pm, oldOpKeys)
+		if toRevokeOpKeys == nil {
+			result.NPendingOpKey++
+			entityID := entity.GetID()
+			time.AfterFunc(RotateOpKeyGraceSeconds, func() {
+				toRevokeOpKeys := newOpKeyRevokeList(pm, oldOpKeys)
+				if toRevokeOpKeys == nil {
+					log.Warn("RotateAllKeys: new op-key is still not valid, keep the old op-keys", "entity", entityID)
+					return
+				}
+				revokeOpKeys(pm, entityID, toRevokeOpKeys)
+			})
+			continue
+		}
+
+		nRevoked, nFailed := revokeOpKeys(pm, entity.GetID(), toRevokeOpKeys)
+		result.NRevokedOpKey += nRevoked
+		result.NFailed += nFailed
+	}
+
+	return result, nil
+}
+
+func newOpKeyRevokeList(pm ProtocolManager, oldOpKeys []*KeyInfo) []*KeyInfo {
+	newestOpKey, err := pm.GetNewestOpKey(false)
+	if err != nil {
+		return nil
+	}
+
+	return opKeysToRevoke(oldOpKeys, newestOpKey)
+}
+
+/*
+opKeysToRevoke returns the old op-keys to be revoked if the newest op-key is a valid new op-key,
+nil if the new op-key is not valid yet (the newest op-key is still one of the old op-keys).
+*/
+func opKeysToRevoke(oldOpKeys []*KeyInfo, newestOpKey *KeyInfo) []*KeyInfo {
+	if newestOpKey == nil || newestOpKey.Status != types.StatusAlive {
+		return nil
+	}
+
+	for _, opKey := range oldOpKeys {
+		if reflect.DeepEqual(opKey.ID, newestOpKey.ID) {
+			return nil
+		}
+	}
+
+	return oldOpKeys
+}
+
+func revokeOpKeys(pm ProtocolManager, entityID *types.PttID, opKeys []*KeyInfo) (nRevoked int, nFailed int) {
+	for _, opKey := range opKeys {
+		_, err := pm.RevokeOpKey(opKey.ID)
+		if err != nil {
+			log.Warn("RotateAllKeys: unable to RevokeOpKey", "entity", entityID, "key", opKey.ID, "e", err)
+			nFailed++
+			continue
+		}
+		nRevoked++
+	}
+
+	return
+}
+
+func (p *BasePtt) entityList() []Entity {
+	p.entityLock.RLock()
+	defer p.entityLock.RUnlock()
+
+	entities := make([]Entity, 0, len(p.entities))
+	for _, entity := range p.entities {
+		entities = append(entities, entity)
+	}
+
+	return entities
+}
+
+func keyInfoToBackendKeyReport(keyInfo *KeyInfo, entityID *types.PttID, serviceName string, keyType string, expireSeconds int64, now types.Timestamp) *BackendKeyReport {
+	report := &BackendKeyReport{
+		EntityID:    entityID,
+		ServiceName: serviceName,
+		KeyType:     keyType,
+	}
+	if keyInfo == nil {
+		return report
+	}
+
+	report.KeyID = keyInfo.ID
+	report.Hash = keyInfo.Hash
+	report.CreateTS = keyInfo.UpdateTS
+	report.AgeSeconds = now.Ts - keyInfo.UpdateTS.Ts
+	report.Count = keyInfo.Count
+
+	if expireSeconds > 0 {
+		report.ExpireTS = types.Timestamp{Ts: keyInfo.UpdateTS.Ts + expireSeconds, NanoTs: keyInfo.UpdateTS.NanoTs}
+		report.IsExpired = report.ExpireTS.IsLess(now)
+	}
+
+	return report
+}
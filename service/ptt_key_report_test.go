@@ -0,0 +1,56 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+func TestOpKeysToRevoke(t *testing.T) {
+	// prepare test-cases
+	oldID := &types.PttID{1}
+	newID := &types.PttID{2}
+
+	oldOpKey := &KeyInfo{BaseObject: &BaseObject{ID: oldID, Status: types.StatusAlive}}
+	newOpKey := &KeyInfo{BaseObject: &BaseObject{ID: newID, Status: types.StatusAlive}}
+	pendingOpKey := &KeyInfo{BaseObject: &BaseObject{ID: newID, Status: types.StatusInternalPending}}
+
+	oldOpKeys := []*KeyInfo{oldOpKey}
+
+	tests := []struct {
+		name        string
+		newestOpKey *KeyInfo
+		want        []*KeyInfo
+	}{
+		{"no newest op-key", nil, nil},
+		{"new op-key not registered yet", oldOpKey, nil},
+		{"new op-key pending", pendingOpKey, nil},
+		{"new op-key valid", newOpKey, oldOpKeys},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := opKeysToRevoke(oldOpKeys, tt.newestOpKey); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("opKeysToRevoke() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
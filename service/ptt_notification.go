@@ -0,0 +1,161 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/pttdb"
+)
+
+/*
+CreateNotification creates the local notification. The existing notification of the same event is kept as it is.
+*/
+func (p *BasePtt) CreateNotification(theType NotificationType, fromID *types.PttID, entityID *types.PttID, objID *types.PttID, subObjID *types.PttID) error {
+	if dbMeta == nil {
+		return nil
+	}
+
+	if p.myEntity != nil && reflect.DeepEqual(fromID, p.myEntity.GetID()) {
+		return nil
+	}
+
+	if !LoadNotifySetting(entityID).IsNotify(theType == NotificationTypeMention) {
+		return nil
+	}
+
+	n, err := NewNotification(theType, fromID, entityID, objID, subObjID)
+	if err != nil {
+		return err
+	}
+
+	p.lockNotification.Lock()
+	defer p.lockNotification.Unlock()
+
+	origN := &Notification{}
+	err = origN.Get(n.ID)
+	if err == nil {
+		return nil
+	}
+
+	idxKey, err := n.MarshalIdxKey()
+	if err != nil {
+		return err
+	}
+
+	err = n.Save()
+	if err != nil {
+		return err
+	}
+
+	err = dbMeta.Put(idxKey, n.ID[:])
+	if err != nil {
+		return err
+	}
+
+	log.Debug("CreateNotification: done", "type", theType, "from", fromID, "entity", entityID)
+
+	return nil
+}
+
+/*
+GetNotifications lists the notifications by the create-ts, starting from the notification of the startID.
+*/
+func (p *BasePtt) GetNotifications(startIDBytes []byte, limit int, listOrder pttdb.ListOrder) ([]*Notification, error) {
+	startID, err := types.UnmarshalTextPttID(startIDBytes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var startKey []byte
+	if startID != nil {
+		startN := &Notification{}
+		err = startN.Get(startID)
+		if err != nil {
+			return nil, err
+		}
+
+		startKey, err = startN.MarshalIdxKey()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	iter, err := dbMeta.NewIteratorWithPrefix(startKey, DBNotificationIdxPrefix, listOrder)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	iterFunc := pttdb.GetFuncIter(iter, listOrder)
+
+	notifications := make([]*Notification, 0)
+	for iterFunc() {
+		if limit > 0 && len(notifications) >= limit {
+			break
+		}
+
+		id := &types.PttID{}
+		err = id.Unmarshal(iter.Value())
+		if err != nil {
+			continue
+		}
+
+		n := &Notification{}
+		err = n.Get(id)
+		if err != nil {
+			continue
+		}
+
+		notifications = append(notifications, n)
+	}
+
+	return notifications, nil
+}
+
+/*
+MarkNotificationRead marks the notification as read.
+*/
+func (p *BasePtt) MarkNotificationRead(idBytes []byte) (bool, error) {
+	id, err := types.UnmarshalTextPttID(idBytes, false)
+	if err != nil {
+		return false, err
+	}
+
+	p.lockNotification.Lock()
+	defer p.lockNotification.Unlock()
+
+	n := &Notification{}
+	err = n.Get(id)
+	if err != nil {
+		return false, err
+	}
+
+	if n.IsRead {
+		return true, nil
+	}
+
+	n.IsRead = true
+	err = n.Save()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
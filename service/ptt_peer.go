@@ -42,6 +42,9 @@ type PttPeer struct {
 
 	UserID *types.PttID
 
+	// the peer opts out of broadcasting the presence.
+	IsHidePresence bool
+
 	lockID      sync.Mutex
 	IDEntityID  *types.PttID
 	IDChallenge *types.Salt
@@ -38,6 +38,8 @@ type PttPeerSet struct {
 
 	peerList []*PttPeer
 
+	isBlockedUser func(id *types.PttID) bool
+
 	lock   sync.RWMutex
 	closed bool
 }
@@ -60,6 +62,13 @@ func NewPttPeerSet() (*PttPeerSet, error) {
 	}, nil
 }
 
+/*
+SetIsBlockedUser sets the blocklist checker. The peers of the blocked users are registered only as the members.
+*/
+func (ps *PttPeerSet) SetIsBlockedUser(isBlockedUser func(id *types.PttID) bool) {
+	ps.isBlockedUser = isBlockedUser
+}
+
 func (ps *PttPeerSet) MePeers(isLocked bool) map[discover.NodeID]*PttPeer {
 	if !isLocked {
 		ps.RLock()
@@ -106,6 +115,11 @@ func (ps *PttPeerSet) Register(peer *PttPeer, peerType PeerType, isLocked bool)
 		return ErrClosed
 	}
 
+	// blocklist: the blocked users are accepted only as the members of the shared entities.
+	if peerType < PeerTypeMember && ps.isBlockedUser != nil && ps.isBlockedUser(peer.UserID) {
+		return ErrBlocked
+	}
+
 	id := peer.ID()
 	pid := peer.GetID()
 	origPeerType, ok := ps.peerTypes[id]
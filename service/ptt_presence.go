@@ -0,0 +1,145 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+)
+
+/*
+Presence is the last-online of the user, updated when the peer of the user is identified and when the peer is removed.
+The users opting out of broadcasting the presence (HidePresence) are not tracked.
+*/
+type Presence struct {
+	V            types.Version
+	ID           *types.PttID    `json:"ID"`
+	LastOnlineTS types.Timestamp `json:"LT"`
+}
+
+func marshalPresenceKey(id *types.PttID) ([]byte, error) {
+	return common.Concat([][]byte{DBPresencePrefix, id[:]})
+}
+
+/*
+setPresence sets the last-online of the user of the peer.
+*/
+func (p *BasePtt) setPresence(peer *PttPeer) {
+	if peer.UserID == nil || peer.IsHidePresence || dbMeta == nil {
+		return
+	}
+
+	if p.myEntity != nil && reflect.DeepEqual(peer.UserID, p.myEntity.GetID()) {
+		return
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return
+	}
+
+	presence := &Presence{
+		V:            types.CurrentVersion,
+		ID:           peer.UserID,
+		LastOnlineTS: ts,
+	}
+
+	err = presence.Save()
+	if err != nil {
+		log.Warn("setPresence: unable to save", "userID", peer.UserID, "e", err)
+	}
+}
+
+func (p *Presence) Save() error {
+	key, err := marshalPresenceKey(p.ID)
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	return dbMeta.Put(key, marshaled)
+}
+
+func (p *Presence) Get(id *types.PttID) error {
+	key, err := marshalPresenceKey(id)
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := dbMeta.Get(key)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(marshaled, p)
+}
+
+/*
+GetPresence gets whether the user is online (with an identified peer not hiding the presence) and the last-online of the user.
+*/
+func (p *BasePtt) GetPresence(id *types.PttID) (bool, types.Timestamp) {
+	if id == nil || dbMeta == nil {
+		return false, types.ZeroTimestamp
+	}
+
+	peer, err := p.GetPeerByUserID(id, false)
+	isOnline := err == nil && !peer.IsHidePresence
+
+	presence := &Presence{}
+	err = presence.Get(id)
+	if err != nil {
+		return isOnline, types.ZeroTimestamp
+	}
+
+	return isOnline, presence.LastOnlineTS
+}
+
+/*
+LoadHidePresence loads whether I opt out of broadcasting my presence.
+*/
+func LoadHidePresence() bool {
+	if dbMeta == nil {
+		return false
+	}
+
+	value, err := dbMeta.Get(DBHidePresencePrefix)
+	if err != nil || len(value) == 0 {
+		return false
+	}
+
+	return value[0] != 0
+}
+
+/*
+SetHidePresence sets whether I opt out of broadcasting my presence. Effective with the newly identified peers.
+*/
+func SetHidePresence(isHide bool) error {
+	value := []byte{0}
+	if isHide {
+		value[0] = 1
+	}
+
+	return dbMeta.Put(DBHidePresencePrefix, value)
+}
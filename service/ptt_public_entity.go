@@ -0,0 +1,370 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/p2p/discover"
+	"github.com/ailabstw/go-pttai/p2p/discv5"
+)
+
+/*
+PublicEntity is the entry of the public-entity directory.
+*/
+type PublicEntity struct {
+	ID       *types.PttID     `json:"ID"`
+	Service  string           `json:"S"`
+	Title    []byte           `json:"T,omitempty"`
+	NodeID   *discover.NodeID `json:"NID,omitempty"`
+	UpdateTS types.Timestamp  `json:"UT"`
+}
+
+/*
+PublicEntityTitler is implemented by the entities providing the title in the public-entity directory.
+*/
+type PublicEntityTitler interface {
+	PublicTitle() []byte
+}
+
+type RequestPublicEntities struct{}
+
+type PublicEntities struct {
+	Entities []*PublicEntity `json:"E"`
+}
+
+func PublicEntityTopic(entityID *types.PttID) discv5.Topic {
+	return discv5.Topic(PublicEntityTopicPrefix + entityID.String())
+}
+
+/**********
+ * Loop
+ **********/
+
+/*
+PublicEntityLoop announces the public entities through the discv5 topics,
+and collects the public entities from the peers already connected.
+
+The nodes of the public entities are dialed only when the user opens or joins the entities.
+*/
+func (p *BasePtt) PublicEntityLoop() error {
+	ticker := time.NewTicker(PublicEntityTickTime)
+	defer ticker.Stop()
+
+	p.publicEntityCore()
+
+loop:
+	for {
+		select {
+		case <-ticker.C:
+			p.publicEntityCore()
+		case <-p.quitSync:
+			log.Debug("PublicEntityLoop: QuitSync")
+			break loop
+		}
+	}
+
+	p.lockPublicEntities.Lock()
+	defer p.lockPublicEntities.Unlock()
+	for entityID, stop := range p.publicTopics {
+		close(stop)
+		delete(p.publicTopics, entityID)
+	}
+
+	return nil
+}
+
+func (p *BasePtt) discV5() *discv5.Network {
+	if p.server == nil {
+		return nil
+	}
+	return p.server.DiscV5
+}
+
+func (p *BasePtt) publicEntityCore() {
+	p.registerPublicTopics()
+
+	p.expirePublicEntities()
+
+	p.requestPublicEntities()
+}
+
+/*
+registerPublicTopics registers the topics of the alive public entities
+and unregisters the topics of the entities no longer public or alive.
+*/
+func (p *BasePtt) registerPublicTopics() {
+	discV5 := p.discV5()
+	if discV5 == nil {
+		return
+	}
+
+	entities := p.getLocalPublicEntities()
+
+	p.lockPublicEntities.Lock()
+	defer p.lockPublicEntities.Unlock()
+
+	for _, entity := range entities {
+		if _, ok := p.publicTopics[*entity.ID]; ok {
+			continue
+		}
+		stop := make(chan struct{})
+		p.publicTopics[*entity.ID] = stop
+		go discV5.RegisterTopic(PublicEntityTopic(entity.ID), stop)
+	}
+
+	for entityID, stop := range p.publicTopics {
+		isAlive := false
+		for _, entity := range entities {
+			if *entity.ID == entityID {
+				isAlive = true
+				break
+			}
+		}
+		if isAlive {
+			continue
+		}
+		close(stop)
+		delete(p.publicTopics, entityID)
+	}
+}
+
+func (p *BasePtt) expirePublicEntities() {
+	now, err := types.GetTimestamp()
+	if err != nil {
+		return
+	}
+	expireTS := now
+	expireTS.Ts -= int64(PublicEntityExpireTime / time.Second)
+
+	p.lockPublicEntities.Lock()
+	defer p.lockPublicEntities.Unlock()
+
+	for entityID, entity := range p.publicEntities {
+		if entity.UpdateTS.IsLess(expireTS) {
+			delete(p.publicEntities, entityID)
+		}
+	}
+}
+
+/*
+requestPublicEntities requests the public entities from the identified peers already connected.
+*/
+func (p *BasePtt) requestPublicEntities() {
+	peers := p.getPublicEntityPeers()
+
+	for _, peer := range peers {
+		p.SendDataToPeer(CodeTypeRequestPublicEntities, &RequestPublicEntities{}, peer)
+	}
+}
+
+func (p *BasePtt) getPublicEntityPeers() []*PttPeer {
+	p.peerLock.RLock()
+	defer p.peerLock.RUnlock()
+
+	peers := make([]*PttPeer, 0, len(p.myPeers)+len(p.importantPeers)+len(p.memberPeers))
+	for _, eachPeers := range []map[discover.NodeID]*PttPeer{p.myPeers, p.importantPeers, p.memberPeers} {
+		for _, peer := range eachPeers {
+			if peer.UserID == nil {
+				continue
+			}
+			peers = append(peers, peer)
+		}
+	}
+
+	return peers
+}
+
+/**********
+ * Request / Handle
+ **********/
+
+func (p *BasePtt) HandleRequestPublicEntities(dataBytes []byte, peer *PttPeer) error {
+	data := &PublicEntities{
+		Entities: p.getLocalPublicEntities(),
+	}
+
+	return p.SendDataToPeer(CodeTypePublicEntities, data, peer)
+}
+
+/*
+HandlePublicEntities handles the public entities announced by the peer.
+
+The announced list replaces the entries of the peer, and only the entities owned by the peer are accepted,
+limited to MaxPublicEntitiesPerPeer per peer and MaxPublicEntities in total.
+*/
+func (p *BasePtt) HandlePublicEntities(dataBytes []byte, peer *PttPeer) error {
+	data := &PublicEntities{}
+	err := json.Unmarshal(dataBytes, data)
+	if err != nil {
+		return err
+	}
+
+	if peer.UserID == nil {
+		return nil
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return err
+	}
+
+	nodeID := peer.GetID()
+
+	entities := make([]*PublicEntity, 0, len(data.Entities))
+	p.entityLock.RLock()
+	for _, entity := range data.Entities {
+		if len(entities) >= MaxPublicEntitiesPerPeer {
+			break
+		}
+		if entity.ID == nil || !isPublicEntityOwner(entity.ID, peer.UserID) {
+			continue
+		}
+		if _, ok := p.entities[*entity.ID]; ok {
+			continue
+		}
+		entities = append(entities, entity)
+	}
+	p.entityLock.RUnlock()
+
+	p.lockPublicEntities.Lock()
+	defer p.lockPublicEntities.Unlock()
+
+	for entityID, entity := range p.publicEntities {
+		if reflect.DeepEqual(entity.NodeID, nodeID) {
+			delete(p.publicEntities, entityID)
+		}
+	}
+
+	for _, entity := range entities {
+		if len(p.publicEntities) >= MaxPublicEntities {
+			break
+		}
+		entity.NodeID = nodeID
+		entity.UpdateTS = ts
+		p.publicEntities[*entity.ID] = entity
+	}
+
+	return nil
+}
+
+/*
+isPublicEntityOwner checks whether the entity is created by the user, as the entity-id is postfixed with the creator-id.
+*/
+func isPublicEntityOwner(entityID *types.PttID, userID *types.PttID) bool {
+	return bytes.Equal(entityID[types.SizePttID-common.AddressLength:], userID[:common.AddressLength])
+}
+
+/**********
+ * Get
+ **********/
+
+func (p *BasePtt) getLocalPublicEntities() []*PublicEntity {
+	p.entityLock.RLock()
+	defer p.entityLock.RUnlock()
+
+	entities := make([]*PublicEntity, 0)
+	for _, entity := range p.entities {
+		if entity.GetStatus() != types.StatusAlive {
+			continue
+		}
+
+		pm := entity.PM()
+		if pm == nil || !pm.IsPublic() {
+			continue
+		}
+
+		publicEntity := &PublicEntity{
+			ID:       entity.GetID(),
+			Service:  entity.Service().Name(),
+			UpdateTS: entity.GetUpdateTS(),
+		}
+		if titler, ok := entity.(PublicEntityTitler); ok {
+			publicEntity.Title = titler.PublicTitle()
+		}
+
+		entities = append(entities, publicEntity)
+	}
+
+	return entities
+}
+
+/*
+GetPublicEntities returns the public entities of the service collected from the directory.
+*/
+func (p *BasePtt) GetPublicEntities(serviceName string) []*PublicEntity {
+	p.lockPublicEntities.RLock()
+	defer p.lockPublicEntities.RUnlock()
+
+	entities := make([]*PublicEntity, 0, len(p.publicEntities))
+	for _, entity := range p.publicEntities {
+		if serviceName != "" && entity.Service != serviceName {
+			continue
+		}
+		entities = append(entities, entity)
+	}
+
+	return entities
+}
+
+/*
+GetPublicEntityNodeID returns the node serving the public entity.
+The directory is looked up first, then the topic of the entity is searched through discv5.
+*/
+func (p *BasePtt) GetPublicEntityNodeID(entityID *types.PttID) (*discover.NodeID, error) {
+	p.lockPublicEntities.RLock()
+	entity, ok := p.publicEntities[*entityID]
+	p.lockPublicEntities.RUnlock()
+	if ok {
+		return entity.NodeID, nil
+	}
+
+	discV5 := p.discV5()
+	if discV5 == nil {
+		return nil, types.ErrInvalidID
+	}
+
+	found := make(chan *discv5.Node, 1)
+	setPeriod := make(chan time.Duration, 1)
+	setPeriod <- PublicEntitySearchPeriod
+	go discV5.SearchTopic(PublicEntityTopic(entityID), setPeriod, found, nil)
+	defer close(setPeriod)
+
+	timer := time.NewTimer(PublicEntityFindTimeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case node := <-found:
+			nodeID := discover.NodeID(node.ID)
+			if nodeID == *p.myNodeID {
+				continue
+			}
+			return &nodeID, nil
+		case <-timer.C:
+			return nil, types.ErrInvalidID
+		case <-p.quitSync:
+			return nil, types.ErrInvalidID
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2018 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/p2p"
+	"github.com/ailabstw/go-pttai/p2p/discover"
+)
+
+func tNewPublicEntityPeer(t *testing.T, nodeID discover.NodeID) *PttPeer {
+	userID, err := types.NewPttID()
+	if err != nil {
+		t.Fatalf("NewPttID() error = %v", err)
+	}
+
+	return &PttPeer{
+		Peer:   p2p.NewPeer(nodeID, "test", nil),
+		UserID: userID,
+	}
+}
+
+func tNewPublicEntities(t *testing.T, ownerID *types.PttID, n int) []*PublicEntity {
+	entities := make([]*PublicEntity, n)
+	for i := range entities {
+		entityID, err := types.NewPttIDWithPostifx(ownerID[:common.AddressLength])
+		if err != nil {
+			t.Fatalf("NewPttIDWithPostifx() error = %v", err)
+		}
+		entities[i] = &PublicEntity{ID: entityID, Service: "content"}
+	}
+	return entities
+}
+
+func tHandlePublicEntities(t *testing.T, p *BasePtt, peer *PttPeer, entities []*PublicEntity) {
+	dataBytes, err := json.Marshal(&PublicEntities{Entities: entities})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	err = p.HandlePublicEntities(dataBytes, peer)
+	if err != nil {
+		t.Fatalf("HandlePublicEntities() error = %v", err)
+	}
+}
+
+func TestIsPublicEntityOwner(t *testing.T) {
+	userID, _ := types.NewPttID()
+	otherID, _ := types.NewPttID()
+	entityID, _ := types.NewPttIDWithPostifx(userID[:common.AddressLength])
+
+	// prepare test-cases
+	tests := []struct {
+		name     string
+		entityID *types.PttID
+		userID   *types.PttID
+		want     bool
+	}{
+		{
+			name:     "owner",
+			entityID: entityID,
+			userID:   userID,
+			want:     true,
+		},
+		{
+			name:     "not owner",
+			entityID: entityID,
+			userID:   otherID,
+			want:     false,
+		},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isPublicEntityOwner(tt.entityID, tt.userID); got != tt.want {
+				t.Errorf("isPublicEntityOwner() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBasePtt_HandlePublicEntities(t *testing.T) {
+	p := &BasePtt{
+		entities:       make(map[types.PttID]Entity),
+		publicEntities: make(map[types.PttID]*PublicEntity),
+	}
+
+	peer := tNewPublicEntityPeer(t, discover.NodeID{1})
+	peer2 := tNewPublicEntityPeer(t, discover.NodeID{2})
+
+	// owner filtering
+	owned := tNewPublicEntities(t, peer.UserID, 2)
+	others := tNewPublicEntities(t, peer2.UserID, 1)
+	tHandlePublicEntities(t, p, peer, append(owned, others...))
+
+	if len(p.publicEntities) != 2 {
+		t.Errorf("HandlePublicEntities() owner: len = %v, want 2", len(p.publicEntities))
+	}
+	if _, ok := p.publicEntities[*others[0].ID]; ok {
+		t.Errorf("HandlePublicEntities() owner: accepted the entity not owned by the peer")
+	}
+
+	// replacement
+	replaced := tNewPublicEntities(t, peer.UserID, 1)
+	tHandlePublicEntities(t, p, peer, replaced)
+
+	if len(p.publicEntities) != 1 {
+		t.Errorf("HandlePublicEntities() replace: len = %v, want 1", len(p.publicEntities))
+	}
+	if _, ok := p.publicEntities[*replaced[0].ID]; !ok {
+		t.Errorf("HandlePublicEntities() replace: missing the new entity")
+	}
+
+	// per-peer cap
+	tHandlePublicEntities(t, p, peer, tNewPublicEntities(t, peer.UserID, MaxPublicEntitiesPerPeer+10))
+
+	if len(p.publicEntities) != MaxPublicEntitiesPerPeer {
+		t.Errorf("HandlePublicEntities() per-peer: len = %v, want %v", len(p.publicEntities), MaxPublicEntitiesPerPeer)
+	}
+
+	// total cap
+	for len(p.publicEntities) < MaxPublicEntities-1 {
+		entityID, _ := types.NewPttID()
+		p.publicEntities[*entityID] = &PublicEntity{ID: entityID, NodeID: &discover.NodeID{3}}
+	}
+	tHandlePublicEntities(t, p, peer2, tNewPublicEntities(t, peer2.UserID, 5))
+
+	if len(p.publicEntities) != MaxPublicEntities {
+		t.Errorf("HandlePublicEntities() total: len = %v, want %v", len(p.publicEntities), MaxPublicEntities)
+	}
+
+	// the unidentified peer
+	unidentified := &PttPeer{Peer: p2p.NewPeer(discover.NodeID{4}, "test", nil)}
+	err := p.HandlePublicEntities([]byte(`{"E":[]}`), unidentified)
+	if err != nil {
+		t.Errorf("HandlePublicEntities() unidentified: error = %v", err)
+	}
+}
@@ -270,6 +270,13 @@ func (p *BasePtt) UnregisterEntity(e Entity, isLocked bool) error {
 	return nil
 }
 
+func (p *BasePtt) GetEntity(id *types.PttID) Entity {
+	p.entityLock.RLock()
+	defer p.entityLock.RUnlock()
+
+	return p.entities[*id]
+}
+
 func (p *BasePtt) GetEntities() map[types.PttID]Entity {
 	return p.entities
 }
@@ -105,6 +105,11 @@ func (p *BasePtt) HandleMessage(code CodeType, data *PttData, peer *PttPeer) err
 	case CodeTypeOpCheckMemberAck:
 		err = p.HandleCodeOpCheckMemberAck(evHash, encData, peer)
 
+	case CodeTypeRequestPublicEntities:
+		err = p.HandleCodeRequestPublicEntities(evHash, encData, peer)
+	case CodeTypePublicEntities:
+		err = p.HandleCodePublicEntities(evHash, encData, peer)
+
 	case CodeTypeIdentifyPeer:
 		err = p.HandleCodeIdentifyPeer(evHash, encData, peer)
 	case CodeTypeIdentifyPeerFail:
@@ -143,6 +148,17 @@ func (p *BasePtt) HandleCodeJoin(hash *common.Address, encData []byte, peer *Ptt
 		return err
 	}
 
+	if peer.UserID != nil && pm.IsBanned(peer.UserID) {
+		log.Error("HandleCodeJoin: banned", "hash", hash, "userID", peer.UserID)
+		return ErrBanned
+	}
+
+	err = pm.ValidateInvite(hash, nil)
+	if err != nil {
+		log.Error("HandleCodeJoin: invalid invite", "hash", hash, "e", err)
+		return err
+	}
+
 	op, dataBytes, err := p.DecryptData(encData, keyInfo)
 	if err != nil {
 		log.Error("HandleCodeJoin: unable to DecryptData", "e", err)
@@ -239,6 +255,14 @@ func (p *BasePtt) HandleCodeOpCheckMemberAck(hash *common.Address, encData []byt
 	return p.HandleOpCheckMemberAck(encData, peer)
 }
 
+func (p *BasePtt) HandleCodeRequestPublicEntities(hash *common.Address, encData []byte, peer *PttPeer) error {
+	return p.HandleRequestPublicEntities(encData, peer)
+}
+
+func (p *BasePtt) HandleCodePublicEntities(hash *common.Address, encData []byte, peer *PttPeer) error {
+	return p.HandlePublicEntities(encData, peer)
+}
+
 func (p *BasePtt) HandleCodeIdentifyPeer(hash *common.Address, encData []byte, peer *PttPeer) error {
 
 	entity, err := p.getEntityFromHash(hash, &p.lockOps, p.ops)
@@ -138,7 +138,14 @@ func (p *BasePtt) FinishIdentifyPeer(peer *PttPeer, isLocked bool, isResetPeerTy
 
 	log.Debug("FinishIdentifyPeer: to SetupPeer", "peer", peer, "peerType", peerType)
 
-	return p.SetupPeer(peer, peerType, true)
+	err = p.SetupPeer(peer, peerType, true)
+	if err != nil {
+		return err
+	}
+
+	p.setPresence(peer)
+
+	return nil
 }
 
 func (p *BasePtt) determinePeerTypeFromAllEntities(peer *PttPeer, isLocked bool) (PeerType, error) {
@@ -269,6 +276,8 @@ func (p *BasePtt) RemovePeer(peer *PttPeer, isLocked bool) error {
 		log.Error("unable to remove peer", "peer", peer, "e", err)
 	}
 
+	p.setPresence(registeredPeer)
+
 	peer.Peer.Disconnect(p2p.DiscUselessPeer)
 
 	return err
@@ -101,6 +101,15 @@ const (
 	EntityTypePublic
 )
 
+// SeenType is the type of the last-seen synced across my nodes.
+type SeenType uint8
+
+const (
+	SeenTypeBoard SeenType = iota
+	SeenTypeArticle
+	SeenTypeFriend
+)
+
 // SignInfo
 
 type SignInfo struct {